@@ -11,14 +11,14 @@ import (
 type settingsDto struct {
 	Host      *HostSettings
 	Template  *TemplateSettings
-	Validator *configuration.HostSettings
+	Validator *ValidatorSettings
 	Log       *configuration.LogSettings
 }
 
 type Settings struct {
 	host      *HostSettings
 	template  *TemplateSettings
-	validator *configuration.HostSettings
+	validator *ValidatorSettings
 	log       *configuration.LogSettings
 }
 
@@ -62,7 +62,7 @@ func (settings *Settings) Template() *TemplateSettings {
 	return settings.template
 }
 
-func (settings *Settings) Validator() *configuration.HostSettings {
+func (settings *Settings) Validator() *ValidatorSettings {
 	return settings.validator
 }
 
@@ -0,0 +1,59 @@
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/configuration"
+)
+
+type validatorSettingsDto struct {
+	IdentityKey string
+	Ip          string
+	Port        int
+	Transport   string
+}
+
+type ValidatorSettings struct {
+	identityKey string
+	ip          string
+	port        string
+	transport   string
+}
+
+func (settings *ValidatorSettings) UnmarshalJSON(data []byte) error {
+	var dto *validatorSettingsDto
+	err := json.Unmarshal(data, &dto)
+	if err != nil {
+		return err
+	}
+	settings.identityKey = dto.IdentityKey
+	settings.ip = dto.Ip
+	settings.port = strconv.Itoa(dto.Port)
+	switch dto.Transport {
+	case "":
+		settings.transport = configuration.TcpTransport
+	case configuration.TcpTransport, configuration.TlsTransport:
+		settings.transport = dto.Transport
+	default:
+		return fmt.Errorf("unknown transport %s", dto.Transport)
+	}
+	return nil
+}
+
+func (settings *ValidatorSettings) IdentityKey() string {
+	return settings.identityKey
+}
+
+func (settings *ValidatorSettings) Ip() string {
+	return settings.ip
+}
+
+func (settings *ValidatorSettings) Port() string {
+	return settings.port
+}
+
+func (settings *ValidatorSettings) Transport() string {
+	return settings.transport
+}
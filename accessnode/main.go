@@ -5,39 +5,75 @@ import (
 	"flag"
 	"fmt"
 	"github.com/my-cloud/ruthenium/accessnode/infrastructure/configuration"
+	"net"
+	"os"
 	"time"
 
 	"github.com/my-cloud/ruthenium/accessnode/presentation"
 	"github.com/my-cloud/ruthenium/validatornode/domain/clock"
 	validatorconfiguration "github.com/my-cloud/ruthenium/validatornode/infrastructure/configuration"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/environment"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log/console"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log/structured"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/p2p"
 )
 
+// version is set at build time with -ldflags "-X main.version=<version>"
+var version = "dev"
+
+type fatalLogger interface {
+	log.Logger
+	Fatal(msg string)
+}
+
 func main() {
 	settingsPath := flag.String("settings-path", environment.NewVariable("SETTINGS_PATH").GetStringValue("accessnode/settings.json"), "The settings file path")
 	flag.Parse()
 	settings, err := configuration.NewSettings(*settingsPath)
 	if err != nil {
-		panic(err.Error())
+		console.NewFatalLogger().Fatal(err.Error())
+		os.Exit(1)
+	}
+	logger := newLogger(settings.Log())
+	var trustStore *p2p.TrustStore
+	if settings.Validator().Transport() == validatorconfiguration.TlsTransport {
+		trustedIdentityKeys := map[string]string{}
+		if settings.Validator().IdentityKey() != "" {
+			trustedIdentityKeys[net.JoinHostPort(settings.Validator().Ip(), settings.Validator().Port())] = settings.Validator().IdentityKey()
+		}
+		trustStore, err = p2p.NewTrustStore("", trustedIdentityKeys)
+		if err != nil {
+			logger.Fatal(err.Error())
+			os.Exit(1)
+		}
 	}
-	logger := console.NewLogger(settings.Log().Level())
-	validatorNeighbor, err := p2p.NewNeighbor(settings.Validator().Ip(), settings.Validator().Port(), time.Minute, console.NewFatalLogger())
+	validatorNeighbor, err := p2p.NewNeighbor(settings.Validator().Ip(), settings.Validator().Port(), time.Minute, trustStore)
 	if err != nil {
 		logger.Fatal(fmt.Errorf("unable to find blockchain client: %w", err).Error())
+		os.Exit(1)
 	}
 	settingsBytes, err := validatorNeighbor.GetSettings()
 	if err != nil {
 		logger.Fatal(fmt.Errorf("unable to get protocol settings: %w", err).Error())
+		os.Exit(1)
 	}
 	var protocolSettings *validatorconfiguration.ProtocolSettings
 	err = json.Unmarshal(settingsBytes, &protocolSettings)
 	if err != nil {
 		logger.Fatal(fmt.Errorf("unable to unmarshal protocol settings: %w", err).Error())
+		os.Exit(1)
 	}
 	watch := clock.NewWatch()
-	node := presentation.NewNode(settings.Host().Port(), validatorNeighbor, protocolSettings, settings.Template().Path(), watch, logger)
+	node := presentation.NewNode(settings.Host().Port(), validatorNeighbor, protocolSettings, settings.Template().Path(), version, watch, logger)
 	logger.Info("host access node is running...")
 	logger.Fatal(node.Run().Error())
+	os.Exit(1)
+}
+
+func newLogger(settings *validatorconfiguration.LogSettings) fatalLogger {
+	if settings.Format() == validatorconfiguration.ConsoleLogFormat {
+		return console.NewLogger(settings.Level())
+	}
+	return structured.NewLogger(settings.Level(), settings.Format(), os.Stderr, clock.NewWatch())
 }
@@ -0,0 +1,44 @@
+package health
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/my-cloud/ruthenium/accessnode/infrastructure/io"
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+)
+
+type livenessDto struct {
+	IsAlive bool `json:"is_alive"`
+}
+
+type readinessDto struct {
+	IsReady bool     `json:"is_ready"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+type HealthController struct {
+	sender application.Sender
+	logger log.Logger
+}
+
+func NewHealthController(sender application.Sender, logger log.Logger) *HealthController {
+	return &HealthController{sender, logger}
+}
+
+func (controller *HealthController) GetLiveness(writer http.ResponseWriter, _ *http.Request) {
+	response := io.NewResponse(writer, controller.logger)
+	response.WriteJson(http.StatusOK, &livenessDto{IsAlive: true})
+}
+
+func (controller *HealthController) GetReadiness(writer http.ResponseWriter, _ *http.Request) {
+	response := io.NewResponse(writer, controller.logger)
+	if _, err := controller.sender.GetTip(); err != nil {
+		reason := fmt.Errorf("failed to reach validator node: %w", err).Error()
+		controller.logger.Debug(reason)
+		response.WriteJson(http.StatusServiceUnavailable, &readinessDto{Reasons: []string{reason}})
+		return
+	}
+	response.WriteJson(http.StatusOK, &readinessDto{IsReady: true})
+}
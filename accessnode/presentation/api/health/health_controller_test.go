@@ -0,0 +1,59 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_GetLiveness_NodeRunning_ReturnsOk(t *testing.T) {
+	// Arrange
+	controller := NewHealthController(new(application.SenderMock), log.NewLoggerMock())
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	// Act
+	controller.GetLiveness(recorder, request)
+
+	// Assert
+	expectedStatusCode := 200
+	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
+}
+
+func Test_GetReadiness_ValidatorUnreachable_ReturnsServiceUnavailable(t *testing.T) {
+	// Arrange
+	senderMock := new(application.SenderMock)
+	senderMock.GetTipFunc = func() ([]byte, error) { return nil, errors.New("") }
+	controller := NewHealthController(senderMock, log.NewLoggerMock())
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	// Act
+	controller.GetReadiness(recorder, request)
+
+	// Assert
+	expectedStatusCode := 503
+	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
+}
+
+func Test_GetReadiness_ValidatorReachable_ReturnsOk(t *testing.T) {
+	// Arrange
+	senderMock := new(application.SenderMock)
+	senderMock.GetTipFunc = func() ([]byte, error) { return []byte("{}"), nil }
+	controller := NewHealthController(senderMock, log.NewLoggerMock())
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	// Act
+	controller.GetReadiness(recorder, request)
+
+	// Assert
+	expectedStatusCode := 200
+	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
+}
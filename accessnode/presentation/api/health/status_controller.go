@@ -0,0 +1,84 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/my-cloud/ruthenium/accessnode/infrastructure/io"
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+)
+
+type statusDto struct {
+	Version         string                   `json:"version"`
+	ValidatorTarget string                   `json:"validator_target"`
+	Tip             *ledger.BlockHeader      `json:"tip"`
+	Neighbors       []*ledger.NeighborStatus `json:"neighbors"`
+	PoolSize        int                      `json:"pool_size"`
+}
+
+type StatusController struct {
+	sender  application.Sender
+	version string
+	logger  log.Logger
+}
+
+func NewStatusController(sender application.Sender, version string, logger log.Logger) *StatusController {
+	return &StatusController{sender, version, logger}
+}
+
+func (controller *StatusController) GetStatus(writer http.ResponseWriter, _ *http.Request) {
+	response := io.NewResponse(writer, controller.logger)
+	tipBytes, err := controller.sender.GetTip()
+	if err != nil {
+		errorMessage := "failed to get tip"
+		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
+		response.Write(http.StatusInternalServerError, errorMessage)
+		return
+	}
+	var tip *ledger.BlockHeader
+	if err = json.Unmarshal(tipBytes, &tip); err != nil {
+		errorMessage := "failed to unmarshal tip"
+		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
+		response.Write(http.StatusInternalServerError, errorMessage)
+		return
+	}
+	neighborsBytes, err := controller.sender.GetNeighbors()
+	if err != nil {
+		errorMessage := "failed to get neighbors"
+		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
+		response.Write(http.StatusInternalServerError, errorMessage)
+		return
+	}
+	var neighbors []*ledger.NeighborStatus
+	if err = json.Unmarshal(neighborsBytes, &neighbors); err != nil {
+		errorMessage := "failed to unmarshal neighbors"
+		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
+		response.Write(http.StatusInternalServerError, errorMessage)
+		return
+	}
+	transactionsBytes, err := controller.sender.GetTransactions()
+	if err != nil {
+		errorMessage := "failed to get transactions"
+		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
+		response.Write(http.StatusInternalServerError, errorMessage)
+		return
+	}
+	// The transactions are only counted, there is no need to decode them
+	var transactions []json.RawMessage
+	if err = json.Unmarshal(transactionsBytes, &transactions); err != nil {
+		errorMessage := "failed to unmarshal transactions"
+		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
+		response.Write(http.StatusInternalServerError, errorMessage)
+		return
+	}
+	response.WriteJson(http.StatusOK, &statusDto{
+		Version:         controller.version,
+		ValidatorTarget: controller.sender.Target(),
+		Tip:             tip,
+		Neighbors:       neighbors,
+		PoolSize:        len(transactions),
+	})
+}
@@ -0,0 +1,60 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_GetStatus_GetTipError_ReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	senderMock := new(application.SenderMock)
+	senderMock.GetTipFunc = func() ([]byte, error) { return nil, errors.New("") }
+	controller := NewStatusController(senderMock, "", log.NewLoggerMock())
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	// Act
+	controller.GetStatus(recorder, request)
+
+	// Assert
+	expectedStatusCode := 500
+	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
+}
+
+func Test_GetStatus_ValidParameters_ReturnsStatus(t *testing.T) {
+	// Arrange
+	senderMock := new(application.SenderMock)
+	tip, _ := ledger.NewBlockHeader(ledger.NewBlock([32]byte{}, nil, nil, 0, nil), 3)
+	senderMock.GetTipFunc = func() ([]byte, error) { return json.Marshal(tip) }
+	senderMock.GetNeighborsFunc = func() ([]byte, error) {
+		return json.Marshal([]*ledger.NeighborStatus{ledger.NewNeighborStatus(0, true, 0, 0, "0.0.0.0:0")})
+	}
+	senderMock.GetTransactionsFunc = func() ([]byte, error) { return json.Marshal([]*ledger.Transaction{{}}) }
+	senderMock.TargetFunc = func() string { return "0.0.0.0:1" }
+	controller := NewStatusController(senderMock, "v1.0.0", log.NewLoggerMock())
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	// Act
+	controller.GetStatus(recorder, request)
+
+	// Assert
+	expectedStatusCode := 200
+	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
+	var status *statusDto
+	_ = json.Unmarshal(recorder.Body.Bytes(), &status)
+	test.Assert(t, status.Version == "v1.0.0", "Wrong version.")
+	test.Assert(t, status.ValidatorTarget == "0.0.0.0:1", "Wrong validator target.")
+	test.Assert(t, status.Tip.Height() == 3, "Wrong tip height.")
+	test.Assert(t, len(status.Neighbors) == 1, "Wrong neighbors count.")
+	test.Assert(t, status.PoolSize == 1, "Wrong pool size.")
+}
@@ -2,6 +2,7 @@ package payment
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/my-cloud/ruthenium/validatornode/application"
 	"net/http"
@@ -69,33 +70,17 @@ func (controller *ProgressController) GetTransactionProgress(writer http.Respons
 		response.Write(http.StatusInternalServerError, errorMessage)
 		return
 	}
-	blocksBytes, err := controller.sender.GetBlocks(uint64(currentBlockHeight))
-	if err != nil {
-		errorMessage := "failed to get blocks"
-		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
-		response.Write(http.StatusInternalServerError, errorMessage)
-		return
-	}
-	var blocks []*ledger.Block
-	err = json.Unmarshal(blocksBytes, &blocks)
-	if err != nil {
-		errorMessage := "failed to unmarshal blocks"
-		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
-		response.Write(http.StatusInternalServerError, errorMessage)
-		return
-	}
-	if len(blocks) == 0 {
-		errorMessage := "failed to get last block, get blocks returned an empty list"
-		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
-		response.Write(http.StatusInternalServerError, errorMessage)
-		return
-	}
-	for _, validatedTransaction := range blocks[0].Transactions() {
-		if validatedTransaction.Id() == searchedUtxo.TransactionId() {
-			progressInfo.TransactionStatus = "validated"
-			response.WriteJson(http.StatusOK, progressInfo)
+	// The validator node fails to return a proof for a transaction which is not in the blockchain
+	if proofBytes, proofErr := controller.sender.GetTransactionProof(searchedUtxo.TransactionId()); proofErr == nil {
+		if err = controller.verifyProof(proofBytes); err != nil {
+			errorMessage := "failed to verify transaction proof"
+			controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
+			response.Write(http.StatusInternalServerError, errorMessage)
 			return
 		}
+		progressInfo.TransactionStatus = "validated"
+		response.WriteJson(http.StatusOK, progressInfo)
+		return
 	}
 	transactionsBytes, err := controller.sender.GetTransactions()
 	if err != nil {
@@ -122,3 +107,25 @@ func (controller *ProgressController) GetTransactionProgress(writer http.Respons
 	progressInfo.TransactionStatus = "rejected"
 	response.WriteJson(http.StatusOK, progressInfo)
 }
+
+func (controller *ProgressController) verifyProof(proofBytes []byte) error {
+	var proof *ledger.MerkleProof
+	if err := json.Unmarshal(proofBytes, &proof); err != nil {
+		return fmt.Errorf("failed to unmarshal transaction proof: %w", err)
+	}
+	if proof == nil {
+		return errors.New("transaction proof is missing")
+	}
+	headersBytes, err := controller.sender.GetHeaders(proof.BlockHeight())
+	if err != nil {
+		return fmt.Errorf("failed to get block header: %w", err)
+	}
+	var headers []*ledger.BlockHeader
+	if err = json.Unmarshal(headersBytes, &headers); err != nil {
+		return fmt.Errorf("failed to unmarshal block headers: %w", err)
+	}
+	if len(headers) == 0 {
+		return errors.New("block header is missing")
+	}
+	return proof.Verify(headers[0])
+}
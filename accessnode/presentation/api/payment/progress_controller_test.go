@@ -93,14 +93,18 @@ func Test_GetTransactionProgress_GetFirstBlockTimestampError_ReturnsInternalServ
 	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
 }
 
-func Test_GetTransactionProgress_GetBlocksError_ReturnsInternalServerError(t *testing.T) {
+func Test_GetTransactionProgress_GetHeadersError_ReturnsInternalServerError(t *testing.T) {
 	// Arrange
 	logger := log.NewLoggerMock()
 	senderMock := new(application.SenderMock)
 	marshalledEmptyArray := []byte{91, 93}
 	senderMock.GetUtxosFunc = func(string) ([]byte, error) { return marshalledEmptyArray, nil }
 	senderMock.GetFirstBlockTimestampFunc = func() (int64, error) { return 0, nil }
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) { return nil, errors.New("") }
+	transaction, _ := ledger.NewRewardTransaction("", false, 0, 0)
+	proof, _ := ledger.NewMerkleProof([]*ledger.Transaction{transaction}, transaction.Id(), 0)
+	marshalledProof, _ := json.Marshal(proof)
+	senderMock.GetTransactionProofFunc = func(string) ([]byte, error) { return marshalledProof, nil }
+	senderMock.GetHeadersFunc = func(uint64) ([]byte, error) { return nil, errors.New("") }
 	watchMock := new(application.TimeProviderMock)
 	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
 	settings := new(application.ProtocolSettingsProviderMock)
@@ -116,7 +120,7 @@ func Test_GetTransactionProgress_GetBlocksError_ReturnsInternalServerError(t *te
 	controller.GetTransactionProgress(recorder, request)
 
 	// Assert
-	areNeighborMethodsCalled := len(senderMock.GetUtxosCalls()) == 1 && len(senderMock.GetFirstBlockTimestampCalls()) == 1 && len(senderMock.GetBlocksCalls()) == 1
+	areNeighborMethodsCalled := len(senderMock.GetUtxosCalls()) == 1 && len(senderMock.GetFirstBlockTimestampCalls()) == 1 && len(senderMock.GetTransactionProofCalls()) == 1 && len(senderMock.GetHeadersCalls()) == 1
 	test.Assert(t, areNeighborMethodsCalled, "Neighbor method is not called whereas it should be.")
 	expectedStatusCode := 500
 	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
@@ -129,9 +133,7 @@ func Test_GetTransactionProgress_GetTransactionsError_ReturnsInternalServerError
 	marshalledEmptyArray := []byte{91, 93}
 	senderMock.GetUtxosFunc = func(string) ([]byte, error) { return marshalledEmptyArray, nil }
 	senderMock.GetFirstBlockTimestampFunc = func() (int64, error) { return 0, nil }
-	blocks := []*ledger.Block{ledger.NewBlock([32]byte{}, nil, nil, 0, nil)}
-	marshalledBlocks, _ := json.Marshal(blocks)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) { return marshalledBlocks, nil }
+	senderMock.GetTransactionProofFunc = func(string) ([]byte, error) { return nil, errors.New("") }
 	senderMock.GetTransactionsFunc = func() ([]byte, error) { return nil, errors.New("") }
 	watchMock := new(application.TimeProviderMock)
 	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
@@ -148,7 +150,7 @@ func Test_GetTransactionProgress_GetTransactionsError_ReturnsInternalServerError
 	controller.GetTransactionProgress(recorder, request)
 
 	// Assert
-	areNeighborMethodsCalled := len(senderMock.GetUtxosCalls()) == 1 && len(senderMock.GetFirstBlockTimestampCalls()) == 1 && len(senderMock.GetBlocksCalls()) == 1 && len(senderMock.GetTransactionsCalls()) == 1
+	areNeighborMethodsCalled := len(senderMock.GetUtxosCalls()) == 1 && len(senderMock.GetFirstBlockTimestampCalls()) == 1 && len(senderMock.GetTransactionProofCalls()) == 1 && len(senderMock.GetTransactionsCalls()) == 1
 	test.Assert(t, areNeighborMethodsCalled, "Neighbor method is not called whereas it should be.")
 	expectedStatusCode := 500
 	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
@@ -161,9 +163,7 @@ func Test_GetTransactionProgress_TransactionNotFound_ReturnsRejected(t *testing.
 	marshalledEmptyArray := []byte{91, 93}
 	senderMock.GetUtxosFunc = func(string) ([]byte, error) { return marshalledEmptyArray, nil }
 	senderMock.GetFirstBlockTimestampFunc = func() (int64, error) { return 0, nil }
-	blocks := []*ledger.Block{ledger.NewBlock([32]byte{}, nil, nil, 0, nil)}
-	marshalledBlocks, _ := json.Marshal(blocks)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) { return marshalledBlocks, nil }
+	senderMock.GetTransactionProofFunc = func(string) ([]byte, error) { return nil, errors.New("") }
 	senderMock.GetTransactionsFunc = func() ([]byte, error) { return marshalledEmptyArray, nil }
 	watchMock := new(application.TimeProviderMock)
 	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
@@ -180,7 +180,7 @@ func Test_GetTransactionProgress_TransactionNotFound_ReturnsRejected(t *testing.
 	controller.GetTransactionProgress(recorder, request)
 
 	// Assert
-	areNeighborMethodsCalled := len(senderMock.GetUtxosCalls()) == 1 && len(senderMock.GetFirstBlockTimestampCalls()) == 1 && len(senderMock.GetBlocksCalls()) == 1 && len(senderMock.GetTransactionsCalls()) == 1
+	areNeighborMethodsCalled := len(senderMock.GetUtxosCalls()) == 1 && len(senderMock.GetFirstBlockTimestampCalls()) == 1 && len(senderMock.GetTransactionProofCalls()) == 1 && len(senderMock.GetTransactionsCalls()) == 1
 	test.Assert(t, areNeighborMethodsCalled, "Neighbor method is not called whereas it should be.")
 	expectedStatusCode := 200
 	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
@@ -239,9 +239,13 @@ func Test_GetTransactionProgress_ValidatedTransactionFound_ReturnsValidated(t *t
 	senderMock.GetUtxosFunc = func(string) ([]byte, error) { return marshalledEmptyArray, nil }
 	senderMock.GetFirstBlockTimestampFunc = func() (int64, error) { return 0, nil }
 	transaction, _ := ledger.NewRewardTransaction("", false, 0, 0)
-	blocks := []*ledger.Block{ledger.NewBlock([32]byte{}, nil, nil, 0, []*ledger.Transaction{transaction})}
-	marshalledBlocks, _ := json.Marshal(blocks)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) { return marshalledBlocks, nil }
+	transactions := []*ledger.Transaction{transaction}
+	proof, _ := ledger.NewMerkleProof(transactions, transaction.Id(), 0)
+	marshalledProof, _ := json.Marshal(proof)
+	senderMock.GetTransactionProofFunc = func(string) ([]byte, error) { return marshalledProof, nil }
+	headers, _ := ledger.NewBlockHeaders([]*ledger.Block{ledger.NewBlock([32]byte{}, nil, nil, 0, transactions)}, 0)
+	marshalledHeaders, _ := json.Marshal(headers)
+	senderMock.GetHeadersFunc = func(uint64) ([]byte, error) { return marshalledHeaders, nil }
 	watchMock := new(application.TimeProviderMock)
 	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
 	settings := new(application.ProtocolSettingsProviderMock)
@@ -258,7 +262,7 @@ func Test_GetTransactionProgress_ValidatedTransactionFound_ReturnsValidated(t *t
 	controller.GetTransactionProgress(recorder, request)
 
 	// Assert
-	areNeighborMethodsCalled := len(senderMock.GetUtxosCalls()) == 1 && len(senderMock.GetFirstBlockTimestampCalls()) == 1 && len(senderMock.GetBlocksCalls()) == 1
+	areNeighborMethodsCalled := len(senderMock.GetUtxosCalls()) == 1 && len(senderMock.GetFirstBlockTimestampCalls()) == 1 && len(senderMock.GetTransactionProofCalls()) == 1 && len(senderMock.GetHeadersCalls()) == 1
 	test.Assert(t, areNeighborMethodsCalled, "Neighbor method is not called whereas it should be.")
 	expectedStatusCode := 200
 	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
@@ -271,6 +275,42 @@ func Test_GetTransactionProgress_ValidatedTransactionFound_ReturnsValidated(t *t
 	test.Assert(t, actualStatus == expectedStatus, fmt.Sprintf("Wrong response. expected: %s actual: %s", expectedStatus, actualStatus))
 }
 
+func Test_GetTransactionProgress_ProofDoesNotMatchHeader_ReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	logger := log.NewLoggerMock()
+	senderMock := new(application.SenderMock)
+	marshalledEmptyArray := []byte{91, 93}
+	senderMock.GetUtxosFunc = func(string) ([]byte, error) { return marshalledEmptyArray, nil }
+	senderMock.GetFirstBlockTimestampFunc = func() (int64, error) { return 0, nil }
+	transaction, _ := ledger.NewRewardTransaction("", false, 0, 0)
+	proof, _ := ledger.NewMerkleProof([]*ledger.Transaction{transaction}, transaction.Id(), 0)
+	marshalledProof, _ := json.Marshal(proof)
+	senderMock.GetTransactionProofFunc = func(string) ([]byte, error) { return marshalledProof, nil }
+	otherTransaction, _ := ledger.NewRewardTransaction("", false, 1, 0)
+	headers, _ := ledger.NewBlockHeaders([]*ledger.Block{ledger.NewBlock([32]byte{}, nil, nil, 0, []*ledger.Transaction{otherTransaction})}, 0)
+	marshalledHeaders, _ := json.Marshal(headers)
+	senderMock.GetHeadersFunc = func(uint64) ([]byte, error) { return marshalledHeaders, nil }
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	controller := NewProgressController(senderMock, settings, watchMock, logger)
+	recorder := httptest.NewRecorder()
+	outputIndex := 0
+	utxo := ledger.NewUtxo(ledger.NewInputInfo(uint16(outputIndex), transaction.Id()), transaction.Outputs()[outputIndex], transaction.Timestamp())
+	marshalledUtxo, _ := json.Marshal(utxo)
+	body := bytes.NewReader(marshalledUtxo)
+	request := httptest.NewRequest(http.MethodPut, "/", body)
+
+	// Act
+	controller.GetTransactionProgress(recorder, request)
+
+	// Assert
+	expectedStatusCode := 500
+	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
+	test.AssertThatMessageIsLogged(t, logger.ErrorCalls(), "failed to verify transaction proof")
+}
+
 func Test_GetTransactionProgress_PendingTransactionFound_ReturnsSent(t *testing.T) {
 	// Arrange
 	logger := log.NewLoggerMock()
@@ -278,9 +318,7 @@ func Test_GetTransactionProgress_PendingTransactionFound_ReturnsSent(t *testing.
 	marshalledEmptyArray := []byte{91, 93}
 	senderMock.GetUtxosFunc = func(string) ([]byte, error) { return marshalledEmptyArray, nil }
 	senderMock.GetFirstBlockTimestampFunc = func() (int64, error) { return 0, nil }
-	blocks := []*ledger.Block{ledger.NewBlock([32]byte{}, nil, nil, 0, nil)}
-	marshalledBlocks, _ := json.Marshal(blocks)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) { return marshalledBlocks, nil }
+	senderMock.GetTransactionProofFunc = func(string) ([]byte, error) { return nil, errors.New("") }
 	transaction, _ := ledger.NewRewardTransaction("", false, 0, 0)
 	transactions := []*ledger.Transaction{transaction}
 	marshalledTransactions, _ := json.Marshal(transactions)
@@ -301,7 +339,7 @@ func Test_GetTransactionProgress_PendingTransactionFound_ReturnsSent(t *testing.
 	controller.GetTransactionProgress(recorder, request)
 
 	// Assert
-	areNeighborMethodsCalled := len(senderMock.GetUtxosCalls()) == 1 && len(senderMock.GetFirstBlockTimestampCalls()) == 1 && len(senderMock.GetBlocksCalls()) == 1 && len(senderMock.GetTransactionsCalls()) == 1
+	areNeighborMethodsCalled := len(senderMock.GetUtxosCalls()) == 1 && len(senderMock.GetFirstBlockTimestampCalls()) == 1 && len(senderMock.GetTransactionProofCalls()) == 1 && len(senderMock.GetTransactionsCalls()) == 1
 	test.Assert(t, areNeighborMethodsCalled, "Neighbor method is not called whereas it should be.")
 	expectedStatusCode := 200
 	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
@@ -39,12 +39,26 @@ func (controller *TransactionController) PostTransaction(writer http.ResponseWri
 		response.Write(http.StatusInternalServerError, errorMessage)
 		return
 	}
-	err = controller.sender.AddTransaction(marshaledTransaction)
+	resultBytes, err := controller.sender.AddTransaction(marshaledTransaction)
 	if err != nil {
 		errorMessage := "failed to add transaction"
 		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
 		response.Write(http.StatusInternalServerError, errorMessage)
 		return
 	}
+	var result *ledger.TransactionResult
+	err = json.Unmarshal(resultBytes, &result)
+	if err != nil {
+		errorMessage := "failed to unmarshal transaction result"
+		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
+		response.Write(http.StatusInternalServerError, errorMessage)
+		return
+	}
+	if !result.IsAccepted() {
+		errorMessage := fmt.Sprintf("transaction rejected: %s: %s", result.ReasonCode(), result.Reason())
+		controller.logger.Debug(errorMessage)
+		response.Write(http.StatusBadRequest, errorMessage)
+		return
+	}
 	response.Write(http.StatusCreated, "success")
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/my-cloud/ruthenium/validatornode/application"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
@@ -40,7 +41,7 @@ func Test_PostTransaction_NodeError_InternalServerError(t *testing.T) {
 	senderMock := new(application.SenderMock)
 	target := "0.0.0.0:0"
 	senderMock.TargetFunc = func() string { return target }
-	senderMock.AddTransactionFunc = func([]byte) error { return errors.New("") }
+	senderMock.AddTransactionFunc = func([]byte) ([]byte, error) { return nil, errors.New("") }
 	logger := log.NewLoggerMock()
 	controller := NewTransactionController(senderMock, logger)
 	transactionRequest, _ := ledger.NewRewardTransaction("", false, 0, 0)
@@ -64,7 +65,7 @@ func Test_PostTransaction_ValidTransaction_NeighborMethodCalled(t *testing.T) {
 	senderMock := new(application.SenderMock)
 	target := "0.0.0.0:0"
 	senderMock.TargetFunc = func() string { return target }
-	senderMock.AddTransactionFunc = func([]byte) error { return nil }
+	senderMock.AddTransactionFunc = func([]byte) ([]byte, error) { return json.Marshal(ledger.NewAcceptedTransactionResult()) }
 	logger := log.NewLoggerMock()
 	controller := NewTransactionController(senderMock, logger)
 	transactionRequest, _ := ledger.NewRewardTransaction("", false, 0, 0)
@@ -82,3 +83,28 @@ func Test_PostTransaction_ValidTransaction_NeighborMethodCalled(t *testing.T) {
 	expectedStatusCode := 201
 	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
 }
+
+func Test_PostTransaction_RejectedTransaction_BadRequest(t *testing.T) {
+	// Arrange
+	senderMock := new(application.SenderMock)
+	target := "0.0.0.0:0"
+	senderMock.TargetFunc = func() string { return target }
+	senderMock.AddTransactionFunc = func([]byte) ([]byte, error) {
+		return json.Marshal(ledger.NewRejectedTransactionResult(ledger.InvalidFeeReasonCode, "failed to verify fee"))
+	}
+	logger := log.NewLoggerMock()
+	controller := NewTransactionController(senderMock, logger)
+	transactionRequest, _ := ledger.NewRewardTransaction("", false, 0, 0)
+	marshalledTransaction, _ := json.Marshal(transactionRequest)
+	body := bytes.NewReader(marshalledTransaction)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/", body)
+
+	// Act
+	controller.PostTransaction(recorder, request)
+
+	// Assert
+	expectedStatusCode := 400
+	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
+	test.Assert(t, strings.Contains(recorder.Body.String(), ledger.InvalidFeeReasonCode), "Response does not contain the rejection reason code.")
+}
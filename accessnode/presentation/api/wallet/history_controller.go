@@ -0,0 +1,70 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"net/http"
+	"strconv"
+
+	"github.com/my-cloud/ruthenium/accessnode/infrastructure/io"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+)
+
+type HistoryController struct {
+	sender application.Sender
+	logger log.Logger
+}
+
+func NewHistoryController(sender application.Sender, logger log.Logger) *HistoryController {
+	return &HistoryController{sender, logger}
+}
+
+func (controller *HistoryController) GetWalletHistory(writer http.ResponseWriter, req *http.Request) {
+	response := io.NewResponse(writer, controller.logger)
+	address := req.URL.Query().Get("address")
+	if address == "" {
+		errorMessage := "address is missing in history request"
+		controller.logger.Error(errorMessage)
+		response.Write(http.StatusBadRequest, errorMessage)
+		return
+	}
+	offset, err := parseUint(req.URL.Query().Get("offset"))
+	if err != nil {
+		errorMessage := "failed to parse offset"
+		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
+		response.Write(http.StatusBadRequest, errorMessage)
+		return
+	}
+	limit, err := parseUint(req.URL.Query().Get("limit"))
+	if err != nil {
+		errorMessage := "failed to parse limit"
+		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
+		response.Write(http.StatusBadRequest, errorMessage)
+		return
+	}
+	historyBytes, err := controller.sender.GetAddressHistory(address, offset, limit)
+	if err != nil {
+		errorMessage := "failed to get address history"
+		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
+		response.Write(http.StatusInternalServerError, errorMessage)
+		return
+	}
+	var history []*ledger.AddressHistoryEntry
+	err = json.Unmarshal(historyBytes, &history)
+	if err != nil {
+		errorMessage := "failed to unmarshal address history"
+		controller.logger.Error(fmt.Errorf("%s: %w", errorMessage, err).Error())
+		response.Write(http.StatusInternalServerError, errorMessage)
+		return
+	}
+	response.WriteJson(http.StatusOK, history)
+}
+
+func parseUint(value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
@@ -0,0 +1,87 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_GetWalletHistory_AddressIsMissing_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	logger := log.NewLoggerMock()
+	senderMock := new(application.SenderMock)
+	controller := NewHistoryController(senderMock, logger)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	// Act
+	controller.GetWalletHistory(recorder, request)
+
+	// Assert
+	expectedStatusCode := 400
+	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
+}
+
+func Test_GetWalletHistory_InvalidOffset_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	logger := log.NewLoggerMock()
+	senderMock := new(application.SenderMock)
+	controller := NewHistoryController(senderMock, logger)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?address=address&offset=-1", "/"), nil)
+
+	// Act
+	controller.GetWalletHistory(recorder, request)
+
+	// Assert
+	expectedStatusCode := 400
+	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
+}
+
+func Test_GetWalletHistory_GetAddressHistoryError_ReturnsInternalServerError(t *testing.T) {
+	// Arrange
+	logger := log.NewLoggerMock()
+	senderMock := new(application.SenderMock)
+	senderMock.GetAddressHistoryFunc = func(string, uint64, uint64) ([]byte, error) { return nil, errors.New("") }
+	controller := NewHistoryController(senderMock, logger)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?address=address", "/"), nil)
+
+	// Act
+	controller.GetWalletHistory(recorder, request)
+
+	// Assert
+	isNeighborMethodCalled := len(senderMock.GetAddressHistoryCalls()) == 1
+	test.Assert(t, isNeighborMethodCalled, "Neighbor method is not called whereas it should be.")
+	expectedStatusCode := 500
+	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
+}
+
+func Test_GetWalletHistory_ValidRequest_ReturnsHistory(t *testing.T) {
+	// Arrange
+	logger := log.NewLoggerMock()
+	senderMock := new(application.SenderMock)
+	marshalledEmptyHistory, _ := json.Marshal([]*ledger.AddressHistoryEntry{})
+	senderMock.GetAddressHistoryFunc = func(string, uint64, uint64) ([]byte, error) { return marshalledEmptyHistory, nil }
+	controller := NewHistoryController(senderMock, logger)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s?address=address&offset=10&limit=5", "/"), nil)
+
+	// Act
+	controller.GetWalletHistory(recorder, request)
+
+	// Assert
+	calls := senderMock.GetAddressHistoryCalls()
+	isNeighborMethodCalled := len(calls) == 1 && calls[0].Offset == 10 && calls[0].Limit == 5
+	test.Assert(t, isNeighborMethodCalled, "Neighbor method is not called with the request parameters whereas it should be.")
+	expectedStatusCode := 200
+	test.Assert(t, recorder.Code == expectedStatusCode, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", expectedStatusCode, recorder.Code))
+}
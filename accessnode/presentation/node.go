@@ -3,11 +3,12 @@ package presentation
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/my-cloud/ruthenium/accessnode/presentation/api"
+	"github.com/my-cloud/ruthenium/accessnode/presentation/api/health"
 	"github.com/my-cloud/ruthenium/accessnode/presentation/api/payment"
 	"github.com/my-cloud/ruthenium/accessnode/presentation/api/wallet"
 	"github.com/my-cloud/ruthenium/validatornode/application"
 	"github.com/my-cloud/ruthenium/validatornode/domain/clock"
-	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log/console"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 )
 
 type Node struct {
@@ -15,7 +16,7 @@ type Node struct {
 	rooter *gin.Engine
 }
 
-func NewNode(port string, sender application.Sender, settings application.ProtocolSettingsProvider, templatePath string, watch *clock.Watch, logger *console.Logger) *Node {
+func NewNode(port string, sender application.Sender, settings application.ProtocolSettingsProvider, templatePath string, version string, watch *clock.Watch, logger log.Logger) *Node {
 	rooter := gin.Default()
 	indexController := api.NewIndexController(templatePath, logger)
 	transactionController := payment.NewTransactionController(sender, logger)
@@ -24,6 +25,9 @@ func NewNode(port string, sender application.Sender, settings application.Protoc
 	progressController := payment.NewProgressController(sender, settings, watch, logger)
 	addressController := wallet.NewAddressController(logger)
 	amountController := wallet.NewAmountController(sender, settings, watch, logger)
+	historyController := wallet.NewHistoryController(sender, logger)
+	healthController := health.NewHealthController(sender, logger)
+	statusController := health.NewStatusController(sender, version, logger)
 	rooter.GET("/", func(c *gin.Context) { indexController.GetIndex(c.Writer, c.Request) })
 	rooter.POST("/transaction", func(c *gin.Context) { transactionController.PostTransaction(c.Writer, c.Request) })
 	rooter.GET("/transactions", func(c *gin.Context) { transactionsController.GetTransactions(c.Writer, c.Request) })
@@ -31,6 +35,10 @@ func NewNode(port string, sender application.Sender, settings application.Protoc
 	rooter.PUT("/transaction/output/progress", func(c *gin.Context) { progressController.GetTransactionProgress(c.Writer, c.Request) })
 	rooter.GET("/wallet/address", func(c *gin.Context) { addressController.GetWalletAddress(c.Writer, c.Request) })
 	rooter.GET("/wallet/amount", func(c *gin.Context) { amountController.GetWalletAmount(c.Writer, c.Request) })
+	rooter.GET("/wallet/history", func(c *gin.Context) { historyController.GetWalletHistory(c.Writer, c.Request) })
+	rooter.GET("/health/liveness", func(c *gin.Context) { healthController.GetLiveness(c.Writer, c.Request) })
+	rooter.GET("/health/readiness", func(c *gin.Context) { healthController.GetReadiness(c.Writer, c.Request) })
+	rooter.GET("/status", func(c *gin.Context) { statusController.GetStatus(c.Writer, c.Request) })
 	return &Node{port, rooter}
 }
 
@@ -8,7 +8,7 @@ import (
 func Test_CalculateFee_UnknownTransactionId_ReturnsError(t *testing.T) {
 	// Arrange
 	// Act
-	node := NewNode("", nil, nil, "", nil, nil)
+	node := NewNode("", nil, nil, "", "", nil, nil)
 
 	// Assert
 	test.Assert(t, node != nil, "node is nil whereas it should not")
@@ -0,0 +1,8 @@
+package application
+
+import "github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+
+type AddressBookStorage interface {
+	AddressBook() ([]*ledger.AddressBookEntry, error)
+	SaveAddressBook(entries []*ledger.AddressBookEntry) error
+}
@@ -0,0 +1,112 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package application
+
+import (
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"sync"
+)
+
+// Ensure, that AddressBookStorageMock does implement AddressBookStorage.
+// If this is not the case, regenerate this file with moq.
+var _ AddressBookStorage = &AddressBookStorageMock{}
+
+// AddressBookStorageMock is a mock implementation of AddressBookStorage.
+//
+//	func TestSomethingThatUsesAddressBookStorage(t *testing.T) {
+//
+//		// make and configure a mocked AddressBookStorage
+//		mockedAddressBookStorage := &AddressBookStorageMock{
+//			AddressBookFunc: func() ([]*ledger.AddressBookEntry, error) {
+//				panic("mock out the AddressBook method")
+//			},
+//			SaveAddressBookFunc: func(entries []*ledger.AddressBookEntry) error {
+//				panic("mock out the SaveAddressBook method")
+//			},
+//		}
+//
+//		// use mockedAddressBookStorage in code that requires AddressBookStorage
+//		// and then make assertions.
+//
+//	}
+type AddressBookStorageMock struct {
+	// AddressBookFunc mocks the AddressBook method.
+	AddressBookFunc func() ([]*ledger.AddressBookEntry, error)
+
+	// SaveAddressBookFunc mocks the SaveAddressBook method.
+	SaveAddressBookFunc func(entries []*ledger.AddressBookEntry) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddressBook holds details about calls to the AddressBook method.
+		AddressBook []struct {
+		}
+		// SaveAddressBook holds details about calls to the SaveAddressBook method.
+		SaveAddressBook []struct {
+			// Entries is the entries argument value.
+			Entries []*ledger.AddressBookEntry
+		}
+	}
+	lockAddressBook     sync.RWMutex
+	lockSaveAddressBook sync.RWMutex
+}
+
+// AddressBook calls AddressBookFunc.
+func (mock *AddressBookStorageMock) AddressBook() ([]*ledger.AddressBookEntry, error) {
+	if mock.AddressBookFunc == nil {
+		panic("AddressBookStorageMock.AddressBookFunc: method is nil but AddressBookStorage.AddressBook was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockAddressBook.Lock()
+	mock.calls.AddressBook = append(mock.calls.AddressBook, callInfo)
+	mock.lockAddressBook.Unlock()
+	return mock.AddressBookFunc()
+}
+
+// AddressBookCalls gets all the calls that were made to AddressBook.
+// Check the length with:
+//
+//	len(mockedAddressBookStorage.AddressBookCalls())
+func (mock *AddressBookStorageMock) AddressBookCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockAddressBook.RLock()
+	calls = mock.calls.AddressBook
+	mock.lockAddressBook.RUnlock()
+	return calls
+}
+
+// SaveAddressBook calls SaveAddressBookFunc.
+func (mock *AddressBookStorageMock) SaveAddressBook(entries []*ledger.AddressBookEntry) error {
+	if mock.SaveAddressBookFunc == nil {
+		panic("AddressBookStorageMock.SaveAddressBookFunc: method is nil but AddressBookStorage.SaveAddressBook was just called")
+	}
+	callInfo := struct {
+		Entries []*ledger.AddressBookEntry
+	}{
+		Entries: entries,
+	}
+	mock.lockSaveAddressBook.Lock()
+	mock.calls.SaveAddressBook = append(mock.calls.SaveAddressBook, callInfo)
+	mock.lockSaveAddressBook.Unlock()
+	return mock.SaveAddressBookFunc(entries)
+}
+
+// SaveAddressBookCalls gets all the calls that were made to SaveAddressBook.
+// Check the length with:
+//
+//	len(mockedAddressBookStorage.SaveAddressBookCalls())
+func (mock *AddressBookStorageMock) SaveAddressBookCalls() []struct {
+	Entries []*ledger.AddressBookEntry
+} {
+	var calls []struct {
+		Entries []*ledger.AddressBookEntry
+	}
+	mock.lockSaveAddressBook.RLock()
+	calls = mock.calls.SaveAddressBook
+	mock.lockSaveAddressBook.RUnlock()
+	return calls
+}
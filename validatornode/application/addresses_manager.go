@@ -5,6 +5,8 @@ type AddressesManager interface {
 	Copy() AddressesManager
 	Filter(addresses []string) (newAddresses []string)
 	IsRegistered(address string) bool
+	MarshalJSON() ([]byte, error)
 	RemovedAddresses() (removedAddresses []string)
+	UnmarshalJSON(data []byte) error
 	Update(addedAddresses []string, removedAddresses []string)
 }
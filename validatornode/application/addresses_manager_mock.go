@@ -29,9 +29,15 @@ var _ AddressesManager = &AddressesManagerMock{}
 //			IsRegisteredFunc: func(address string) bool {
 //				panic("mock out the IsRegistered method")
 //			},
+//			MarshalJSONFunc: func() ([]byte, error) {
+//				panic("mock out the MarshalJSON method")
+//			},
 //			RemovedAddressesFunc: func() []string {
 //				panic("mock out the RemovedAddresses method")
 //			},
+//			UnmarshalJSONFunc: func(data []byte) error {
+//				panic("mock out the UnmarshalJSON method")
+//			},
 //			UpdateFunc: func(addedAddresses []string, removedAddresses []string)  {
 //				panic("mock out the Update method")
 //			},
@@ -54,9 +60,15 @@ type AddressesManagerMock struct {
 	// IsRegisteredFunc mocks the IsRegistered method.
 	IsRegisteredFunc func(address string) bool
 
+	// MarshalJSONFunc mocks the MarshalJSON method.
+	MarshalJSONFunc func() ([]byte, error)
+
 	// RemovedAddressesFunc mocks the RemovedAddresses method.
 	RemovedAddressesFunc func() []string
 
+	// UnmarshalJSONFunc mocks the UnmarshalJSON method.
+	UnmarshalJSONFunc func(data []byte) error
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(addedAddresses []string, removedAddresses []string)
 
@@ -78,9 +90,17 @@ type AddressesManagerMock struct {
 			// Address is the address argument value.
 			Address string
 		}
+		// MarshalJSON holds details about calls to the MarshalJSON method.
+		MarshalJSON []struct {
+		}
 		// RemovedAddresses holds details about calls to the RemovedAddresses method.
 		RemovedAddresses []struct {
 		}
+		// UnmarshalJSON holds details about calls to the UnmarshalJSON method.
+		UnmarshalJSON []struct {
+			// Data is the data argument value.
+			Data []byte
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// AddedAddresses is the addedAddresses argument value.
@@ -93,7 +113,9 @@ type AddressesManagerMock struct {
 	lockCopy             sync.RWMutex
 	lockFilter           sync.RWMutex
 	lockIsRegistered     sync.RWMutex
+	lockMarshalJSON      sync.RWMutex
 	lockRemovedAddresses sync.RWMutex
+	lockUnmarshalJSON    sync.RWMutex
 	lockUpdate           sync.RWMutex
 }
 
@@ -215,6 +237,33 @@ func (mock *AddressesManagerMock) IsRegisteredCalls() []struct {
 	return calls
 }
 
+// MarshalJSON calls MarshalJSONFunc.
+func (mock *AddressesManagerMock) MarshalJSON() ([]byte, error) {
+	if mock.MarshalJSONFunc == nil {
+		panic("AddressesManagerMock.MarshalJSONFunc: method is nil but AddressesManager.MarshalJSON was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockMarshalJSON.Lock()
+	mock.calls.MarshalJSON = append(mock.calls.MarshalJSON, callInfo)
+	mock.lockMarshalJSON.Unlock()
+	return mock.MarshalJSONFunc()
+}
+
+// MarshalJSONCalls gets all the calls that were made to MarshalJSON.
+// Check the length with:
+//
+//	len(mockedAddressesManager.MarshalJSONCalls())
+func (mock *AddressesManagerMock) MarshalJSONCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockMarshalJSON.RLock()
+	calls = mock.calls.MarshalJSON
+	mock.lockMarshalJSON.RUnlock()
+	return calls
+}
+
 // RemovedAddresses calls RemovedAddressesFunc.
 func (mock *AddressesManagerMock) RemovedAddresses() []string {
 	if mock.RemovedAddressesFunc == nil {
@@ -242,6 +291,38 @@ func (mock *AddressesManagerMock) RemovedAddressesCalls() []struct {
 	return calls
 }
 
+// UnmarshalJSON calls UnmarshalJSONFunc.
+func (mock *AddressesManagerMock) UnmarshalJSON(data []byte) error {
+	if mock.UnmarshalJSONFunc == nil {
+		panic("AddressesManagerMock.UnmarshalJSONFunc: method is nil but AddressesManager.UnmarshalJSON was just called")
+	}
+	callInfo := struct {
+		Data []byte
+	}{
+		Data: data,
+	}
+	mock.lockUnmarshalJSON.Lock()
+	mock.calls.UnmarshalJSON = append(mock.calls.UnmarshalJSON, callInfo)
+	mock.lockUnmarshalJSON.Unlock()
+	return mock.UnmarshalJSONFunc(data)
+}
+
+// UnmarshalJSONCalls gets all the calls that were made to UnmarshalJSON.
+// Check the length with:
+//
+//	len(mockedAddressesManager.UnmarshalJSONCalls())
+func (mock *AddressesManagerMock) UnmarshalJSONCalls() []struct {
+	Data []byte
+} {
+	var calls []struct {
+		Data []byte
+	}
+	mock.lockUnmarshalJSON.RLock()
+	calls = mock.calls.UnmarshalJSON
+	mock.lockUnmarshalJSON.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *AddressesManagerMock) Update(addedAddresses []string, removedAddresses []string) {
 	if mock.UpdateFunc == nil {
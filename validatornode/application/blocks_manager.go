@@ -1,11 +1,24 @@
 package application
 
-import "github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+import (
+	"github.com/my-cloud/ruthenium/validatornode/domain/encryption"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+)
 
 type BlocksManager interface {
-	AddBlock(timestamp int64, transactions []*ledger.Transaction, newRegisteredAddresses []string) error
+	AbandonedTransactions() []*ledger.Transaction
+	AddressHistory(address string, offset uint64, limit uint64) ([]*ledger.AddressHistoryEntry, error)
+	AddAnnouncedBlock(block *ledger.Block, blockHeight uint64, broadcasterTarget string)
+	AddBlock(timestamp int64, transactions []*ledger.Transaction, newRegisteredAddresses []string, privateKey *encryption.PrivateKey) error
+	Block(blockHeight uint64) (*ledger.Block, error)
+	BlockByHash(hash [32]byte) (*ledger.Block, error)
 	Blocks(startingBlockHeight uint64) []*ledger.Block
+	BlocksRange(startingBlockHeight uint64, endingBlockHeight uint64) ([]*ledger.Block, error)
 	FirstBlockTimestamp() int64
+	Headers(startingBlockHeight uint64) ([]*ledger.BlockHeader, error)
 	LastBlockTimestamp() int64
 	LastBlockTransactions() []*ledger.Transaction
+	Tip() (*ledger.BlockHeader, error)
+	TransactionById(transactionId string) (*ledger.IndexedTransaction, error)
+	TransactionProof(transactionId string) (*ledger.MerkleProof, error)
 }
@@ -4,6 +4,7 @@
 package application
 
 import (
+	"github.com/my-cloud/ruthenium/validatornode/domain/encryption"
 	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
 	"sync"
 )
@@ -18,21 +19,51 @@ var _ BlocksManager = &BlocksManagerMock{}
 //
 //		// make and configure a mocked BlocksManager
 //		mockedBlocksManager := &BlocksManagerMock{
-//			AddBlockFunc: func(timestamp int64, transactions []*ledger.Transaction, newRegisteredAddresses []string) error {
+//			AbandonedTransactionsFunc: func() []*ledger.Transaction {
+//				panic("mock out the AbandonedTransactions method")
+//			},
+//			AddAnnouncedBlockFunc: func(block *ledger.Block, blockHeight uint64, broadcasterTarget string)  {
+//				panic("mock out the AddAnnouncedBlock method")
+//			},
+//			AddBlockFunc: func(timestamp int64, transactions []*ledger.Transaction, newRegisteredAddresses []string, privateKey *encryption.PrivateKey) error {
 //				panic("mock out the AddBlock method")
 //			},
+//			AddressHistoryFunc: func(address string, offset uint64, limit uint64) ([]*ledger.AddressHistoryEntry, error) {
+//				panic("mock out the AddressHistory method")
+//			},
+//			BlockFunc: func(blockHeight uint64) (*ledger.Block, error) {
+//				panic("mock out the Block method")
+//			},
+//			BlockByHashFunc: func(hash [32]byte) (*ledger.Block, error) {
+//				panic("mock out the BlockByHash method")
+//			},
 //			BlocksFunc: func(startingBlockHeight uint64) []*ledger.Block {
 //				panic("mock out the Blocks method")
 //			},
+//			BlocksRangeFunc: func(startingBlockHeight uint64, endingBlockHeight uint64) ([]*ledger.Block, error) {
+//				panic("mock out the BlocksRange method")
+//			},
 //			FirstBlockTimestampFunc: func() int64 {
 //				panic("mock out the FirstBlockTimestamp method")
 //			},
+//			HeadersFunc: func(startingBlockHeight uint64) ([]*ledger.BlockHeader, error) {
+//				panic("mock out the Headers method")
+//			},
 //			LastBlockTimestampFunc: func() int64 {
 //				panic("mock out the LastBlockTimestamp method")
 //			},
 //			LastBlockTransactionsFunc: func() []*ledger.Transaction {
 //				panic("mock out the LastBlockTransactions method")
 //			},
+//			TipFunc: func() (*ledger.BlockHeader, error) {
+//				panic("mock out the Tip method")
+//			},
+//			TransactionByIdFunc: func(transactionId string) (*ledger.IndexedTransaction, error) {
+//				panic("mock out the TransactionById method")
+//			},
+//			TransactionProofFunc: func(transactionId string) (*ledger.MerkleProof, error) {
+//				panic("mock out the TransactionProof method")
+//			},
 //		}
 //
 //		// use mockedBlocksManager in code that requires BlocksManager
@@ -40,23 +71,65 @@ var _ BlocksManager = &BlocksManagerMock{}
 //
 //	}
 type BlocksManagerMock struct {
+	// AbandonedTransactionsFunc mocks the AbandonedTransactions method.
+	AbandonedTransactionsFunc func() []*ledger.Transaction
+
+	// AddAnnouncedBlockFunc mocks the AddAnnouncedBlock method.
+	AddAnnouncedBlockFunc func(block *ledger.Block, blockHeight uint64, broadcasterTarget string)
+
 	// AddBlockFunc mocks the AddBlock method.
-	AddBlockFunc func(timestamp int64, transactions []*ledger.Transaction, newRegisteredAddresses []string) error
+	AddBlockFunc func(timestamp int64, transactions []*ledger.Transaction, newRegisteredAddresses []string, privateKey *encryption.PrivateKey) error
+
+	// AddressHistoryFunc mocks the AddressHistory method.
+	AddressHistoryFunc func(address string, offset uint64, limit uint64) ([]*ledger.AddressHistoryEntry, error)
+
+	// BlockFunc mocks the Block method.
+	BlockFunc func(blockHeight uint64) (*ledger.Block, error)
+
+	// BlockByHashFunc mocks the BlockByHash method.
+	BlockByHashFunc func(hash [32]byte) (*ledger.Block, error)
 
 	// BlocksFunc mocks the Blocks method.
 	BlocksFunc func(startingBlockHeight uint64) []*ledger.Block
 
+	// BlocksRangeFunc mocks the BlocksRange method.
+	BlocksRangeFunc func(startingBlockHeight uint64, endingBlockHeight uint64) ([]*ledger.Block, error)
+
 	// FirstBlockTimestampFunc mocks the FirstBlockTimestamp method.
 	FirstBlockTimestampFunc func() int64
 
+	// HeadersFunc mocks the Headers method.
+	HeadersFunc func(startingBlockHeight uint64) ([]*ledger.BlockHeader, error)
+
 	// LastBlockTimestampFunc mocks the LastBlockTimestamp method.
 	LastBlockTimestampFunc func() int64
 
 	// LastBlockTransactionsFunc mocks the LastBlockTransactions method.
 	LastBlockTransactionsFunc func() []*ledger.Transaction
 
+	// TipFunc mocks the Tip method.
+	TipFunc func() (*ledger.BlockHeader, error)
+
+	// TransactionByIdFunc mocks the TransactionById method.
+	TransactionByIdFunc func(transactionId string) (*ledger.IndexedTransaction, error)
+
+	// TransactionProofFunc mocks the TransactionProof method.
+	TransactionProofFunc func(transactionId string) (*ledger.MerkleProof, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AbandonedTransactions holds details about calls to the AbandonedTransactions method.
+		AbandonedTransactions []struct {
+		}
+		// AddAnnouncedBlock holds details about calls to the AddAnnouncedBlock method.
+		AddAnnouncedBlock []struct {
+			// Block is the block argument value.
+			Block *ledger.Block
+			// BlockHeight is the blockHeight argument value.
+			BlockHeight uint64
+			// BroadcasterTarget is the broadcasterTarget argument value.
+			BroadcasterTarget string
+		}
 		// AddBlock holds details about calls to the AddBlock method.
 		AddBlock []struct {
 			// Timestamp is the timestamp argument value.
@@ -65,31 +138,154 @@ type BlocksManagerMock struct {
 			Transactions []*ledger.Transaction
 			// NewRegisteredAddresses is the newRegisteredAddresses argument value.
 			NewRegisteredAddresses []string
+			// PrivateKey is the privateKey argument value.
+			PrivateKey *encryption.PrivateKey
+		}
+		// AddressHistory holds details about calls to the AddressHistory method.
+		AddressHistory []struct {
+			// Address is the address argument value.
+			Address string
+			// Offset is the offset argument value.
+			Offset uint64
+			// Limit is the limit argument value.
+			Limit uint64
+		}
+		// Block holds details about calls to the Block method.
+		Block []struct {
+			// BlockHeight is the blockHeight argument value.
+			BlockHeight uint64
+		}
+		// BlockByHash holds details about calls to the BlockByHash method.
+		BlockByHash []struct {
+			// Hash is the hash argument value.
+			Hash [32]byte
 		}
 		// Blocks holds details about calls to the Blocks method.
 		Blocks []struct {
 			// StartingBlockHeight is the startingBlockHeight argument value.
 			StartingBlockHeight uint64
 		}
+		// BlocksRange holds details about calls to the BlocksRange method.
+		BlocksRange []struct {
+			// StartingBlockHeight is the startingBlockHeight argument value.
+			StartingBlockHeight uint64
+			// EndingBlockHeight is the endingBlockHeight argument value.
+			EndingBlockHeight uint64
+		}
 		// FirstBlockTimestamp holds details about calls to the FirstBlockTimestamp method.
 		FirstBlockTimestamp []struct {
 		}
+		// Headers holds details about calls to the Headers method.
+		Headers []struct {
+			// StartingBlockHeight is the startingBlockHeight argument value.
+			StartingBlockHeight uint64
+		}
 		// LastBlockTimestamp holds details about calls to the LastBlockTimestamp method.
 		LastBlockTimestamp []struct {
 		}
 		// LastBlockTransactions holds details about calls to the LastBlockTransactions method.
 		LastBlockTransactions []struct {
 		}
+		// Tip holds details about calls to the Tip method.
+		Tip []struct {
+		}
+		// TransactionById holds details about calls to the TransactionById method.
+		TransactionById []struct {
+			// TransactionId is the transactionId argument value.
+			TransactionId string
+		}
+		// TransactionProof holds details about calls to the TransactionProof method.
+		TransactionProof []struct {
+			// TransactionId is the transactionId argument value.
+			TransactionId string
+		}
 	}
+	lockAbandonedTransactions sync.RWMutex
+	lockAddAnnouncedBlock     sync.RWMutex
 	lockAddBlock              sync.RWMutex
+	lockAddressHistory        sync.RWMutex
+	lockBlock                 sync.RWMutex
+	lockBlockByHash           sync.RWMutex
 	lockBlocks                sync.RWMutex
+	lockBlocksRange           sync.RWMutex
 	lockFirstBlockTimestamp   sync.RWMutex
+	lockHeaders               sync.RWMutex
 	lockLastBlockTimestamp    sync.RWMutex
 	lockLastBlockTransactions sync.RWMutex
+	lockTip                   sync.RWMutex
+	lockTransactionById       sync.RWMutex
+	lockTransactionProof      sync.RWMutex
+}
+
+// AbandonedTransactions calls AbandonedTransactionsFunc.
+func (mock *BlocksManagerMock) AbandonedTransactions() []*ledger.Transaction {
+	if mock.AbandonedTransactionsFunc == nil {
+		panic("BlocksManagerMock.AbandonedTransactionsFunc: method is nil but BlocksManager.AbandonedTransactions was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockAbandonedTransactions.Lock()
+	mock.calls.AbandonedTransactions = append(mock.calls.AbandonedTransactions, callInfo)
+	mock.lockAbandonedTransactions.Unlock()
+	return mock.AbandonedTransactionsFunc()
+}
+
+// AbandonedTransactionsCalls gets all the calls that were made to AbandonedTransactions.
+// Check the length with:
+//
+//	len(mockedBlocksManager.AbandonedTransactionsCalls())
+func (mock *BlocksManagerMock) AbandonedTransactionsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockAbandonedTransactions.RLock()
+	calls = mock.calls.AbandonedTransactions
+	mock.lockAbandonedTransactions.RUnlock()
+	return calls
+}
+
+// AddAnnouncedBlock calls AddAnnouncedBlockFunc.
+func (mock *BlocksManagerMock) AddAnnouncedBlock(block *ledger.Block, blockHeight uint64, broadcasterTarget string) {
+	if mock.AddAnnouncedBlockFunc == nil {
+		panic("BlocksManagerMock.AddAnnouncedBlockFunc: method is nil but BlocksManager.AddAnnouncedBlock was just called")
+	}
+	callInfo := struct {
+		Block             *ledger.Block
+		BlockHeight       uint64
+		BroadcasterTarget string
+	}{
+		Block:             block,
+		BlockHeight:       blockHeight,
+		BroadcasterTarget: broadcasterTarget,
+	}
+	mock.lockAddAnnouncedBlock.Lock()
+	mock.calls.AddAnnouncedBlock = append(mock.calls.AddAnnouncedBlock, callInfo)
+	mock.lockAddAnnouncedBlock.Unlock()
+	mock.AddAnnouncedBlockFunc(block, blockHeight, broadcasterTarget)
+}
+
+// AddAnnouncedBlockCalls gets all the calls that were made to AddAnnouncedBlock.
+// Check the length with:
+//
+//	len(mockedBlocksManager.AddAnnouncedBlockCalls())
+func (mock *BlocksManagerMock) AddAnnouncedBlockCalls() []struct {
+	Block             *ledger.Block
+	BlockHeight       uint64
+	BroadcasterTarget string
+} {
+	var calls []struct {
+		Block             *ledger.Block
+		BlockHeight       uint64
+		BroadcasterTarget string
+	}
+	mock.lockAddAnnouncedBlock.RLock()
+	calls = mock.calls.AddAnnouncedBlock
+	mock.lockAddAnnouncedBlock.RUnlock()
+	return calls
 }
 
 // AddBlock calls AddBlockFunc.
-func (mock *BlocksManagerMock) AddBlock(timestamp int64, transactions []*ledger.Transaction, newRegisteredAddresses []string) error {
+func (mock *BlocksManagerMock) AddBlock(timestamp int64, transactions []*ledger.Transaction, newRegisteredAddresses []string, privateKey *encryption.PrivateKey) error {
 	if mock.AddBlockFunc == nil {
 		panic("BlocksManagerMock.AddBlockFunc: method is nil but BlocksManager.AddBlock was just called")
 	}
@@ -97,15 +293,17 @@ func (mock *BlocksManagerMock) AddBlock(timestamp int64, transactions []*ledger.
 		Timestamp              int64
 		Transactions           []*ledger.Transaction
 		NewRegisteredAddresses []string
+		PrivateKey             *encryption.PrivateKey
 	}{
 		Timestamp:              timestamp,
 		Transactions:           transactions,
 		NewRegisteredAddresses: newRegisteredAddresses,
+		PrivateKey:             privateKey,
 	}
 	mock.lockAddBlock.Lock()
 	mock.calls.AddBlock = append(mock.calls.AddBlock, callInfo)
 	mock.lockAddBlock.Unlock()
-	return mock.AddBlockFunc(timestamp, transactions, newRegisteredAddresses)
+	return mock.AddBlockFunc(timestamp, transactions, newRegisteredAddresses, privateKey)
 }
 
 // AddBlockCalls gets all the calls that were made to AddBlock.
@@ -116,11 +314,13 @@ func (mock *BlocksManagerMock) AddBlockCalls() []struct {
 	Timestamp              int64
 	Transactions           []*ledger.Transaction
 	NewRegisteredAddresses []string
+	PrivateKey             *encryption.PrivateKey
 } {
 	var calls []struct {
 		Timestamp              int64
 		Transactions           []*ledger.Transaction
 		NewRegisteredAddresses []string
+		PrivateKey             *encryption.PrivateKey
 	}
 	mock.lockAddBlock.RLock()
 	calls = mock.calls.AddBlock
@@ -128,6 +328,110 @@ func (mock *BlocksManagerMock) AddBlockCalls() []struct {
 	return calls
 }
 
+// AddressHistory calls AddressHistoryFunc.
+func (mock *BlocksManagerMock) AddressHistory(address string, offset uint64, limit uint64) ([]*ledger.AddressHistoryEntry, error) {
+	if mock.AddressHistoryFunc == nil {
+		panic("BlocksManagerMock.AddressHistoryFunc: method is nil but BlocksManager.AddressHistory was just called")
+	}
+	callInfo := struct {
+		Address string
+		Offset  uint64
+		Limit   uint64
+	}{
+		Address: address,
+		Offset:  offset,
+		Limit:   limit,
+	}
+	mock.lockAddressHistory.Lock()
+	mock.calls.AddressHistory = append(mock.calls.AddressHistory, callInfo)
+	mock.lockAddressHistory.Unlock()
+	return mock.AddressHistoryFunc(address, offset, limit)
+}
+
+// AddressHistoryCalls gets all the calls that were made to AddressHistory.
+// Check the length with:
+//
+//	len(mockedBlocksManager.AddressHistoryCalls())
+func (mock *BlocksManagerMock) AddressHistoryCalls() []struct {
+	Address string
+	Offset  uint64
+	Limit   uint64
+} {
+	var calls []struct {
+		Address string
+		Offset  uint64
+		Limit   uint64
+	}
+	mock.lockAddressHistory.RLock()
+	calls = mock.calls.AddressHistory
+	mock.lockAddressHistory.RUnlock()
+	return calls
+}
+
+// Block calls BlockFunc.
+func (mock *BlocksManagerMock) Block(blockHeight uint64) (*ledger.Block, error) {
+	if mock.BlockFunc == nil {
+		panic("BlocksManagerMock.BlockFunc: method is nil but BlocksManager.Block was just called")
+	}
+	callInfo := struct {
+		BlockHeight uint64
+	}{
+		BlockHeight: blockHeight,
+	}
+	mock.lockBlock.Lock()
+	mock.calls.Block = append(mock.calls.Block, callInfo)
+	mock.lockBlock.Unlock()
+	return mock.BlockFunc(blockHeight)
+}
+
+// BlockCalls gets all the calls that were made to Block.
+// Check the length with:
+//
+//	len(mockedBlocksManager.BlockCalls())
+func (mock *BlocksManagerMock) BlockCalls() []struct {
+	BlockHeight uint64
+} {
+	var calls []struct {
+		BlockHeight uint64
+	}
+	mock.lockBlock.RLock()
+	calls = mock.calls.Block
+	mock.lockBlock.RUnlock()
+	return calls
+}
+
+// BlockByHash calls BlockByHashFunc.
+func (mock *BlocksManagerMock) BlockByHash(hash [32]byte) (*ledger.Block, error) {
+	if mock.BlockByHashFunc == nil {
+		panic("BlocksManagerMock.BlockByHashFunc: method is nil but BlocksManager.BlockByHash was just called")
+	}
+	callInfo := struct {
+		Hash [32]byte
+	}{
+		Hash: hash,
+	}
+	mock.lockBlockByHash.Lock()
+	mock.calls.BlockByHash = append(mock.calls.BlockByHash, callInfo)
+	mock.lockBlockByHash.Unlock()
+	return mock.BlockByHashFunc(hash)
+}
+
+// BlockByHashCalls gets all the calls that were made to BlockByHash.
+// Check the length with:
+//
+//	len(mockedBlocksManager.BlockByHashCalls())
+func (mock *BlocksManagerMock) BlockByHashCalls() []struct {
+	Hash [32]byte
+} {
+	var calls []struct {
+		Hash [32]byte
+	}
+	mock.lockBlockByHash.RLock()
+	calls = mock.calls.BlockByHash
+	mock.lockBlockByHash.RUnlock()
+	return calls
+}
+
 // Blocks calls BlocksFunc.
 func (mock *BlocksManagerMock) Blocks(startingBlockHeight uint64) []*ledger.Block {
 	if mock.BlocksFunc == nil {
@@ -160,6 +464,42 @@ func (mock *BlocksManagerMock) BlocksCalls() []struct {
 	return calls
 }
 
+// BlocksRange calls BlocksRangeFunc.
+func (mock *BlocksManagerMock) BlocksRange(startingBlockHeight uint64, endingBlockHeight uint64) ([]*ledger.Block, error) {
+	if mock.BlocksRangeFunc == nil {
+		panic("BlocksManagerMock.BlocksRangeFunc: method is nil but BlocksManager.BlocksRange was just called")
+	}
+	callInfo := struct {
+		StartingBlockHeight uint64
+		EndingBlockHeight   uint64
+	}{
+		StartingBlockHeight: startingBlockHeight,
+		EndingBlockHeight:   endingBlockHeight,
+	}
+	mock.lockBlocksRange.Lock()
+	mock.calls.BlocksRange = append(mock.calls.BlocksRange, callInfo)
+	mock.lockBlocksRange.Unlock()
+	return mock.BlocksRangeFunc(startingBlockHeight, endingBlockHeight)
+}
+
+// BlocksRangeCalls gets all the calls that were made to BlocksRange.
+// Check the length with:
+//
+//	len(mockedBlocksManager.BlocksRangeCalls())
+func (mock *BlocksManagerMock) BlocksRangeCalls() []struct {
+	StartingBlockHeight uint64
+	EndingBlockHeight   uint64
+} {
+	var calls []struct {
+		StartingBlockHeight uint64
+		EndingBlockHeight   uint64
+	}
+	mock.lockBlocksRange.RLock()
+	calls = mock.calls.BlocksRange
+	mock.lockBlocksRange.RUnlock()
+	return calls
+}
+
 // FirstBlockTimestamp calls FirstBlockTimestampFunc.
 func (mock *BlocksManagerMock) FirstBlockTimestamp() int64 {
 	if mock.FirstBlockTimestampFunc == nil {
@@ -187,6 +527,38 @@ func (mock *BlocksManagerMock) FirstBlockTimestampCalls() []struct {
 	return calls
 }
 
+// Headers calls HeadersFunc.
+func (mock *BlocksManagerMock) Headers(startingBlockHeight uint64) ([]*ledger.BlockHeader, error) {
+	if mock.HeadersFunc == nil {
+		panic("BlocksManagerMock.HeadersFunc: method is nil but BlocksManager.Headers was just called")
+	}
+	callInfo := struct {
+		StartingBlockHeight uint64
+	}{
+		StartingBlockHeight: startingBlockHeight,
+	}
+	mock.lockHeaders.Lock()
+	mock.calls.Headers = append(mock.calls.Headers, callInfo)
+	mock.lockHeaders.Unlock()
+	return mock.HeadersFunc(startingBlockHeight)
+}
+
+// HeadersCalls gets all the calls that were made to Headers.
+// Check the length with:
+//
+//	len(mockedBlocksManager.HeadersCalls())
+func (mock *BlocksManagerMock) HeadersCalls() []struct {
+	StartingBlockHeight uint64
+} {
+	var calls []struct {
+		StartingBlockHeight uint64
+	}
+	mock.lockHeaders.RLock()
+	calls = mock.calls.Headers
+	mock.lockHeaders.RUnlock()
+	return calls
+}
+
 // LastBlockTimestamp calls LastBlockTimestampFunc.
 func (mock *BlocksManagerMock) LastBlockTimestamp() int64 {
 	if mock.LastBlockTimestampFunc == nil {
@@ -240,3 +612,94 @@ func (mock *BlocksManagerMock) LastBlockTransactionsCalls() []struct {
 	mock.lockLastBlockTransactions.RUnlock()
 	return calls
 }
+
+// Tip calls TipFunc.
+func (mock *BlocksManagerMock) Tip() (*ledger.BlockHeader, error) {
+	if mock.TipFunc == nil {
+		panic("BlocksManagerMock.TipFunc: method is nil but BlocksManager.Tip was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockTip.Lock()
+	mock.calls.Tip = append(mock.calls.Tip, callInfo)
+	mock.lockTip.Unlock()
+	return mock.TipFunc()
+}
+
+// TipCalls gets all the calls that were made to Tip.
+// Check the length with:
+//
+//	len(mockedBlocksManager.TipCalls())
+func (mock *BlocksManagerMock) TipCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTip.RLock()
+	calls = mock.calls.Tip
+	mock.lockTip.RUnlock()
+	return calls
+}
+
+// TransactionById calls TransactionByIdFunc.
+func (mock *BlocksManagerMock) TransactionById(transactionId string) (*ledger.IndexedTransaction, error) {
+	if mock.TransactionByIdFunc == nil {
+		panic("BlocksManagerMock.TransactionByIdFunc: method is nil but BlocksManager.TransactionById was just called")
+	}
+	callInfo := struct {
+		TransactionId string
+	}{
+		TransactionId: transactionId,
+	}
+	mock.lockTransactionById.Lock()
+	mock.calls.TransactionById = append(mock.calls.TransactionById, callInfo)
+	mock.lockTransactionById.Unlock()
+	return mock.TransactionByIdFunc(transactionId)
+}
+
+// TransactionByIdCalls gets all the calls that were made to TransactionById.
+// Check the length with:
+//
+//	len(mockedBlocksManager.TransactionByIdCalls())
+func (mock *BlocksManagerMock) TransactionByIdCalls() []struct {
+	TransactionId string
+} {
+	var calls []struct {
+		TransactionId string
+	}
+	mock.lockTransactionById.RLock()
+	calls = mock.calls.TransactionById
+	mock.lockTransactionById.RUnlock()
+	return calls
+}
+
+// TransactionProof calls TransactionProofFunc.
+func (mock *BlocksManagerMock) TransactionProof(transactionId string) (*ledger.MerkleProof, error) {
+	if mock.TransactionProofFunc == nil {
+		panic("BlocksManagerMock.TransactionProofFunc: method is nil but BlocksManager.TransactionProof was just called")
+	}
+	callInfo := struct {
+		TransactionId string
+	}{
+		TransactionId: transactionId,
+	}
+	mock.lockTransactionProof.Lock()
+	mock.calls.TransactionProof = append(mock.calls.TransactionProof, callInfo)
+	mock.lockTransactionProof.Unlock()
+	return mock.TransactionProofFunc(transactionId)
+}
+
+// TransactionProofCalls gets all the calls that were made to TransactionProof.
+// Check the length with:
+//
+//	len(mockedBlocksManager.TransactionProofCalls())
+func (mock *BlocksManagerMock) TransactionProofCalls() []struct {
+	TransactionId string
+} {
+	var calls []struct {
+		TransactionId string
+	}
+	mock.lockTransactionProof.RLock()
+	calls = mock.calls.TransactionProof
+	mock.lockTransactionProof.RUnlock()
+	return calls
+}
@@ -0,0 +1,8 @@
+package application
+
+import "github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+
+type BlocksRelayer interface {
+	AnnounceBlock(header *ledger.BlockHeader)
+	FetchBlock(header *ledger.BlockHeader, broadcasterTarget string, remoteIp string) *ledger.Block
+}
@@ -0,0 +1,131 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package application
+
+import (
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"sync"
+)
+
+// Ensure, that BlocksRelayerMock does implement BlocksRelayer.
+// If this is not the case, regenerate this file with moq.
+var _ BlocksRelayer = &BlocksRelayerMock{}
+
+// BlocksRelayerMock is a mock implementation of BlocksRelayer.
+//
+//	func TestSomethingThatUsesBlocksRelayer(t *testing.T) {
+//
+//		// make and configure a mocked BlocksRelayer
+//		mockedBlocksRelayer := &BlocksRelayerMock{
+//			AnnounceBlockFunc: func(header *ledger.BlockHeader)  {
+//				panic("mock out the AnnounceBlock method")
+//			},
+//			FetchBlockFunc: func(header *ledger.BlockHeader, broadcasterTarget string, remoteIp string) *ledger.Block {
+//				panic("mock out the FetchBlock method")
+//			},
+//		}
+//
+//		// use mockedBlocksRelayer in code that requires BlocksRelayer
+//		// and then make assertions.
+//
+//	}
+type BlocksRelayerMock struct {
+	// AnnounceBlockFunc mocks the AnnounceBlock method.
+	AnnounceBlockFunc func(header *ledger.BlockHeader)
+
+	// FetchBlockFunc mocks the FetchBlock method.
+	FetchBlockFunc func(header *ledger.BlockHeader, broadcasterTarget string, remoteIp string) *ledger.Block
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AnnounceBlock holds details about calls to the AnnounceBlock method.
+		AnnounceBlock []struct {
+			// Header is the header argument value.
+			Header *ledger.BlockHeader
+		}
+		// FetchBlock holds details about calls to the FetchBlock method.
+		FetchBlock []struct {
+			// Header is the header argument value.
+			Header *ledger.BlockHeader
+			// BroadcasterTarget is the broadcasterTarget argument value.
+			BroadcasterTarget string
+			// RemoteIp is the remoteIp argument value.
+			RemoteIp string
+		}
+	}
+	lockAnnounceBlock sync.RWMutex
+	lockFetchBlock    sync.RWMutex
+}
+
+// AnnounceBlock calls AnnounceBlockFunc.
+func (mock *BlocksRelayerMock) AnnounceBlock(header *ledger.BlockHeader) {
+	if mock.AnnounceBlockFunc == nil {
+		panic("BlocksRelayerMock.AnnounceBlockFunc: method is nil but BlocksRelayer.AnnounceBlock was just called")
+	}
+	callInfo := struct {
+		Header *ledger.BlockHeader
+	}{
+		Header: header,
+	}
+	mock.lockAnnounceBlock.Lock()
+	mock.calls.AnnounceBlock = append(mock.calls.AnnounceBlock, callInfo)
+	mock.lockAnnounceBlock.Unlock()
+	mock.AnnounceBlockFunc(header)
+}
+
+// AnnounceBlockCalls gets all the calls that were made to AnnounceBlock.
+// Check the length with:
+//
+//	len(mockedBlocksRelayer.AnnounceBlockCalls())
+func (mock *BlocksRelayerMock) AnnounceBlockCalls() []struct {
+	Header *ledger.BlockHeader
+} {
+	var calls []struct {
+		Header *ledger.BlockHeader
+	}
+	mock.lockAnnounceBlock.RLock()
+	calls = mock.calls.AnnounceBlock
+	mock.lockAnnounceBlock.RUnlock()
+	return calls
+}
+
+// FetchBlock calls FetchBlockFunc.
+func (mock *BlocksRelayerMock) FetchBlock(header *ledger.BlockHeader, broadcasterTarget string, remoteIp string) *ledger.Block {
+	if mock.FetchBlockFunc == nil {
+		panic("BlocksRelayerMock.FetchBlockFunc: method is nil but BlocksRelayer.FetchBlock was just called")
+	}
+	callInfo := struct {
+		Header            *ledger.BlockHeader
+		BroadcasterTarget string
+		RemoteIp          string
+	}{
+		Header:            header,
+		BroadcasterTarget: broadcasterTarget,
+		RemoteIp:          remoteIp,
+	}
+	mock.lockFetchBlock.Lock()
+	mock.calls.FetchBlock = append(mock.calls.FetchBlock, callInfo)
+	mock.lockFetchBlock.Unlock()
+	return mock.FetchBlockFunc(header, broadcasterTarget, remoteIp)
+}
+
+// FetchBlockCalls gets all the calls that were made to FetchBlock.
+// Check the length with:
+//
+//	len(mockedBlocksRelayer.FetchBlockCalls())
+func (mock *BlocksRelayerMock) FetchBlockCalls() []struct {
+	Header            *ledger.BlockHeader
+	BroadcasterTarget string
+	RemoteIp          string
+} {
+	var calls []struct {
+		Header            *ledger.BlockHeader
+		BroadcasterTarget string
+		RemoteIp          string
+	}
+	mock.lockFetchBlock.RLock()
+	calls = mock.calls.FetchBlock
+	mock.lockFetchBlock.RUnlock()
+	return calls
+}
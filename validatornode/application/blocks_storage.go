@@ -0,0 +1,9 @@
+package application
+
+import "github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+
+type BlocksStorage interface {
+	AddBlock(block *ledger.Block) error
+	Blocks() ([]*ledger.Block, error)
+	Truncate(blocksCount uint64) error
+}
@@ -0,0 +1,156 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package application
+
+import (
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"sync"
+)
+
+// Ensure, that BlocksStorageMock does implement BlocksStorage.
+// If this is not the case, regenerate this file with moq.
+var _ BlocksStorage = &BlocksStorageMock{}
+
+// BlocksStorageMock is a mock implementation of BlocksStorage.
+//
+//	func TestSomethingThatUsesBlocksStorage(t *testing.T) {
+//
+//		// make and configure a mocked BlocksStorage
+//		mockedBlocksStorage := &BlocksStorageMock{
+//			AddBlockFunc: func(block *ledger.Block) error {
+//				panic("mock out the AddBlock method")
+//			},
+//			BlocksFunc: func() ([]*ledger.Block, error) {
+//				panic("mock out the Blocks method")
+//			},
+//			TruncateFunc: func(blocksCount uint64) error {
+//				panic("mock out the Truncate method")
+//			},
+//		}
+//
+//		// use mockedBlocksStorage in code that requires BlocksStorage
+//		// and then make assertions.
+//
+//	}
+type BlocksStorageMock struct {
+	// AddBlockFunc mocks the AddBlock method.
+	AddBlockFunc func(block *ledger.Block) error
+
+	// BlocksFunc mocks the Blocks method.
+	BlocksFunc func() ([]*ledger.Block, error)
+
+	// TruncateFunc mocks the Truncate method.
+	TruncateFunc func(blocksCount uint64) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddBlock holds details about calls to the AddBlock method.
+		AddBlock []struct {
+			// Block is the block argument value.
+			Block *ledger.Block
+		}
+		// Blocks holds details about calls to the Blocks method.
+		Blocks []struct {
+		}
+		// Truncate holds details about calls to the Truncate method.
+		Truncate []struct {
+			// BlocksCount is the blocksCount argument value.
+			BlocksCount uint64
+		}
+	}
+	lockAddBlock sync.RWMutex
+	lockBlocks   sync.RWMutex
+	lockTruncate sync.RWMutex
+}
+
+// AddBlock calls AddBlockFunc.
+func (mock *BlocksStorageMock) AddBlock(block *ledger.Block) error {
+	if mock.AddBlockFunc == nil {
+		panic("BlocksStorageMock.AddBlockFunc: method is nil but BlocksStorage.AddBlock was just called")
+	}
+	callInfo := struct {
+		Block *ledger.Block
+	}{
+		Block: block,
+	}
+	mock.lockAddBlock.Lock()
+	mock.calls.AddBlock = append(mock.calls.AddBlock, callInfo)
+	mock.lockAddBlock.Unlock()
+	return mock.AddBlockFunc(block)
+}
+
+// AddBlockCalls gets all the calls that were made to AddBlock.
+// Check the length with:
+//
+//	len(mockedBlocksStorage.AddBlockCalls())
+func (mock *BlocksStorageMock) AddBlockCalls() []struct {
+	Block *ledger.Block
+} {
+	var calls []struct {
+		Block *ledger.Block
+	}
+	mock.lockAddBlock.RLock()
+	calls = mock.calls.AddBlock
+	mock.lockAddBlock.RUnlock()
+	return calls
+}
+
+// Blocks calls BlocksFunc.
+func (mock *BlocksStorageMock) Blocks() ([]*ledger.Block, error) {
+	if mock.BlocksFunc == nil {
+		panic("BlocksStorageMock.BlocksFunc: method is nil but BlocksStorage.Blocks was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockBlocks.Lock()
+	mock.calls.Blocks = append(mock.calls.Blocks, callInfo)
+	mock.lockBlocks.Unlock()
+	return mock.BlocksFunc()
+}
+
+// BlocksCalls gets all the calls that were made to Blocks.
+// Check the length with:
+//
+//	len(mockedBlocksStorage.BlocksCalls())
+func (mock *BlocksStorageMock) BlocksCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockBlocks.RLock()
+	calls = mock.calls.Blocks
+	mock.lockBlocks.RUnlock()
+	return calls
+}
+
+// Truncate calls TruncateFunc.
+func (mock *BlocksStorageMock) Truncate(blocksCount uint64) error {
+	if mock.TruncateFunc == nil {
+		panic("BlocksStorageMock.TruncateFunc: method is nil but BlocksStorage.Truncate was just called")
+	}
+	callInfo := struct {
+		BlocksCount uint64
+	}{
+		BlocksCount: blocksCount,
+	}
+	mock.lockTruncate.Lock()
+	mock.calls.Truncate = append(mock.calls.Truncate, callInfo)
+	mock.lockTruncate.Unlock()
+	return mock.TruncateFunc(blocksCount)
+}
+
+// TruncateCalls gets all the calls that were made to Truncate.
+// Check the length with:
+//
+//	len(mockedBlocksStorage.TruncateCalls())
+func (mock *BlocksStorageMock) TruncateCalls() []struct {
+	BlocksCount uint64
+} {
+	var calls []struct {
+		BlocksCount uint64
+	}
+	mock.lockTruncate.RLock()
+	calls = mock.calls.Truncate
+	mock.lockTruncate.RUnlock()
+	return calls
+}
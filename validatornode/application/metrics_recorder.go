@@ -0,0 +1,13 @@
+package application
+
+import "time"
+
+type MetricsRecorder interface {
+	IncrementProducedBlocks()
+	IncrementRejectedBlocks()
+	IncrementRejectedRequests(reason string)
+	ObserveBlockchainUpdate(duration time.Duration, isReplaced bool, isForked bool)
+	ObserveRegistrySynchronization(keptAddressesCount int, removedAddressesCount int, failedChecksCount int)
+	ObserveRequest(topic string, duration time.Duration, isSuccessful bool)
+	ObserveTask(task string, duration time.Duration, isSuccessful bool, isOverrun bool)
+}
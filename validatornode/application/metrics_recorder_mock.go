@@ -0,0 +1,379 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package application
+
+import (
+	"sync"
+	"time"
+)
+
+// Ensure, that MetricsRecorderMock does implement MetricsRecorder.
+// If this is not the case, regenerate this file with moq.
+var _ MetricsRecorder = &MetricsRecorderMock{}
+
+// MetricsRecorderMock is a mock implementation of MetricsRecorder.
+//
+//	func TestSomethingThatUsesMetricsRecorder(t *testing.T) {
+//
+//		// make and configure a mocked MetricsRecorder
+//		mockedMetricsRecorder := &MetricsRecorderMock{
+//			IncrementProducedBlocksFunc: func()  {
+//				panic("mock out the IncrementProducedBlocks method")
+//			},
+//			IncrementRejectedBlocksFunc: func()  {
+//				panic("mock out the IncrementRejectedBlocks method")
+//			},
+//			IncrementRejectedRequestsFunc: func(reason string)  {
+//				panic("mock out the IncrementRejectedRequests method")
+//			},
+//			ObserveBlockchainUpdateFunc: func(duration time.Duration, isReplaced bool, isForked bool)  {
+//				panic("mock out the ObserveBlockchainUpdate method")
+//			},
+//			ObserveRegistrySynchronizationFunc: func(keptAddressesCount int, removedAddressesCount int, failedChecksCount int)  {
+//				panic("mock out the ObserveRegistrySynchronization method")
+//			},
+//			ObserveRequestFunc: func(topic string, duration time.Duration, isSuccessful bool)  {
+//				panic("mock out the ObserveRequest method")
+//			},
+//			ObserveTaskFunc: func(task string, duration time.Duration, isSuccessful bool, isOverrun bool)  {
+//				panic("mock out the ObserveTask method")
+//			},
+//		}
+//
+//		// use mockedMetricsRecorder in code that requires MetricsRecorder
+//		// and then make assertions.
+//
+//	}
+type MetricsRecorderMock struct {
+	// IncrementProducedBlocksFunc mocks the IncrementProducedBlocks method.
+	IncrementProducedBlocksFunc func()
+
+	// IncrementRejectedBlocksFunc mocks the IncrementRejectedBlocks method.
+	IncrementRejectedBlocksFunc func()
+
+	// IncrementRejectedRequestsFunc mocks the IncrementRejectedRequests method.
+	IncrementRejectedRequestsFunc func(reason string)
+
+	// ObserveBlockchainUpdateFunc mocks the ObserveBlockchainUpdate method.
+	ObserveBlockchainUpdateFunc func(duration time.Duration, isReplaced bool, isForked bool)
+
+	// ObserveRegistrySynchronizationFunc mocks the ObserveRegistrySynchronization method.
+	ObserveRegistrySynchronizationFunc func(keptAddressesCount int, removedAddressesCount int, failedChecksCount int)
+
+	// ObserveRequestFunc mocks the ObserveRequest method.
+	ObserveRequestFunc func(topic string, duration time.Duration, isSuccessful bool)
+
+	// ObserveTaskFunc mocks the ObserveTask method.
+	ObserveTaskFunc func(task string, duration time.Duration, isSuccessful bool, isOverrun bool)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// IncrementProducedBlocks holds details about calls to the IncrementProducedBlocks method.
+		IncrementProducedBlocks []struct {
+		}
+		// IncrementRejectedBlocks holds details about calls to the IncrementRejectedBlocks method.
+		IncrementRejectedBlocks []struct {
+		}
+		// IncrementRejectedRequests holds details about calls to the IncrementRejectedRequests method.
+		IncrementRejectedRequests []struct {
+			// Reason is the reason argument value.
+			Reason string
+		}
+		// ObserveBlockchainUpdate holds details about calls to the ObserveBlockchainUpdate method.
+		ObserveBlockchainUpdate []struct {
+			// Duration is the duration argument value.
+			Duration time.Duration
+			// IsReplaced is the isReplaced argument value.
+			IsReplaced bool
+			// IsForked is the isForked argument value.
+			IsForked bool
+		}
+		// ObserveRegistrySynchronization holds details about calls to the ObserveRegistrySynchronization method.
+		ObserveRegistrySynchronization []struct {
+			// KeptAddressesCount is the keptAddressesCount argument value.
+			KeptAddressesCount int
+			// RemovedAddressesCount is the removedAddressesCount argument value.
+			RemovedAddressesCount int
+			// FailedChecksCount is the failedChecksCount argument value.
+			FailedChecksCount int
+		}
+		// ObserveRequest holds details about calls to the ObserveRequest method.
+		ObserveRequest []struct {
+			// Topic is the topic argument value.
+			Topic string
+			// Duration is the duration argument value.
+			Duration time.Duration
+			// IsSuccessful is the isSuccessful argument value.
+			IsSuccessful bool
+		}
+		// ObserveTask holds details about calls to the ObserveTask method.
+		ObserveTask []struct {
+			// Task is the task argument value.
+			Task string
+			// Duration is the duration argument value.
+			Duration time.Duration
+			// IsSuccessful is the isSuccessful argument value.
+			IsSuccessful bool
+			// IsOverrun is the isOverrun argument value.
+			IsOverrun bool
+		}
+	}
+	lockIncrementProducedBlocks        sync.RWMutex
+	lockIncrementRejectedBlocks        sync.RWMutex
+	lockIncrementRejectedRequests      sync.RWMutex
+	lockObserveBlockchainUpdate        sync.RWMutex
+	lockObserveRegistrySynchronization sync.RWMutex
+	lockObserveRequest                 sync.RWMutex
+	lockObserveTask                    sync.RWMutex
+}
+
+// IncrementProducedBlocks calls IncrementProducedBlocksFunc.
+func (mock *MetricsRecorderMock) IncrementProducedBlocks() {
+	if mock.IncrementProducedBlocksFunc == nil {
+		panic("MetricsRecorderMock.IncrementProducedBlocksFunc: method is nil but MetricsRecorder.IncrementProducedBlocks was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIncrementProducedBlocks.Lock()
+	mock.calls.IncrementProducedBlocks = append(mock.calls.IncrementProducedBlocks, callInfo)
+	mock.lockIncrementProducedBlocks.Unlock()
+	mock.IncrementProducedBlocksFunc()
+}
+
+// IncrementProducedBlocksCalls gets all the calls that were made to IncrementProducedBlocks.
+// Check the length with:
+//
+//	len(mockedMetricsRecorder.IncrementProducedBlocksCalls())
+func (mock *MetricsRecorderMock) IncrementProducedBlocksCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIncrementProducedBlocks.RLock()
+	calls = mock.calls.IncrementProducedBlocks
+	mock.lockIncrementProducedBlocks.RUnlock()
+	return calls
+}
+
+// IncrementRejectedBlocks calls IncrementRejectedBlocksFunc.
+func (mock *MetricsRecorderMock) IncrementRejectedBlocks() {
+	if mock.IncrementRejectedBlocksFunc == nil {
+		panic("MetricsRecorderMock.IncrementRejectedBlocksFunc: method is nil but MetricsRecorder.IncrementRejectedBlocks was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockIncrementRejectedBlocks.Lock()
+	mock.calls.IncrementRejectedBlocks = append(mock.calls.IncrementRejectedBlocks, callInfo)
+	mock.lockIncrementRejectedBlocks.Unlock()
+	mock.IncrementRejectedBlocksFunc()
+}
+
+// IncrementRejectedBlocksCalls gets all the calls that were made to IncrementRejectedBlocks.
+// Check the length with:
+//
+//	len(mockedMetricsRecorder.IncrementRejectedBlocksCalls())
+func (mock *MetricsRecorderMock) IncrementRejectedBlocksCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIncrementRejectedBlocks.RLock()
+	calls = mock.calls.IncrementRejectedBlocks
+	mock.lockIncrementRejectedBlocks.RUnlock()
+	return calls
+}
+
+// IncrementRejectedRequests calls IncrementRejectedRequestsFunc.
+func (mock *MetricsRecorderMock) IncrementRejectedRequests(reason string) {
+	if mock.IncrementRejectedRequestsFunc == nil {
+		panic("MetricsRecorderMock.IncrementRejectedRequestsFunc: method is nil but MetricsRecorder.IncrementRejectedRequests was just called")
+	}
+	callInfo := struct {
+		Reason string
+	}{
+		Reason: reason,
+	}
+	mock.lockIncrementRejectedRequests.Lock()
+	mock.calls.IncrementRejectedRequests = append(mock.calls.IncrementRejectedRequests, callInfo)
+	mock.lockIncrementRejectedRequests.Unlock()
+	mock.IncrementRejectedRequestsFunc(reason)
+}
+
+// IncrementRejectedRequestsCalls gets all the calls that were made to IncrementRejectedRequests.
+// Check the length with:
+//
+//	len(mockedMetricsRecorder.IncrementRejectedRequestsCalls())
+func (mock *MetricsRecorderMock) IncrementRejectedRequestsCalls() []struct {
+	Reason string
+} {
+	var calls []struct {
+		Reason string
+	}
+	mock.lockIncrementRejectedRequests.RLock()
+	calls = mock.calls.IncrementRejectedRequests
+	mock.lockIncrementRejectedRequests.RUnlock()
+	return calls
+}
+
+// ObserveBlockchainUpdate calls ObserveBlockchainUpdateFunc.
+func (mock *MetricsRecorderMock) ObserveBlockchainUpdate(duration time.Duration, isReplaced bool, isForked bool) {
+	if mock.ObserveBlockchainUpdateFunc == nil {
+		panic("MetricsRecorderMock.ObserveBlockchainUpdateFunc: method is nil but MetricsRecorder.ObserveBlockchainUpdate was just called")
+	}
+	callInfo := struct {
+		Duration   time.Duration
+		IsReplaced bool
+		IsForked   bool
+	}{
+		Duration:   duration,
+		IsReplaced: isReplaced,
+		IsForked:   isForked,
+	}
+	mock.lockObserveBlockchainUpdate.Lock()
+	mock.calls.ObserveBlockchainUpdate = append(mock.calls.ObserveBlockchainUpdate, callInfo)
+	mock.lockObserveBlockchainUpdate.Unlock()
+	mock.ObserveBlockchainUpdateFunc(duration, isReplaced, isForked)
+}
+
+// ObserveBlockchainUpdateCalls gets all the calls that were made to ObserveBlockchainUpdate.
+// Check the length with:
+//
+//	len(mockedMetricsRecorder.ObserveBlockchainUpdateCalls())
+func (mock *MetricsRecorderMock) ObserveBlockchainUpdateCalls() []struct {
+	Duration   time.Duration
+	IsReplaced bool
+	IsForked   bool
+} {
+	var calls []struct {
+		Duration   time.Duration
+		IsReplaced bool
+		IsForked   bool
+	}
+	mock.lockObserveBlockchainUpdate.RLock()
+	calls = mock.calls.ObserveBlockchainUpdate
+	mock.lockObserveBlockchainUpdate.RUnlock()
+	return calls
+}
+
+// ObserveRegistrySynchronization calls ObserveRegistrySynchronizationFunc.
+func (mock *MetricsRecorderMock) ObserveRegistrySynchronization(keptAddressesCount int, removedAddressesCount int, failedChecksCount int) {
+	if mock.ObserveRegistrySynchronizationFunc == nil {
+		panic("MetricsRecorderMock.ObserveRegistrySynchronizationFunc: method is nil but MetricsRecorder.ObserveRegistrySynchronization was just called")
+	}
+	callInfo := struct {
+		KeptAddressesCount    int
+		RemovedAddressesCount int
+		FailedChecksCount     int
+	}{
+		KeptAddressesCount:    keptAddressesCount,
+		RemovedAddressesCount: removedAddressesCount,
+		FailedChecksCount:     failedChecksCount,
+	}
+	mock.lockObserveRegistrySynchronization.Lock()
+	mock.calls.ObserveRegistrySynchronization = append(mock.calls.ObserveRegistrySynchronization, callInfo)
+	mock.lockObserveRegistrySynchronization.Unlock()
+	mock.ObserveRegistrySynchronizationFunc(keptAddressesCount, removedAddressesCount, failedChecksCount)
+}
+
+// ObserveRegistrySynchronizationCalls gets all the calls that were made to ObserveRegistrySynchronization.
+// Check the length with:
+//
+//	len(mockedMetricsRecorder.ObserveRegistrySynchronizationCalls())
+func (mock *MetricsRecorderMock) ObserveRegistrySynchronizationCalls() []struct {
+	KeptAddressesCount    int
+	RemovedAddressesCount int
+	FailedChecksCount     int
+} {
+	var calls []struct {
+		KeptAddressesCount    int
+		RemovedAddressesCount int
+		FailedChecksCount     int
+	}
+	mock.lockObserveRegistrySynchronization.RLock()
+	calls = mock.calls.ObserveRegistrySynchronization
+	mock.lockObserveRegistrySynchronization.RUnlock()
+	return calls
+}
+
+// ObserveRequest calls ObserveRequestFunc.
+func (mock *MetricsRecorderMock) ObserveRequest(topic string, duration time.Duration, isSuccessful bool) {
+	if mock.ObserveRequestFunc == nil {
+		panic("MetricsRecorderMock.ObserveRequestFunc: method is nil but MetricsRecorder.ObserveRequest was just called")
+	}
+	callInfo := struct {
+		Topic        string
+		Duration     time.Duration
+		IsSuccessful bool
+	}{
+		Topic:        topic,
+		Duration:     duration,
+		IsSuccessful: isSuccessful,
+	}
+	mock.lockObserveRequest.Lock()
+	mock.calls.ObserveRequest = append(mock.calls.ObserveRequest, callInfo)
+	mock.lockObserveRequest.Unlock()
+	mock.ObserveRequestFunc(topic, duration, isSuccessful)
+}
+
+// ObserveRequestCalls gets all the calls that were made to ObserveRequest.
+// Check the length with:
+//
+//	len(mockedMetricsRecorder.ObserveRequestCalls())
+func (mock *MetricsRecorderMock) ObserveRequestCalls() []struct {
+	Topic        string
+	Duration     time.Duration
+	IsSuccessful bool
+} {
+	var calls []struct {
+		Topic        string
+		Duration     time.Duration
+		IsSuccessful bool
+	}
+	mock.lockObserveRequest.RLock()
+	calls = mock.calls.ObserveRequest
+	mock.lockObserveRequest.RUnlock()
+	return calls
+}
+
+// ObserveTask calls ObserveTaskFunc.
+func (mock *MetricsRecorderMock) ObserveTask(task string, duration time.Duration, isSuccessful bool, isOverrun bool) {
+	if mock.ObserveTaskFunc == nil {
+		panic("MetricsRecorderMock.ObserveTaskFunc: method is nil but MetricsRecorder.ObserveTask was just called")
+	}
+	callInfo := struct {
+		Task         string
+		Duration     time.Duration
+		IsSuccessful bool
+		IsOverrun    bool
+	}{
+		Task:         task,
+		Duration:     duration,
+		IsSuccessful: isSuccessful,
+		IsOverrun:    isOverrun,
+	}
+	mock.lockObserveTask.Lock()
+	mock.calls.ObserveTask = append(mock.calls.ObserveTask, callInfo)
+	mock.lockObserveTask.Unlock()
+	mock.ObserveTaskFunc(task, duration, isSuccessful, isOverrun)
+}
+
+// ObserveTaskCalls gets all the calls that were made to ObserveTask.
+// Check the length with:
+//
+//	len(mockedMetricsRecorder.ObserveTaskCalls())
+func (mock *MetricsRecorderMock) ObserveTaskCalls() []struct {
+	Task         string
+	Duration     time.Duration
+	IsSuccessful bool
+	IsOverrun    bool
+} {
+	var calls []struct {
+		Task         string
+		Duration     time.Duration
+		IsSuccessful bool
+		IsOverrun    bool
+	}
+	mock.lockObserveTask.RLock()
+	calls = mock.calls.ObserveTask
+	mock.lockObserveTask.RUnlock()
+	return calls
+}
@@ -0,0 +1,10 @@
+package application
+
+type Misbehavior int
+
+const (
+	ForkMisbehavior Misbehavior = iota
+	InvalidBlocksMisbehavior
+	MalformedResponseMisbehavior
+	TimeoutMisbehavior
+)
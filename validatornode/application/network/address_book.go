@@ -0,0 +1,89 @@
+package network
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+)
+
+type addressBookRecord struct {
+	failuresCount int
+	lastSeen      time.Time
+	score         int
+}
+
+type addressBook struct {
+	recordsByTargetValue map[string]*addressBookRecord
+	entryLifetime        time.Duration
+	maxFailuresCount     int
+	mutex                sync.RWMutex
+}
+
+func newAddressBook(entryLifetime time.Duration, maxFailuresCount int) *addressBook {
+	return &addressBook{map[string]*addressBookRecord{}, entryLifetime, maxFailuresCount, sync.RWMutex{}}
+}
+
+func (book *addressBook) entries() []*ledger.AddressBookEntry {
+	book.mutex.RLock()
+	defer book.mutex.RUnlock()
+	var targetValues []string
+	for targetValue := range book.recordsByTargetValue {
+		targetValues = append(targetValues, targetValue)
+	}
+	sort.Strings(targetValues)
+	entries := make([]*ledger.AddressBookEntry, len(targetValues))
+	for i, targetValue := range targetValues {
+		record := book.recordsByTargetValue[targetValue]
+		entries[i] = ledger.NewAddressBookEntry(record.failuresCount, record.lastSeen.UnixNano(), record.score, targetValue)
+	}
+	return entries
+}
+
+func (book *addressBook) load(entries []*ledger.AddressBookEntry) {
+	book.mutex.Lock()
+	defer book.mutex.Unlock()
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		book.recordsByTargetValue[entry.Target()] = &addressBookRecord{entry.FailuresCount(), time.Unix(0, entry.LastSeenTimestamp()), entry.Score()}
+	}
+}
+
+func (book *addressBook) prune(now time.Time) {
+	book.mutex.Lock()
+	defer book.mutex.Unlock()
+	for targetValue, record := range book.recordsByTargetValue {
+		isStale := book.entryLifetime > 0 && now.Sub(record.lastSeen) > book.entryLifetime
+		isFailing := book.maxFailuresCount > 0 && record.failuresCount >= book.maxFailuresCount
+		if isStale || isFailing {
+			delete(book.recordsByTargetValue, targetValue)
+		}
+	}
+}
+
+func (book *addressBook) recordFailure(targetValue string) {
+	book.mutex.Lock()
+	defer book.mutex.Unlock()
+	if record, isKnown := book.recordsByTargetValue[targetValue]; isKnown {
+		record.failuresCount++
+	}
+}
+
+func (book *addressBook) recordSuccess(targetValue string, score int, now time.Time) {
+	book.mutex.Lock()
+	defer book.mutex.Unlock()
+	book.recordsByTargetValue[targetValue] = &addressBookRecord{0, now, score}
+}
+
+func (book *addressBook) scoresByTargetValue() map[string]int {
+	book.mutex.RLock()
+	defer book.mutex.RUnlock()
+	scoresByTargetValue := map[string]int{}
+	for targetValue, record := range book.recordsByTargetValue {
+		scoresByTargetValue[targetValue] = record.score
+	}
+	return scoresByTargetValue
+}
@@ -0,0 +1,94 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+)
+
+// A validator produces at most one block per validation, so a remote IP announcing more blocks than this is flooding
+const maxFetchedBlocksPerSecond = 1
+
+type BlocksRelay struct {
+	inboundLimiter *RateLimiter
+	seenCache      *SeenCache
+	senderCreator  application.SenderCreator
+	sendersManager application.SendersManager
+	logger         log.Logger
+}
+
+func NewBlocksRelay(senderCreator application.SenderCreator, sendersManager application.SendersManager, seenBlocksTtl time.Duration, watch application.TimeProvider, logger log.Logger) *BlocksRelay {
+	relay := new(BlocksRelay)
+	relay.inboundLimiter = NewRateLimiter(maxFetchedBlocksPerSecond, watch)
+	relay.seenCache = NewSeenCache(seenBlocksTtl, watch)
+	relay.senderCreator = senderCreator
+	relay.sendersManager = sendersManager
+	relay.logger = logger
+	return relay
+}
+
+func (relay *BlocksRelay) AnnounceBlock(header *ledger.BlockHeader) {
+	relay.seenCache.Add(fmt.Sprintf("%x", header.Hash()))
+	announcement := ledger.NewBlockAnnouncement(header, relay.sendersManager.HostTarget())
+	marshaledAnnouncement, err := json.Marshal(announcement)
+	if err != nil {
+		relay.logger.Debug(fmt.Errorf("failed to marshal block announcement: %w", err).Error())
+		return
+	}
+	for _, sender := range relay.sendersManager.Senders() {
+		go func(sender application.Sender) {
+			_ = sender.AnnounceBlock(marshaledAnnouncement)
+		}(sender)
+	}
+}
+
+func (relay *BlocksRelay) FetchBlock(header *ledger.BlockHeader, broadcasterTarget string, remoteIp string) *ledger.Block {
+	hashValue := fmt.Sprintf("%x", header.Hash())
+	if relay.seenCache.Contains(hashValue) {
+		return nil
+	}
+	if relay.inboundLimiter.Allow(remoteIp, 1) == 0 {
+		relay.logger.With(log.TargetKey, remoteIp).Debug(fmt.Sprintf("relay rate limit reached for announcer %s: announced block ignored", remoteIp))
+		return nil
+	}
+	if !relay.seenCache.Add(hashValue) {
+		return nil
+	}
+	block, err := relay.fetchBlock(header, broadcasterTarget)
+	if err != nil {
+		relay.seenCache.Remove(hashValue)
+		relay.logger.With(log.TargetKey, broadcasterTarget).With(log.BlockHeightKey, header.Height()).With(log.ErrorKey, err).Debug(fmt.Errorf("failed to fetch announced block: %w", err).Error())
+		return nil
+	}
+	return block
+}
+
+func (relay *BlocksRelay) fetchBlock(header *ledger.BlockHeader, broadcasterTarget string) (*ledger.Block, error) {
+	target, err := NewTargetFromValue(broadcasterTarget)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := relay.senderCreator.CreateSender(target.Ip(), target.Port())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sender for target %s: %w", broadcasterTarget, err)
+	}
+	blockBytes, err := sender.GetBlockByHash(header.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block from target %s: %w", broadcasterTarget, err)
+	}
+	var block *ledger.Block
+	if err = json.Unmarshal(blockBytes, &block); err != nil || block == nil {
+		relay.sendersManager.Penalize(broadcasterTarget, application.MalformedResponseMisbehavior)
+		return nil, fmt.Errorf("failed to unmarshal block from target %s: %v", broadcasterTarget, err)
+	}
+	hash, err := block.Hash()
+	if err != nil || hash != header.Hash() {
+		relay.sendersManager.Penalize(broadcasterTarget, application.MalformedResponseMisbehavior)
+		return nil, fmt.Errorf("block from target %s does not match the announced header", broadcasterTarget)
+	}
+	return block, nil
+}
@@ -0,0 +1,104 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_FetchBlock_BlockAlreadyAnnounced_BlockNotFetched(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.HostTargetFunc = func() string { return "0.0.0.0:0" }
+	sendersManagerMock.SendersFunc = func() []application.Sender { return nil }
+	relay := NewBlocksRelay(senderCreatorMock, sendersManagerMock, time.Minute, watchMock, log.NewLoggerMock())
+	header, _ := ledger.NewBlockHeader(ledger.NewBlock([32]byte{}, nil, nil, 0, nil), 1)
+	relay.AnnounceBlock(header)
+
+	// Act
+	block := relay.FetchBlock(header, "0.0.0.0:1", "0.0.0.0")
+
+	// Assert
+	test.Assert(t, block == nil, "Block is fetched whereas it should not.")
+	isSenderCreated := len(senderCreatorMock.CreateSenderCalls()) != 0
+	test.Assert(t, !isSenderCreated, "Sender is created whereas it should not.")
+}
+
+func Test_FetchBlock_UnknownBlock_BlockFetchedOnce(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	announcedBlock := ledger.NewBlock([32]byte{}, nil, nil, 0, nil)
+	senderMock := new(application.SenderMock)
+	senderMock.GetBlockByHashFunc = func([32]byte) ([]byte, error) { return json.Marshal(announcedBlock) }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
+	relay := NewBlocksRelay(senderCreatorMock, nil, time.Minute, watchMock, log.NewLoggerMock())
+	header, _ := ledger.NewBlockHeader(announcedBlock, 1)
+
+	// Act
+	block := relay.FetchBlock(header, "0.0.0.0:1", "0.0.0.0")
+	_ = relay.FetchBlock(header, "0.0.0.0:1", "0.0.0.1")
+
+	// Assert
+	test.Assert(t, block != nil, "Block is not fetched whereas it should be.")
+	expectedFetchesCount := 1
+	actualFetchesCount := len(senderMock.GetBlockByHashCalls())
+	test.Assert(t, actualFetchesCount == expectedFetchesCount, fmt.Sprintf("Wrong fetches count. Expected: %d - Actual: %d", expectedFetchesCount, actualFetchesCount))
+}
+
+func Test_FetchBlock_BlockDoesNotMatchHeader_NeighborPenalized(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	otherBlock := ledger.NewBlock([32]byte{}, nil, nil, 1, nil)
+	senderMock := new(application.SenderMock)
+	senderMock.GetBlockByHashFunc = func([32]byte) ([]byte, error) { return json.Marshal(otherBlock) }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
+	relay := NewBlocksRelay(senderCreatorMock, sendersManagerMock, time.Minute, watchMock, log.NewLoggerMock())
+	header, _ := ledger.NewBlockHeader(ledger.NewBlock([32]byte{}, nil, nil, 0, nil), 1)
+
+	// Act
+	block := relay.FetchBlock(header, "0.0.0.0:1", "0.0.0.0")
+
+	// Assert
+	test.Assert(t, block == nil, "Block is fetched whereas it should not.")
+	penalizeCalls := sendersManagerMock.PenalizeCalls()
+	test.Assert(t, len(penalizeCalls) == 1 && penalizeCalls[0].Misbehavior == application.MalformedResponseMisbehavior, "Neighbor is not penalized whereas it should be.")
+}
+
+func Test_FetchBlock_RemoteIpRateLimitReached_BlockNotFetched(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	senderMock := new(application.SenderMock)
+	senderCreatorMock := new(application.SenderCreatorMock)
+	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
+	relay := NewBlocksRelay(senderCreatorMock, nil, time.Minute, watchMock, log.NewLoggerMock())
+	firstBlock := ledger.NewBlock([32]byte{}, nil, nil, 0, nil)
+	senderMock.GetBlockByHashFunc = func([32]byte) ([]byte, error) { return json.Marshal(firstBlock) }
+	firstHeader, _ := ledger.NewBlockHeader(firstBlock, 1)
+	_ = relay.FetchBlock(firstHeader, "0.0.0.0:1", "0.0.0.0")
+	secondHeader, _ := ledger.NewBlockHeader(ledger.NewBlock([32]byte{}, nil, nil, 1, nil), 1)
+
+	// Act
+	block := relay.FetchBlock(secondHeader, "0.0.0.0:2", "0.0.0.0")
+
+	// Assert
+	test.Assert(t, block == nil, "Block is fetched whereas it should not.")
+	expectedFetchesCount := 1
+	actualFetchesCount := len(senderMock.GetBlockByHashCalls())
+	test.Assert(t, actualFetchesCount == expectedFetchesCount, fmt.Sprintf("Wrong fetches count. Expected: %d - Actual: %d", expectedFetchesCount, actualFetchesCount))
+}
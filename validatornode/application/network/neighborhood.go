@@ -1,33 +1,69 @@
 package network
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/my-cloud/ruthenium/validatornode/application"
 	"math/rand"
 	"sort"
 	"sync"
 	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 )
 
+const ProtocolVersion = 1
+
+var penaltiesByMisbehavior = map[application.Misbehavior]int{
+	application.ForkMisbehavior:              10,
+	application.InvalidBlocksMisbehavior:     50,
+	application.MalformedResponseMisbehavior: 20,
+	application.TimeoutMisbehavior:           5,
+}
+
 type Neighborhood struct {
-	senderCreator            application.SenderCreator
-	hostTarget               *Target
-	maxOutboundsCount        int
-	senders                  []application.Sender
-	sendersMutex             sync.RWMutex
-	scoresBySeedTargetValue  map[string]int
-	scoresByTargetValue      map[string]int
-	scoresByTargetValueMutex sync.RWMutex
-	watch                    application.TimeProvider
-}
-
-func NewNeighborhood(senderCreator application.SenderCreator, hostIp string, hostPort string, maxOutboundsCount int, scoresBySeedTargetValue map[string]int, watch application.TimeProvider) *Neighborhood {
+	addressBook                    *addressBook
+	addressBookStorage             application.AddressBookStorage
+	banDuration                    time.Duration
+	banScoreThreshold              int
+	banExpirationsByTargetValue    map[string]time.Time
+	misbehaviorScoresByTargetValue map[string]int
+	misbehaviorsMutex              sync.RWMutex
+	blocksManager                  application.BlocksManager
+	senderCreator                  application.SenderCreator
+	hostTarget                     *Target
+	maxOutboundsCount              int
+	neighborsTipHeight             uint64
+	networkId                      string
+	protocolSettingsHash           [32]byte
+	senders                        []application.Sender
+	sendersMutex                   sync.RWMutex
+	scoresBySeedTargetValue        map[string]int
+	scoresByTargetValue            map[string]int
+	scoresByTargetValueMutex       sync.RWMutex
+	watch                          application.TimeProvider
+	logger                         log.Logger
+}
+
+func NewNeighborhood(senderCreator application.SenderCreator, hostIp string, hostPort string, maxOutboundsCount int, networkId string, protocolSettingsHash [32]byte, banDuration time.Duration, banScoreThreshold int, addressBookStorage application.AddressBookStorage, addressBookEntryLifetime time.Duration, addressBookMaxFailuresCount int, scoresBySeedTargetValue map[string]int, watch application.TimeProvider, logger log.Logger) *Neighborhood {
 	neighborhood := new(Neighborhood)
+	neighborhood.addressBook = newAddressBook(addressBookEntryLifetime, addressBookMaxFailuresCount)
+	neighborhood.addressBookStorage = addressBookStorage
+	neighborhood.banDuration = banDuration
+	neighborhood.banScoreThreshold = banScoreThreshold
+	neighborhood.banExpirationsByTargetValue = map[string]time.Time{}
+	neighborhood.misbehaviorScoresByTargetValue = map[string]int{}
 	neighborhood.senderCreator = senderCreator
 	neighborhood.hostTarget = NewTarget(hostIp, hostPort)
 	neighborhood.maxOutboundsCount = maxOutboundsCount
+	neighborhood.networkId = networkId
+	neighborhood.protocolSettingsHash = protocolSettingsHash
 	neighborhood.scoresBySeedTargetValue = scoresBySeedTargetValue
 	neighborhood.scoresByTargetValue = map[string]int{}
 	neighborhood.watch = watch
+	neighborhood.logger = logger
 	return neighborhood
 }
 
@@ -36,47 +72,202 @@ func (neighborhood *Neighborhood) AddTargets(targetValues []string) {
 	defer neighborhood.scoresByTargetValueMutex.Unlock()
 	for _, targetValue := range targetValues {
 		_, isTargetAlreadyKnown := neighborhood.scoresByTargetValue[targetValue]
-		target, err := NewTargetFromValue(targetValue)
-		if err != nil {
+		if _, err := NewTargetFromValue(targetValue); err != nil {
 			continue
 		}
-		isTargetOnSameNetwork := neighborhood.hostTarget.IsSameNetworkId(target)
-		if !isTargetAlreadyKnown && isTargetOnSameNetwork {
+		if !isTargetAlreadyKnown && !neighborhood.isBanned(targetValue) {
 			neighborhood.scoresByTargetValue[targetValue] = 0
 		}
 	}
 }
 
+func (neighborhood *Neighborhood) Flush() error {
+	if err := neighborhood.addressBookStorage.SaveAddressBook(neighborhood.addressBook.entries()); err != nil {
+		return fmt.Errorf("failed to save address book: %w", err)
+	}
+	return nil
+}
+
+func (neighborhood *Neighborhood) Handshake() (*ledger.Handshake, error) {
+	var genesisBlockHash [32]byte
+	var tip *ledger.BlockHeader
+	// The genesis block is not found while the blockchain is empty
+	if genesisBlock, err := neighborhood.blocksManager.Block(0); err == nil {
+		genesisBlockHash, err = genesisBlock.Hash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate genesis block hash: %w", err)
+		}
+		tip, err = neighborhood.blocksManager.Tip()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tip: %w", err)
+		}
+	}
+	return ledger.NewHandshake(genesisBlockHash, neighborhood.networkId, ProtocolVersion, neighborhood.protocolSettingsHash, tip), nil
+}
+
 func (neighborhood *Neighborhood) HostTarget() string {
 	return neighborhood.hostTarget.Value()
 }
 
 func (neighborhood *Neighborhood) Incentive(targetValue string) {
+	if neighborhood.isBanned(targetValue) {
+		return
+	}
 	neighborhood.scoresByTargetValueMutex.Lock()
 	defer neighborhood.scoresByTargetValueMutex.Unlock()
 	neighborhood.scoresByTargetValue[targetValue] += 1
 }
 
+func (neighborhood *Neighborhood) Load() error {
+	entries, err := neighborhood.addressBookStorage.AddressBook()
+	if err != nil {
+		return fmt.Errorf("failed to load address book: %w", err)
+	}
+	neighborhood.addressBook.load(entries)
+	neighborhood.addressBook.prune(neighborhood.watch.Now())
+	scoresByTargetValue := neighborhood.addressBook.scoresByTargetValue()
+	neighborhood.scoresByTargetValueMutex.Lock()
+	defer neighborhood.scoresByTargetValueMutex.Unlock()
+	for targetValue, score := range scoresByTargetValue {
+		neighborhood.scoresByTargetValue[targetValue] = score
+	}
+	neighborhood.logger.Info(fmt.Sprintf("address book loaded: %d targets", len(scoresByTargetValue)))
+	return nil
+}
+
+func (neighborhood *Neighborhood) NeighborsStatus() []*ledger.NeighborStatus {
+	neighborhood.scoresByTargetValueMutex.RLock()
+	scoresByTargetValue := map[string]int{}
+	for targetValue, score := range neighborhood.scoresByTargetValue {
+		scoresByTargetValue[targetValue] = score
+	}
+	neighborhood.scoresByTargetValueMutex.RUnlock()
+	neighborhood.sendersMutex.RLock()
+	outboundTargetValues := map[string]bool{}
+	for _, sender := range neighborhood.senders {
+		outboundTargetValues[sender.Target()] = true
+	}
+	neighborhood.sendersMutex.RUnlock()
+	neighborhood.misbehaviorsMutex.RLock()
+	defer neighborhood.misbehaviorsMutex.RUnlock()
+	targetValuesSet := map[string]bool{}
+	for targetValue := range scoresByTargetValue {
+		targetValuesSet[targetValue] = true
+	}
+	for targetValue := range outboundTargetValues {
+		targetValuesSet[targetValue] = true
+	}
+	for targetValue := range neighborhood.misbehaviorScoresByTargetValue {
+		targetValuesSet[targetValue] = true
+	}
+	for targetValue := range neighborhood.banExpirationsByTargetValue {
+		targetValuesSet[targetValue] = true
+	}
+	var targetValues []string
+	for targetValue := range targetValuesSet {
+		targetValues = append(targetValues, targetValue)
+	}
+	sort.Strings(targetValues)
+	now := neighborhood.watch.Now()
+	statuses := make([]*ledger.NeighborStatus, len(targetValues))
+	for i, targetValue := range targetValues {
+		var banExpirationTimestamp int64
+		if banExpiration, isBanned := neighborhood.banExpirationsByTargetValue[targetValue]; isBanned && now.Before(banExpiration) {
+			banExpirationTimestamp = banExpiration.UnixNano()
+		}
+		misbehaviorScore := neighborhood.misbehaviorScoresByTargetValue[targetValue]
+		statuses[i] = ledger.NewNeighborStatus(banExpirationTimestamp, outboundTargetValues[targetValue], misbehaviorScore, scoresByTargetValue[targetValue], targetValue)
+	}
+	return statuses
+}
+
+func (neighborhood *Neighborhood) NeighborsTipHeight() uint64 {
+	neighborhood.sendersMutex.RLock()
+	defer neighborhood.sendersMutex.RUnlock()
+	return neighborhood.neighborsTipHeight
+}
+
+func (neighborhood *Neighborhood) Penalize(targetValue string, misbehavior application.Misbehavior) {
+	penalty := penaltiesByMisbehavior[misbehavior]
+	neighborhood.scoresByTargetValueMutex.Lock()
+	if _, isTargetKnown := neighborhood.scoresByTargetValue[targetValue]; isTargetKnown {
+		neighborhood.scoresByTargetValue[targetValue] -= penalty
+	}
+	neighborhood.scoresByTargetValueMutex.Unlock()
+	neighborhood.misbehaviorsMutex.Lock()
+	neighborhood.misbehaviorScoresByTargetValue[targetValue] += penalty
+	isBanned := neighborhood.banScoreThreshold > 0 && neighborhood.misbehaviorScoresByTargetValue[targetValue] >= neighborhood.banScoreThreshold
+	if isBanned {
+		banExpiration := neighborhood.watch.Now().Add(neighborhood.banDuration)
+		neighborhood.banExpirationsByTargetValue[targetValue] = banExpiration
+		delete(neighborhood.misbehaviorScoresByTargetValue, targetValue)
+		neighborhood.logger.With(log.TargetKey, targetValue).Info(fmt.Sprintf("neighbor %s banned until %v", targetValue, banExpiration))
+	}
+	neighborhood.misbehaviorsMutex.Unlock()
+	if isBanned {
+		neighborhood.scoresByTargetValueMutex.Lock()
+		delete(neighborhood.scoresByTargetValue, targetValue)
+		neighborhood.scoresByTargetValueMutex.Unlock()
+		neighborhood.sendersMutex.Lock()
+		var senders []application.Sender
+		for _, sender := range neighborhood.senders {
+			if sender.Target() != targetValue {
+				senders = append(senders, sender)
+			}
+		}
+		neighborhood.senders = senders
+		neighborhood.sendersMutex.Unlock()
+	}
+}
+
 func (neighborhood *Neighborhood) Senders() []application.Sender {
+	neighborhood.sendersMutex.RLock()
+	defer neighborhood.sendersMutex.RUnlock()
 	return neighborhood.senders
 }
 
-func (neighborhood *Neighborhood) Synchronize(_ int64) {
+func (neighborhood *Neighborhood) SetBlocksManager(blocksManager application.BlocksManager) {
+	neighborhood.blocksManager = blocksManager
+}
+
+func (neighborhood *Neighborhood) Synchronize(ctx context.Context, _ int64) error {
 	neighborhood.scoresByTargetValueMutex.Lock()
 	var scoresByTargetValue map[string]int
 	if len(neighborhood.scoresByTargetValue) == 0 {
-		scoresByTargetValue = neighborhood.scoresBySeedTargetValue
+		// Known targets from the address book keep the network reachable if the seeds are down
+		scoresByTargetValue = neighborhood.addressBook.scoresByTargetValue()
+		for seedTargetValue, score := range neighborhood.scoresBySeedTargetValue {
+			if _, isKnown := scoresByTargetValue[seedTargetValue]; !isKnown {
+				scoresByTargetValue[seedTargetValue] = score
+			}
+		}
 	} else {
 		scoresByTargetValue = neighborhood.scoresByTargetValue
 	}
 	neighborhood.scoresByTargetValue = map[string]int{}
 	neighborhood.scoresByTargetValueMutex.Unlock()
+	neighborhood.forgiveMisbehaviors()
+	handshake, err := neighborhood.Handshake()
+	if err != nil {
+		return fmt.Errorf("failed to create handshake: %w", err)
+	}
+	marshaledHandshake, err := json.Marshal(handshake)
+	if err != nil {
+		return fmt.Errorf("failed to marshal handshake: %w", err)
+	}
 	neighborsByScore := map[int][]application.Sender{}
+	var neighborsCount int
 	var targetValues []string
 	hostTargetValue := neighborhood.hostTarget.Value()
 	targetValues = append(targetValues, hostTargetValue)
+	var neighborsTipHeights []uint64
+	var waitGroup sync.WaitGroup
+	var mutex sync.Mutex
 	for targetValue, score := range scoresByTargetValue {
-		if targetValue != hostTargetValue {
+		if ctx.Err() != nil {
+			break
+		}
+		if targetValue != hostTargetValue && !neighborhood.isBanned(targetValue) {
 			neighborTarget, err := NewTargetFromValue(targetValue)
 			if err != nil {
 				continue
@@ -85,13 +276,39 @@ func (neighborhood *Neighborhood) Synchronize(_ int64) {
 			if err != nil {
 				continue
 			}
-			neighborsByScore[score] = append(neighborsByScore[score], neighbor)
-			targetValues = append(targetValues, targetValue)
+			waitGroup.Add(1)
+			go func(neighbor application.Sender, targetValue string, score int) {
+				defer waitGroup.Done()
+				neighborTip, err := neighborhood.shakeHands(neighbor, handshake, marshaledHandshake)
+				if err != nil {
+					neighborhood.addressBook.recordFailure(targetValue)
+					neighborhood.logger.With(log.TargetKey, targetValue).With(log.ErrorKey, err).Debug(fmt.Errorf("neighbor %s dropped: %w", targetValue, err).Error())
+					return
+				}
+				neighborhood.addressBook.recordSuccess(targetValue, score, neighborhood.watch.Now())
+				mutex.Lock()
+				defer mutex.Unlock()
+				neighborsByScore[score] = append(neighborsByScore[score], neighbor)
+				neighborsCount++
+				targetValues = append(targetValues, targetValue)
+				if neighborTip != nil {
+					neighborsTipHeights = append(neighborsTipHeights, neighborTip.Height())
+				}
+			}(neighbor, targetValue, score)
 		}
 	}
-	outbounds := neighborhood.selectOutbounds(neighborsByScore, len(scoresByTargetValue))
+	waitGroup.Wait()
+	if err = ctx.Err(); err != nil {
+		return fmt.Errorf("failed to synchronize neighborhood: %w", err)
+	}
+	neighborhood.addressBook.prune(neighborhood.watch.Now())
+	if err = neighborhood.Flush(); err != nil {
+		neighborhood.logger.Error(err.Error())
+	}
+	outbounds := neighborhood.selectOutbounds(neighborsByScore, neighborsCount)
 	neighborhood.sendersMutex.Lock()
 	neighborhood.senders = outbounds
+	neighborhood.neighborsTipHeight = median(neighborsTipHeights)
 	neighborhood.sendersMutex.Unlock()
 	for _, neighbor := range outbounds {
 		var neighborTargetValues []string
@@ -105,6 +322,46 @@ func (neighborhood *Neighborhood) Synchronize(_ int64) {
 			_ = neighbor.SendTargets(neighborTargetValues)
 		}(neighbor)
 	}
+	return nil
+}
+
+func (neighborhood *Neighborhood) forgiveMisbehaviors() {
+	neighborhood.misbehaviorsMutex.Lock()
+	defer neighborhood.misbehaviorsMutex.Unlock()
+	now := neighborhood.watch.Now()
+	for targetValue, banExpiration := range neighborhood.banExpirationsByTargetValue {
+		if !now.Before(banExpiration) {
+			delete(neighborhood.banExpirationsByTargetValue, targetValue)
+		}
+	}
+	for targetValue := range neighborhood.misbehaviorScoresByTargetValue {
+		neighborhood.misbehaviorScoresByTargetValue[targetValue]--
+		if neighborhood.misbehaviorScoresByTargetValue[targetValue] <= 0 {
+			delete(neighborhood.misbehaviorScoresByTargetValue, targetValue)
+		}
+	}
+}
+
+func (neighborhood *Neighborhood) isBanned(targetValue string) bool {
+	neighborhood.misbehaviorsMutex.RLock()
+	defer neighborhood.misbehaviorsMutex.RUnlock()
+	banExpiration, isBanned := neighborhood.banExpirationsByTargetValue[targetValue]
+	return isBanned && neighborhood.watch.Now().Before(banExpiration)
+}
+
+func (neighborhood *Neighborhood) shakeHands(neighbor application.Sender, handshake *ledger.Handshake, marshaledHandshake []byte) (*ledger.BlockHeader, error) {
+	neighborHandshakeBytes, err := neighbor.Handshake(marshaledHandshake)
+	if err != nil {
+		return nil, fmt.Errorf("failed to shake hands: %w", err)
+	}
+	var neighborHandshake *ledger.Handshake
+	if err = json.Unmarshal(neighborHandshakeBytes, &neighborHandshake); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal handshake: %w", err)
+	}
+	if err = handshake.VerifyCompatibility(neighborHandshake); err != nil {
+		return nil, err
+	}
+	return neighborHandshake.Tip(), nil
 }
 
 func (neighborhood *Neighborhood) selectOutbounds(neighborsByScore map[int][]application.Sender, targetsCount int) []application.Sender {
@@ -137,3 +394,12 @@ func min(first, second int) int {
 	}
 	return result
 }
+
+// The tip heights are reported by the neighbors without being verified, so the median is used to ignore the outliers
+func median(heights []uint64) uint64 {
+	if len(heights) == 0 {
+		return 0
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights[(len(heights)-1)/2]
+}
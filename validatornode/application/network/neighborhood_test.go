@@ -1,11 +1,16 @@
 package network
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/my-cloud/ruthenium/validatornode/application"
 	"testing"
 	"time"
 
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
 )
 
@@ -17,9 +22,11 @@ func Test_AddTargets_MoreThanOneTarget_IncentiveTargetsSender(t *testing.T) {
 	senderMock := new(application.SenderMock)
 	senderMock.TargetFunc = func() string { return "0.0.0.0:1" }
 	senderMock.SendTargetsFunc = func([]string) error { return nil }
+	senderMock.HandshakeFunc = func([]byte) ([]byte, error) { return json.Marshal(newHandshake("mainnet")) }
 	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
 	scoresBySeedTarget := map[string]int{}
-	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, scoresBySeedTarget, watchMock)
+	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, "mainnet", [32]byte{}, time.Hour, 100, newAddressBookStorageMock(), 0, 0, scoresBySeedTarget, watchMock, log.NewLoggerMock())
+	neighborhood.SetBlocksManager(newEmptyBlocksManagerMock())
 	target1 := "0.0.0.0:1"
 	target2 := "0.0.0.0:0"
 	targetRequests := []string{target1, target2}
@@ -28,7 +35,7 @@ func Test_AddTargets_MoreThanOneTarget_IncentiveTargetsSender(t *testing.T) {
 	neighborhood.AddTargets(targetRequests)
 
 	// Assert
-	neighborhood.Synchronize(0)
+	_ = neighborhood.Synchronize(context.Background(), 0)
 	neighbors := neighborhood.Senders()
 	expectedNeighborsCount := 1
 	test.Assert(t, len(neighbors) == expectedNeighborsCount, fmt.Sprintf("Wrong neighbors count. Expected: %d - Actual: %d", expectedNeighborsCount, len(neighbors)))
@@ -44,16 +51,18 @@ func Test_Incentive_TargetIsNotKnown_TargetIncentive(t *testing.T) {
 	senderMock := new(application.SenderMock)
 	senderMock.TargetFunc = func() string { return "0.0.0.0:1" }
 	senderMock.SendTargetsFunc = func([]string) error { return nil }
+	senderMock.HandshakeFunc = func([]byte) ([]byte, error) { return json.Marshal(newHandshake("mainnet")) }
 	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
 	scoresBySeedTarget := map[string]int{}
-	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, scoresBySeedTarget, watchMock)
+	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, "mainnet", [32]byte{}, time.Hour, 100, newAddressBookStorageMock(), 0, 0, scoresBySeedTarget, watchMock, log.NewLoggerMock())
+	neighborhood.SetBlocksManager(newEmptyBlocksManagerMock())
 	expectedTarget := "0.0.0.0:1"
 
 	// Act
 	neighborhood.Incentive(expectedTarget)
 
 	// Assert
-	neighborhood.Synchronize(0)
+	_ = neighborhood.Synchronize(context.Background(), 0)
 	neighbors := neighborhood.Senders()
 	expectedNeighborsCount := 1
 	test.Assert(t, len(neighbors) == expectedNeighborsCount, fmt.Sprintf("Wrong neighbors count. Expected: %d - Actual: %d", expectedNeighborsCount, len(neighbors)))
@@ -69,15 +78,288 @@ func Test_Synchronize_OneSeed_NeighborAdded(t *testing.T) {
 	senderMock := new(application.SenderMock)
 	senderMock.TargetFunc = func() string { return "0.0.0.0:1" }
 	senderMock.SendTargetsFunc = func([]string) error { return nil }
+	senderMock.HandshakeFunc = func([]byte) ([]byte, error) { return json.Marshal(newHandshake("mainnet")) }
 	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
 	scoresBySeedTarget := map[string]int{"0.0.0.0:1": 0}
-	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, scoresBySeedTarget, watchMock)
+	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, "mainnet", [32]byte{}, time.Hour, 100, newAddressBookStorageMock(), 0, 0, scoresBySeedTarget, watchMock, log.NewLoggerMock())
+	neighborhood.SetBlocksManager(newEmptyBlocksManagerMock())
 
 	// Act
-	neighborhood.Synchronize(0)
+	_ = neighborhood.Synchronize(context.Background(), 0)
 
 	// Assert
 	neighbors := neighborhood.Senders()
 	expectedNeighborsCount := 1
 	test.Assert(t, len(neighbors) == expectedNeighborsCount, fmt.Sprintf("Wrong neighbors count. Expected: %d - Actual: %d", expectedNeighborsCount, len(neighbors)))
 }
+
+func Test_Synchronize_NeighborHasBlocks_NeighborsTipHeightUpdated(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Now() }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	senderMock := new(application.SenderMock)
+	senderMock.TargetFunc = func() string { return "0.0.0.0:1" }
+	senderMock.SendTargetsFunc = func([]string) error { return nil }
+	tip, _ := ledger.NewBlockHeader(ledger.NewBlock([32]byte{}, nil, nil, 0, nil), 3)
+	senderMock.HandshakeFunc = func([]byte) ([]byte, error) {
+		return json.Marshal(ledger.NewHandshake([32]byte{}, "mainnet", ProtocolVersion, [32]byte{}, tip))
+	}
+	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
+	scoresBySeedTarget := map[string]int{"0.0.0.0:1": 0}
+	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, "mainnet", [32]byte{}, time.Hour, 100, newAddressBookStorageMock(), 0, 0, scoresBySeedTarget, watchMock, log.NewLoggerMock())
+	neighborhood.SetBlocksManager(newEmptyBlocksManagerMock())
+
+	// Act
+	_ = neighborhood.Synchronize(context.Background(), 0)
+
+	// Assert
+	neighborsTipHeight := neighborhood.NeighborsTipHeight()
+	test.Assert(t, neighborsTipHeight == 3, fmt.Sprintf("Wrong neighbors tip height. Expected: 3 - Actual: %d", neighborsTipHeight))
+}
+
+func Test_Synchronize_NeighborReportsHugeTipHeight_NeighborsTipHeightIsMedian(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Now() }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	heightsByPort := map[string]uint64{"1": 3, "2": 4, "3": 1000000}
+	senderCreatorMock.CreateSenderFunc = func(ip string, port string) (application.Sender, error) {
+		senderMock := new(application.SenderMock)
+		senderMock.TargetFunc = func() string { return ip + ":" + port }
+		senderMock.SendTargetsFunc = func([]string) error { return nil }
+		tip, _ := ledger.NewBlockHeader(ledger.NewBlock([32]byte{}, nil, nil, 0, nil), heightsByPort[port])
+		senderMock.HandshakeFunc = func([]byte) ([]byte, error) {
+			return json.Marshal(ledger.NewHandshake([32]byte{}, "mainnet", ProtocolVersion, [32]byte{}, tip))
+		}
+		return senderMock, nil
+	}
+	scoresBySeedTarget := map[string]int{"0.0.0.0:1": 0, "0.0.0.0:2": 0, "0.0.0.0:3": 0}
+	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 3, "mainnet", [32]byte{}, time.Hour, 100, newAddressBookStorageMock(), 0, 0, scoresBySeedTarget, watchMock, log.NewLoggerMock())
+	neighborhood.SetBlocksManager(newEmptyBlocksManagerMock())
+
+	// Act
+	_ = neighborhood.Synchronize(context.Background(), 0)
+
+	// Assert
+	neighborsTipHeight := neighborhood.NeighborsTipHeight()
+	test.Assert(t, neighborsTipHeight == 4, fmt.Sprintf("Wrong neighbors tip height. Expected: 4 - Actual: %d", neighborsTipHeight))
+}
+
+func Test_Synchronize_NeighborOnAnotherNetwork_NeighborDropped(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Now() }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	senderMock := new(application.SenderMock)
+	senderMock.TargetFunc = func() string { return "0.0.0.0:1" }
+	senderMock.SendTargetsFunc = func([]string) error { return nil }
+	senderMock.HandshakeFunc = func([]byte) ([]byte, error) { return json.Marshal(newHandshake("testnet")) }
+	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
+	scoresBySeedTarget := map[string]int{"0.0.0.0:1": 0}
+	logger := log.NewLoggerMock()
+	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, "mainnet", [32]byte{}, time.Hour, 100, newAddressBookStorageMock(), 0, 0, scoresBySeedTarget, watchMock, logger)
+	neighborhood.SetBlocksManager(newEmptyBlocksManagerMock())
+
+	// Act
+	_ = neighborhood.Synchronize(context.Background(), 0)
+
+	// Assert
+	neighbors := neighborhood.Senders()
+	expectedNeighborsCount := 0
+	test.Assert(t, len(neighbors) == expectedNeighborsCount, fmt.Sprintf("Wrong neighbors count. Expected: %d - Actual: %d", expectedNeighborsCount, len(neighbors)))
+	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), "neighbor 0.0.0.0:1 dropped: network id mismatch")
+}
+
+func Test_Synchronize_NeighborRepliesNullHandshake_NeighborDropped(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Now() }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	senderMock := new(application.SenderMock)
+	senderMock.TargetFunc = func() string { return "0.0.0.0:1" }
+	senderMock.SendTargetsFunc = func([]string) error { return nil }
+	senderMock.HandshakeFunc = func([]byte) ([]byte, error) { return []byte("null"), nil }
+	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
+	scoresBySeedTarget := map[string]int{"0.0.0.0:1": 0}
+	logger := log.NewLoggerMock()
+	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, "mainnet", [32]byte{}, time.Hour, 100, newAddressBookStorageMock(), 0, 0, scoresBySeedTarget, watchMock, logger)
+	neighborhood.SetBlocksManager(newEmptyBlocksManagerMock())
+
+	// Act
+	_ = neighborhood.Synchronize(context.Background(), 0)
+
+	// Assert
+	neighbors := neighborhood.Senders()
+	expectedNeighborsCount := 0
+	test.Assert(t, len(neighbors) == expectedNeighborsCount, fmt.Sprintf("Wrong neighbors count. Expected: %d - Actual: %d", expectedNeighborsCount, len(neighbors)))
+	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), "neighbor 0.0.0.0:1 dropped: handshake is missing")
+}
+
+func Test_Load_SeedsAreDown_AddressBookNeighborAdded(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	senderMock := new(application.SenderMock)
+	senderMock.TargetFunc = func() string { return "0.0.0.0:1" }
+	senderMock.SendTargetsFunc = func([]string) error { return nil }
+	senderMock.HandshakeFunc = func([]byte) ([]byte, error) { return json.Marshal(newHandshake("mainnet")) }
+	senderCreatorMock.CreateSenderFunc = func(ip string, port string) (application.Sender, error) {
+		if port == "1" {
+			return senderMock, nil
+		}
+		return nil, errors.New("seed is down")
+	}
+	addressBookStorageMock := newAddressBookStorageMock()
+	addressBookStorageMock.AddressBookFunc = func() ([]*ledger.AddressBookEntry, error) {
+		return []*ledger.AddressBookEntry{ledger.NewAddressBookEntry(0, 0, 0, "0.0.0.0:1")}, nil
+	}
+	scoresBySeedTarget := map[string]int{"0.0.0.0:2": 0}
+	logger := log.NewLoggerMock()
+	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, "mainnet", [32]byte{}, time.Hour, 100, addressBookStorageMock, time.Hour, 10, scoresBySeedTarget, watchMock, logger)
+	neighborhood.SetBlocksManager(newEmptyBlocksManagerMock())
+
+	// Act
+	err := neighborhood.Load()
+
+	// Assert
+	test.Assert(t, err == nil, fmt.Sprintf("Failed to load the address book: %v", err))
+	test.AssertThatMessageIsLogged(t, logger.InfoCalls(), "address book loaded: 1 targets")
+	_ = neighborhood.Synchronize(context.Background(), 0)
+	neighbors := neighborhood.Senders()
+	expectedNeighborsCount := 1
+	test.Assert(t, len(neighbors) == expectedNeighborsCount, fmt.Sprintf("Wrong neighbors count. Expected: %d - Actual: %d", expectedNeighborsCount, len(neighbors)))
+}
+
+func Test_Load_StaleEntry_EntryPruned(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0).Add(2 * time.Hour) }
+	addressBookStorageMock := newAddressBookStorageMock()
+	addressBookStorageMock.AddressBookFunc = func() ([]*ledger.AddressBookEntry, error) {
+		return []*ledger.AddressBookEntry{ledger.NewAddressBookEntry(0, 0, 0, "0.0.0.0:1")}, nil
+	}
+	logger := log.NewLoggerMock()
+	neighborhood := NewNeighborhood(new(application.SenderCreatorMock), "0.0.0.0", "0", 1, "mainnet", [32]byte{}, time.Hour, 100, addressBookStorageMock, time.Hour, 10, map[string]int{}, watchMock, logger)
+
+	// Act
+	err := neighborhood.Load()
+
+	// Assert
+	test.Assert(t, err == nil, fmt.Sprintf("Failed to load the address book: %v", err))
+	test.AssertThatMessageIsLogged(t, logger.InfoCalls(), "address book loaded: 0 targets")
+}
+
+func Test_Load_NullEntry_EntrySkipped(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	addressBookStorageMock := newAddressBookStorageMock()
+	addressBookStorageMock.AddressBookFunc = func() ([]*ledger.AddressBookEntry, error) {
+		return []*ledger.AddressBookEntry{nil, ledger.NewAddressBookEntry(0, 0, 0, "0.0.0.0:1")}, nil
+	}
+	logger := log.NewLoggerMock()
+	neighborhood := NewNeighborhood(new(application.SenderCreatorMock), "0.0.0.0", "0", 1, "mainnet", [32]byte{}, time.Hour, 100, addressBookStorageMock, time.Hour, 10, map[string]int{}, watchMock, logger)
+
+	// Act
+	err := neighborhood.Load()
+
+	// Assert
+	test.Assert(t, err == nil, fmt.Sprintf("Failed to load the address book: %v", err))
+	test.AssertThatMessageIsLogged(t, logger.InfoCalls(), "address book loaded: 1 targets")
+}
+
+func Test_Synchronize_NeighborFailsTooManyTimes_EntryPruned(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	senderMock := new(application.SenderMock)
+	senderMock.HandshakeFunc = func([]byte) ([]byte, error) { return nil, errors.New("connection refused") }
+	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
+	addressBookStorageMock := newAddressBookStorageMock()
+	addressBookStorageMock.AddressBookFunc = func() ([]*ledger.AddressBookEntry, error) {
+		return []*ledger.AddressBookEntry{ledger.NewAddressBookEntry(1, 0, 0, "0.0.0.0:1")}, nil
+	}
+	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, "mainnet", [32]byte{}, time.Hour, 100, addressBookStorageMock, time.Hour, 2, map[string]int{}, watchMock, log.NewLoggerMock())
+	neighborhood.SetBlocksManager(newEmptyBlocksManagerMock())
+	_ = neighborhood.Load()
+
+	// Act
+	_ = neighborhood.Synchronize(context.Background(), 0)
+
+	// Assert
+	saveCalls := addressBookStorageMock.SaveAddressBookCalls()
+	expectedSaveCallsCount := 1
+	test.Assert(t, len(saveCalls) == expectedSaveCallsCount, fmt.Sprintf("Wrong save calls count. Expected: %d - Actual: %d", expectedSaveCallsCount, len(saveCalls)))
+	savedEntriesCount := len(saveCalls[0].Entries)
+	test.Assert(t, savedEntriesCount == 0, fmt.Sprintf("Wrong saved entries count. Expected: 0 - Actual: %d", savedEntriesCount))
+}
+
+func newAddressBookStorageMock() *application.AddressBookStorageMock {
+	addressBookStorageMock := new(application.AddressBookStorageMock)
+	addressBookStorageMock.AddressBookFunc = func() ([]*ledger.AddressBookEntry, error) { return nil, nil }
+	addressBookStorageMock.SaveAddressBookFunc = func([]*ledger.AddressBookEntry) error { return nil }
+	return addressBookStorageMock
+}
+
+func newEmptyBlocksManagerMock() *application.BlocksManagerMock {
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.BlockFunc = func(uint64) (*ledger.Block, error) { return nil, errors.New("block not found") }
+	return blocksManagerMock
+}
+
+func newHandshake(networkId string) *ledger.Handshake {
+	return ledger.NewHandshake([32]byte{}, networkId, ProtocolVersion, [32]byte{}, nil)
+}
+
+func Test_Penalize_MisbehaviorScoreReachesThreshold_NeighborBanned(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return nil, errors.New("") }
+	scoresBySeedTarget := map[string]int{}
+	logger := log.NewLoggerMock()
+	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, "mainnet", [32]byte{}, time.Hour, 50, newAddressBookStorageMock(), 0, 0, scoresBySeedTarget, watchMock, logger)
+	neighborhood.SetBlocksManager(newEmptyBlocksManagerMock())
+	target := "0.0.0.0:1"
+
+	// Act
+	neighborhood.Penalize(target, application.InvalidBlocksMisbehavior)
+
+	// Assert
+	neighborhood.AddTargets([]string{target})
+	neighborsStatus := neighborhood.NeighborsStatus()
+	expectedNeighborsCount := 1
+	test.Assert(t, len(neighborsStatus) == expectedNeighborsCount, fmt.Sprintf("Wrong neighbors count. Expected: %d - Actual: %d", expectedNeighborsCount, len(neighborsStatus)))
+	test.Assert(t, neighborsStatus[0].IsBanned(), "Neighbor is not banned whereas it should be.")
+	_ = neighborhood.Synchronize(context.Background(), 0)
+	isSenderCreated := len(senderCreatorMock.CreateSenderCalls()) != 0
+	test.Assert(t, !isSenderCreated, "Sender is created for a banned neighbor whereas it should not.")
+	test.AssertThatMessageIsLogged(t, logger.InfoCalls(), "neighbor 0.0.0.0:1 banned")
+}
+
+func Test_Penalize_MisbehaviorScoreBelowThreshold_NeighborNotBanned(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	scoresBySeedTarget := map[string]int{}
+	neighborhood := NewNeighborhood(senderCreatorMock, "0.0.0.0", "0", 1, "mainnet", [32]byte{}, time.Hour, 100, newAddressBookStorageMock(), 0, 0, scoresBySeedTarget, watchMock, log.NewLoggerMock())
+	target := "0.0.0.0:1"
+	neighborhood.AddTargets([]string{target})
+
+	// Act
+	neighborhood.Penalize(target, application.TimeoutMisbehavior)
+
+	// Assert
+	neighborsStatus := neighborhood.NeighborsStatus()
+	expectedNeighborsCount := 1
+	test.Assert(t, len(neighborsStatus) == expectedNeighborsCount, fmt.Sprintf("Wrong neighbors count. Expected: %d - Actual: %d", expectedNeighborsCount, len(neighborsStatus)))
+	test.Assert(t, !neighborsStatus[0].IsBanned(), "Neighbor is banned whereas it should not.")
+	expectedScore := -5
+	actualScore := neighborsStatus[0].Score()
+	test.Assert(t, actualScore == expectedScore, fmt.Sprintf("Wrong score. Expected: %d - Actual: %d", expectedScore, actualScore))
+}
@@ -0,0 +1,66 @@
+package network
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+)
+
+type rateBucket struct {
+	lastRefill time.Time
+	tokens     float64
+}
+
+type RateLimiter struct {
+	bucketsByKey      map[string]*rateBucket
+	lastPruning       time.Time
+	maxCountPerSecond int
+	mutex             sync.Mutex
+	watch             application.TimeProvider
+}
+
+func NewRateLimiter(maxCountPerSecond int, watch application.TimeProvider) *RateLimiter {
+	limiter := new(RateLimiter)
+	limiter.bucketsByKey = map[string]*rateBucket{}
+	limiter.lastPruning = watch.Now()
+	limiter.maxCountPerSecond = maxCountPerSecond
+	limiter.watch = watch
+	return limiter
+}
+
+func (limiter *RateLimiter) Allow(key string, count int) int {
+	if limiter.maxCountPerSecond == 0 {
+		return count
+	}
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	now := limiter.watch.Now()
+	limiter.prune(now)
+	capacity := float64(limiter.maxCountPerSecond)
+	bucket, isKnown := limiter.bucketsByKey[key]
+	if !isKnown {
+		bucket = &rateBucket{now, capacity}
+		limiter.bucketsByKey[key] = bucket
+	} else {
+		refilledTokens := bucket.tokens + now.Sub(bucket.lastRefill).Seconds()*capacity
+		bucket.tokens = math.Min(refilledTokens, capacity)
+		bucket.lastRefill = now
+	}
+	allowedCount := min(count, int(bucket.tokens))
+	bucket.tokens -= float64(allowedCount)
+	return allowedCount
+}
+
+func (limiter *RateLimiter) prune(now time.Time) {
+	if now.Sub(limiter.lastPruning) < time.Second {
+		return
+	}
+	for key, bucket := range limiter.bucketsByKey {
+		if now.Sub(bucket.lastRefill) >= time.Second {
+			delete(limiter.bucketsByKey, key)
+		}
+	}
+	limiter.lastPruning = now
+}
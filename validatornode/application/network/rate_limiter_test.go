@@ -0,0 +1,55 @@
+package network
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_Allow_CountExceedsLimit_LimitAllowed(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	limiter := NewRateLimiter(2, watchMock)
+
+	// Act
+	allowedCount := limiter.Allow("0.0.0.0:0", 3)
+
+	// Assert
+	expectedAllowedCount := 2
+	test.Assert(t, allowedCount == expectedAllowedCount, fmt.Sprintf("Wrong allowed count. Expected: %d - Actual: %d", expectedAllowedCount, allowedCount))
+}
+
+func Test_Allow_OneSecondElapsed_LimitRefilled(t *testing.T) {
+	// Arrange
+	now := time.Unix(0, 0)
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return now }
+	limiter := NewRateLimiter(2, watchMock)
+	limiter.Allow("0.0.0.0:0", 2)
+	now = now.Add(time.Second)
+
+	// Act
+	allowedCount := limiter.Allow("0.0.0.0:0", 2)
+
+	// Assert
+	expectedAllowedCount := 2
+	test.Assert(t, allowedCount == expectedAllowedCount, fmt.Sprintf("Wrong allowed count. Expected: %d - Actual: %d", expectedAllowedCount, allowedCount))
+}
+
+func Test_Allow_NoLimit_CountAllowed(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	limiter := NewRateLimiter(0, watchMock)
+
+	// Act
+	allowedCount := limiter.Allow("0.0.0.0:0", 3)
+
+	// Assert
+	expectedAllowedCount := 3
+	test.Assert(t, allowedCount == expectedAllowedCount, fmt.Sprintf("Wrong allowed count. Expected: %d - Actual: %d", expectedAllowedCount, allowedCount))
+}
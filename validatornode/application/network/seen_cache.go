@@ -0,0 +1,62 @@
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+)
+
+type SeenCache struct {
+	expirationsById map[string]time.Time
+	lastPruning     time.Time
+	mutex           sync.Mutex
+	ttl             time.Duration
+	watch           application.TimeProvider
+}
+
+func NewSeenCache(ttl time.Duration, watch application.TimeProvider) *SeenCache {
+	cache := new(SeenCache)
+	cache.expirationsById = map[string]time.Time{}
+	cache.lastPruning = watch.Now()
+	cache.ttl = ttl
+	cache.watch = watch
+	return cache
+}
+
+func (cache *SeenCache) Add(id string) bool {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	now := cache.watch.Now()
+	cache.prune(now)
+	if expiration, isSeen := cache.expirationsById[id]; isSeen && now.Before(expiration) {
+		return false
+	}
+	cache.expirationsById[id] = now.Add(cache.ttl)
+	return true
+}
+
+func (cache *SeenCache) Contains(id string) bool {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	expiration, isSeen := cache.expirationsById[id]
+	return isSeen && cache.watch.Now().Before(expiration)
+}
+
+func (cache *SeenCache) Remove(id string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	delete(cache.expirationsById, id)
+}
+
+func (cache *SeenCache) prune(now time.Time) {
+	if now.Sub(cache.lastPruning) < cache.ttl {
+		return
+	}
+	for id, expiration := range cache.expirationsById {
+		if !now.Before(expiration) {
+			delete(cache.expirationsById, id)
+		}
+	}
+	cache.lastPruning = now
+}
@@ -0,0 +1,39 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_Add_IdAlreadySeen_ReturnsFalse(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	cache := NewSeenCache(time.Minute, watchMock)
+	cache.Add("id")
+
+	// Act
+	isAdded := cache.Add("id")
+
+	// Assert
+	test.Assert(t, !isAdded, "Id is added whereas it should not.")
+}
+
+func Test_Add_IdExpired_ReturnsTrue(t *testing.T) {
+	// Arrange
+	now := time.Unix(0, 0)
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return now }
+	cache := NewSeenCache(time.Minute, watchMock)
+	cache.Add("id")
+	now = now.Add(time.Minute)
+
+	// Act
+	isAdded := cache.Add("id")
+
+	// Assert
+	test.Assert(t, isAdded, "Id is not added whereas it should be.")
+}
@@ -0,0 +1,136 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+)
+
+type TransactionsRelay struct {
+	announcedIds      []string
+	announcedIdsMutex sync.Mutex
+	inboundLimiter    *RateLimiter
+	outboundLimiter   *RateLimiter
+	seenCache         *SeenCache
+	senderCreator     application.SenderCreator
+	sendersManager    application.SendersManager
+	logger            log.Logger
+}
+
+func NewTransactionsRelay(senderCreator application.SenderCreator, sendersManager application.SendersManager, seenTransactionsTtl time.Duration, maxRelayedTransactionsPerSecond int, watch application.TimeProvider, logger log.Logger) *TransactionsRelay {
+	relay := new(TransactionsRelay)
+	relay.inboundLimiter = NewRateLimiter(maxRelayedTransactionsPerSecond, watch)
+	relay.outboundLimiter = NewRateLimiter(maxRelayedTransactionsPerSecond, watch)
+	relay.seenCache = NewSeenCache(seenTransactionsTtl, watch)
+	relay.senderCreator = senderCreator
+	relay.sendersManager = sendersManager
+	relay.logger = logger
+	return relay
+}
+
+func (relay *TransactionsRelay) AnnounceTransaction(transactionId string) {
+	relay.seenCache.Add(transactionId)
+	relay.announcedIdsMutex.Lock()
+	defer relay.announcedIdsMutex.Unlock()
+	relay.announcedIds = append(relay.announcedIds, transactionId)
+}
+
+// FetchTransactions fetches the unseen announced transactions from the broadcaster, the fetched transactions count is limited for each announcer remote IP since the broadcaster target is given by the announcer itself
+func (relay *TransactionsRelay) FetchTransactions(transactionIds []string, broadcasterTarget string, remoteIp string) []*ledger.Transaction {
+	var unseenIds []string
+	for _, transactionId := range transactionIds {
+		if !relay.seenCache.Contains(transactionId) {
+			unseenIds = append(unseenIds, transactionId)
+		}
+	}
+	if len(unseenIds) == 0 {
+		return nil
+	}
+	allowedCount := relay.inboundLimiter.Allow(remoteIp, len(unseenIds))
+	if allowedCount < len(unseenIds) {
+		relay.logger.With(log.TargetKey, remoteIp).Debug(fmt.Sprintf("relay rate limit reached for announcer %s: %d announced transactions ignored", remoteIp, len(unseenIds)-allowedCount))
+	}
+	requestedIdsSet := map[string]bool{}
+	var requestedIds []string
+	for _, transactionId := range unseenIds[:allowedCount] {
+		if relay.seenCache.Add(transactionId) {
+			requestedIdsSet[transactionId] = true
+			requestedIds = append(requestedIds, transactionId)
+		}
+	}
+	if len(requestedIds) == 0 {
+		return nil
+	}
+	transactions, err := relay.fetchTransactions(requestedIds, broadcasterTarget)
+	if err != nil {
+		for _, transactionId := range requestedIds {
+			relay.seenCache.Remove(transactionId)
+		}
+		relay.logger.Debug(fmt.Errorf("failed to fetch announced transactions: %w", err).Error())
+		return nil
+	}
+	var requestedTransactions []*ledger.Transaction
+	for _, transaction := range transactions {
+		if transaction != nil && requestedIdsSet[transaction.Id()] {
+			requestedTransactions = append(requestedTransactions, transaction)
+		}
+	}
+	return requestedTransactions
+}
+
+func (relay *TransactionsRelay) Relay(_ context.Context, _ int64) error {
+	relay.announcedIdsMutex.Lock()
+	transactionIds := relay.announcedIds
+	relay.announcedIds = nil
+	relay.announcedIdsMutex.Unlock()
+	if len(transactionIds) == 0 {
+		return nil
+	}
+	hostTarget := relay.sendersManager.HostTarget()
+	for _, sender := range relay.sendersManager.Senders() {
+		target := sender.Target()
+		allowedCount := relay.outboundLimiter.Allow(target, len(transactionIds))
+		if allowedCount < len(transactionIds) {
+			relay.logger.With(log.TargetKey, target).Debug(fmt.Sprintf("relay rate limit reached for neighbor %s: %d transactions not announced", target, len(transactionIds)-allowedCount))
+		}
+		if allowedCount == 0 {
+			continue
+		}
+		announcement := ledger.NewTransactionsAnnouncement(transactionIds[:allowedCount], hostTarget)
+		marshaledAnnouncement, err := json.Marshal(announcement)
+		if err != nil {
+			return fmt.Errorf("failed to marshal transactions announcement: %w", err)
+		}
+		go func(sender application.Sender) {
+			_ = sender.AnnounceTransactions(marshaledAnnouncement)
+		}(sender)
+	}
+	return nil
+}
+
+func (relay *TransactionsRelay) fetchTransactions(transactionIds []string, broadcasterTarget string) ([]*ledger.Transaction, error) {
+	target, err := NewTargetFromValue(broadcasterTarget)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := relay.senderCreator.CreateSender(target.Ip(), target.Port())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sender for target %s: %w", broadcasterTarget, err)
+	}
+	transactionsBytes, err := sender.GetPendingTransactions(transactionIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending transactions from target %s: %w", broadcasterTarget, err)
+	}
+	var transactions []*ledger.Transaction
+	if err = json.Unmarshal(transactionsBytes, &transactions); err != nil {
+		relay.sendersManager.Penalize(broadcasterTarget, application.MalformedResponseMisbehavior)
+		return nil, fmt.Errorf("failed to unmarshal pending transactions: %w", err)
+	}
+	return transactions, nil
+}
@@ -0,0 +1,126 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_FetchTransactions_TransactionAlreadyAnnounced_TransactionNotFetched(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	relay := NewTransactionsRelay(senderCreatorMock, nil, time.Minute, 0, watchMock, log.NewLoggerMock())
+	relay.AnnounceTransaction("id")
+
+	// Act
+	transactions := relay.FetchTransactions([]string{"id"}, "0.0.0.0:0", "0.0.0.0")
+
+	// Assert
+	test.Assert(t, len(transactions) == 0, "Transactions are fetched whereas they should not.")
+	isSenderCreated := len(senderCreatorMock.CreateSenderCalls()) != 0
+	test.Assert(t, !isSenderCreated, "Sender is created whereas it should not.")
+}
+
+func Test_FetchTransactions_UnknownTransaction_TransactionFetchedOnce(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	transaction, _ := ledger.NewRewardTransaction("", false, 0, 0)
+	senderMock := new(application.SenderMock)
+	senderMock.GetPendingTransactionsFunc = func([]string) ([]byte, error) { return json.Marshal([]*ledger.Transaction{transaction}) }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
+	relay := NewTransactionsRelay(senderCreatorMock, nil, time.Minute, 0, watchMock, log.NewLoggerMock())
+
+	// Act
+	transactions := relay.FetchTransactions([]string{transaction.Id()}, "0.0.0.0:0", "0.0.0.0")
+	_ = relay.FetchTransactions([]string{transaction.Id()}, "0.0.0.0:0", "0.0.0.0")
+
+	// Assert
+	expectedTransactionsCount := 1
+	test.Assert(t, len(transactions) == expectedTransactionsCount, fmt.Sprintf("Wrong transactions count. Expected: %d - Actual: %d", expectedTransactionsCount, len(transactions)))
+	expectedFetchesCount := 1
+	actualFetchesCount := len(senderMock.GetPendingTransactionsCalls())
+	test.Assert(t, actualFetchesCount == expectedFetchesCount, fmt.Sprintf("Wrong fetches count. Expected: %d - Actual: %d", expectedFetchesCount, actualFetchesCount))
+}
+
+func Test_FetchTransactions_NullTransactionFetched_NullTransactionSkipped(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	senderMock := new(application.SenderMock)
+	senderMock.GetPendingTransactionsFunc = func([]string) ([]byte, error) { return []byte("[null]"), nil }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
+	relay := NewTransactionsRelay(senderCreatorMock, nil, time.Minute, 0, watchMock, log.NewLoggerMock())
+
+	// Act
+	transactions := relay.FetchTransactions([]string{"id"}, "0.0.0.0:0", "0.0.0.0")
+
+	// Assert
+	test.Assert(t, len(transactions) == 0, "Transactions are fetched whereas they should not.")
+}
+
+func Test_FetchTransactions_BroadcasterTargetRotated_RateLimitedByRemoteIp(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	senderMock := new(application.SenderMock)
+	senderMock.GetPendingTransactionsFunc = func([]string) ([]byte, error) { return []byte("[]"), nil }
+	senderCreatorMock := new(application.SenderCreatorMock)
+	senderCreatorMock.CreateSenderFunc = func(string, string) (application.Sender, error) { return senderMock, nil }
+	logger := log.NewLoggerMock()
+	relay := NewTransactionsRelay(senderCreatorMock, nil, time.Minute, 1, watchMock, logger)
+
+	// Act
+	_ = relay.FetchTransactions([]string{"id1"}, "0.0.0.0:1", "0.0.0.0")
+	_ = relay.FetchTransactions([]string{"id2"}, "0.0.0.0:2", "0.0.0.0")
+
+	// Assert
+	expectedFetchesCount := 1
+	actualFetchesCount := len(senderMock.GetPendingTransactionsCalls())
+	test.Assert(t, actualFetchesCount == expectedFetchesCount, fmt.Sprintf("Wrong fetches count. Expected: %d - Actual: %d", expectedFetchesCount, actualFetchesCount))
+	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), "relay rate limit reached for announcer 0.0.0.0")
+}
+
+func Test_Relay_RateLimitReached_TransactionsPartiallyAnnounced(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	waitGroup := sync.WaitGroup{}
+	var announcement *ledger.TransactionsAnnouncement
+	senderMock := new(application.SenderMock)
+	senderMock.TargetFunc = func() string { return "0.0.0.0:1" }
+	senderMock.AnnounceTransactionsFunc = func(announcementBytes []byte) error {
+		defer waitGroup.Done()
+		return json.Unmarshal(announcementBytes, &announcement)
+	}
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.HostTargetFunc = func() string { return "0.0.0.0:0" }
+	sendersManagerMock.SendersFunc = func() []application.Sender { return []application.Sender{senderMock} }
+	logger := log.NewLoggerMock()
+	relay := NewTransactionsRelay(nil, sendersManagerMock, time.Minute, 1, watchMock, logger)
+	relay.AnnounceTransaction("id1")
+	relay.AnnounceTransaction("id2")
+	waitGroup.Add(1)
+
+	// Act
+	_ = relay.Relay(context.Background(), 0)
+
+	// Assert
+	waitGroup.Wait()
+	expectedTransactionsCount := 1
+	actualTransactionsCount := len(announcement.TransactionIds())
+	test.Assert(t, actualTransactionsCount == expectedTransactionsCount, fmt.Sprintf("Wrong announced transactions count. Expected: %d - Actual: %d", expectedTransactionsCount, actualTransactionsCount))
+	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), "relay rate limit reached for neighbor 0.0.0.0:1")
+}
@@ -0,0 +1,7 @@
+package application
+
+import "context"
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
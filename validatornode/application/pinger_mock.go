@@ -0,0 +1,75 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package application
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that PingerMock does implement Pinger.
+// If this is not the case, regenerate this file with moq.
+var _ Pinger = &PingerMock{}
+
+// PingerMock is a mock implementation of Pinger.
+//
+//	func TestSomethingThatUsesPinger(t *testing.T) {
+//
+//		// make and configure a mocked Pinger
+//		mockedPinger := &PingerMock{
+//			PingFunc: func(ctx context.Context) error {
+//				panic("mock out the Ping method")
+//			},
+//		}
+//
+//		// use mockedPinger in code that requires Pinger
+//		// and then make assertions.
+//
+//	}
+type PingerMock struct {
+	// PingFunc mocks the Ping method.
+	PingFunc func(ctx context.Context) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Ping holds details about calls to the Ping method.
+		Ping []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockPing sync.RWMutex
+}
+
+// Ping calls PingFunc.
+func (mock *PingerMock) Ping(ctx context.Context) error {
+	if mock.PingFunc == nil {
+		panic("PingerMock.PingFunc: method is nil but Pinger.Ping was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPing.Lock()
+	mock.calls.Ping = append(mock.calls.Ping, callInfo)
+	mock.lockPing.Unlock()
+	return mock.PingFunc(ctx)
+}
+
+// PingCalls gets all the calls that were made to Ping.
+// Check the length with:
+//
+//	len(mockedPinger.PingCalls())
+func (mock *PingerMock) PingCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockPing.RLock()
+	calls = mock.calls.Ping
+	mock.lockPing.RUnlock()
+	return calls
+}
@@ -2,11 +2,24 @@ package application
 
 type Sender interface {
 	Target() string
+	GetAddressHistory(address string, offset uint64, limit uint64) (history []byte, err error)
+	GetBlock(blockHeight uint64) (block []byte, err error)
+	GetBlockByHash(hash [32]byte) (block []byte, err error)
 	GetBlocks(startingBlockHeight uint64) (blocks []byte, err error)
+	GetBlocksRange(startingBlockHeight uint64, endingBlockHeight uint64) (blocks []byte, err error)
+	Handshake(handshake []byte) (neighborHandshake []byte, err error)
 	GetFirstBlockTimestamp() (firstBlockTimestamp int64, err error)
+	GetHeaders(startingBlockHeight uint64) (headers []byte, err error)
+	GetNeighbors() (neighbors []byte, err error)
 	GetSettings() (settings []byte, err error)
+	GetTip() (tip []byte, err error)
 	SendTargets(targets []string) error
-	AddTransaction(transaction []byte) error
+	AnnounceBlock(announcement []byte) error
+	AnnounceTransactions(announcement []byte) error
+	GetPendingTransactions(transactionIds []string) (transactions []byte, err error)
+	AddTransaction(transaction []byte) (result []byte, err error)
+	GetTransactionById(transactionId string) (transaction []byte, err error)
+	GetTransactionProof(transactionId string) (proof []byte, err error)
 	GetTransactions() (transactions []byte, err error)
 	GetUtxos(address string) (utxos []byte, err error)
 }
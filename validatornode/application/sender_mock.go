@@ -17,24 +17,63 @@ var _ Sender = &SenderMock{}
 //
 //		// make and configure a mocked Sender
 //		mockedSender := &SenderMock{
-//			AddTransactionFunc: func(transaction []byte) error {
+//			AddTransactionFunc: func(transaction []byte) ([]byte, error) {
 //				panic("mock out the AddTransaction method")
 //			},
+//			AnnounceBlockFunc: func(announcement []byte) error {
+//				panic("mock out the AnnounceBlock method")
+//			},
+//			AnnounceTransactionsFunc: func(announcement []byte) error {
+//				panic("mock out the AnnounceTransactions method")
+//			},
+//			GetAddressHistoryFunc: func(address string, offset uint64, limit uint64) ([]byte, error) {
+//				panic("mock out the GetAddressHistory method")
+//			},
+//			GetBlockFunc: func(blockHeight uint64) ([]byte, error) {
+//				panic("mock out the GetBlock method")
+//			},
+//			GetBlockByHashFunc: func(hash [32]byte) ([]byte, error) {
+//				panic("mock out the GetBlockByHash method")
+//			},
 //			GetBlocksFunc: func(startingBlockHeight uint64) ([]byte, error) {
 //				panic("mock out the GetBlocks method")
 //			},
+//			GetBlocksRangeFunc: func(startingBlockHeight uint64, endingBlockHeight uint64) ([]byte, error) {
+//				panic("mock out the GetBlocksRange method")
+//			},
 //			GetFirstBlockTimestampFunc: func() (int64, error) {
 //				panic("mock out the GetFirstBlockTimestamp method")
 //			},
+//			GetHeadersFunc: func(startingBlockHeight uint64) ([]byte, error) {
+//				panic("mock out the GetHeaders method")
+//			},
+//			GetNeighborsFunc: func() ([]byte, error) {
+//				panic("mock out the GetNeighbors method")
+//			},
+//			GetPendingTransactionsFunc: func(transactionIds []string) ([]byte, error) {
+//				panic("mock out the GetPendingTransactions method")
+//			},
 //			GetSettingsFunc: func() ([]byte, error) {
 //				panic("mock out the GetSettings method")
 //			},
+//			GetTipFunc: func() ([]byte, error) {
+//				panic("mock out the GetTip method")
+//			},
+//			GetTransactionByIdFunc: func(transactionId string) ([]byte, error) {
+//				panic("mock out the GetTransactionById method")
+//			},
+//			GetTransactionProofFunc: func(transactionId string) ([]byte, error) {
+//				panic("mock out the GetTransactionProof method")
+//			},
 //			GetTransactionsFunc: func() ([]byte, error) {
 //				panic("mock out the GetTransactions method")
 //			},
 //			GetUtxosFunc: func(address string) ([]byte, error) {
 //				panic("mock out the GetUtxos method")
 //			},
+//			HandshakeFunc: func(handshake []byte) ([]byte, error) {
+//				panic("mock out the Handshake method")
+//			},
 //			SendTargetsFunc: func(targets []string) error {
 //				panic("mock out the SendTargets method")
 //			},
@@ -49,23 +88,62 @@ var _ Sender = &SenderMock{}
 //	}
 type SenderMock struct {
 	// AddTransactionFunc mocks the AddTransaction method.
-	AddTransactionFunc func(transaction []byte) error
+	AddTransactionFunc func(transaction []byte) ([]byte, error)
+
+	// AnnounceBlockFunc mocks the AnnounceBlock method.
+	AnnounceBlockFunc func(announcement []byte) error
+
+	// AnnounceTransactionsFunc mocks the AnnounceTransactions method.
+	AnnounceTransactionsFunc func(announcement []byte) error
+
+	// GetAddressHistoryFunc mocks the GetAddressHistory method.
+	GetAddressHistoryFunc func(address string, offset uint64, limit uint64) ([]byte, error)
+
+	// GetBlockFunc mocks the GetBlock method.
+	GetBlockFunc func(blockHeight uint64) ([]byte, error)
+
+	// GetBlockByHashFunc mocks the GetBlockByHash method.
+	GetBlockByHashFunc func(hash [32]byte) ([]byte, error)
 
 	// GetBlocksFunc mocks the GetBlocks method.
 	GetBlocksFunc func(startingBlockHeight uint64) ([]byte, error)
 
+	// GetBlocksRangeFunc mocks the GetBlocksRange method.
+	GetBlocksRangeFunc func(startingBlockHeight uint64, endingBlockHeight uint64) ([]byte, error)
+
 	// GetFirstBlockTimestampFunc mocks the GetFirstBlockTimestamp method.
 	GetFirstBlockTimestampFunc func() (int64, error)
 
+	// GetHeadersFunc mocks the GetHeaders method.
+	GetHeadersFunc func(startingBlockHeight uint64) ([]byte, error)
+
+	// GetNeighborsFunc mocks the GetNeighbors method.
+	GetNeighborsFunc func() ([]byte, error)
+
+	// GetPendingTransactionsFunc mocks the GetPendingTransactions method.
+	GetPendingTransactionsFunc func(transactionIds []string) ([]byte, error)
+
 	// GetSettingsFunc mocks the GetSettings method.
 	GetSettingsFunc func() ([]byte, error)
 
+	// GetTipFunc mocks the GetTip method.
+	GetTipFunc func() ([]byte, error)
+
+	// GetTransactionByIdFunc mocks the GetTransactionById method.
+	GetTransactionByIdFunc func(transactionId string) ([]byte, error)
+
+	// GetTransactionProofFunc mocks the GetTransactionProof method.
+	GetTransactionProofFunc func(transactionId string) ([]byte, error)
+
 	// GetTransactionsFunc mocks the GetTransactions method.
 	GetTransactionsFunc func() ([]byte, error)
 
 	// GetUtxosFunc mocks the GetUtxos method.
 	GetUtxosFunc func(address string) ([]byte, error)
 
+	// HandshakeFunc mocks the Handshake method.
+	HandshakeFunc func(handshake []byte) ([]byte, error)
+
 	// SendTargetsFunc mocks the SendTargets method.
 	SendTargetsFunc func(targets []string) error
 
@@ -79,17 +157,79 @@ type SenderMock struct {
 			// Transaction is the transaction argument value.
 			Transaction []byte
 		}
+		// AnnounceBlock holds details about calls to the AnnounceBlock method.
+		AnnounceBlock []struct {
+			// Announcement is the announcement argument value.
+			Announcement []byte
+		}
+		// AnnounceTransactions holds details about calls to the AnnounceTransactions method.
+		AnnounceTransactions []struct {
+			// Announcement is the announcement argument value.
+			Announcement []byte
+		}
+		// GetAddressHistory holds details about calls to the GetAddressHistory method.
+		GetAddressHistory []struct {
+			// Address is the address argument value.
+			Address string
+			// Offset is the offset argument value.
+			Offset uint64
+			// Limit is the limit argument value.
+			Limit uint64
+		}
+		// GetBlock holds details about calls to the GetBlock method.
+		GetBlock []struct {
+			// BlockHeight is the blockHeight argument value.
+			BlockHeight uint64
+		}
+		// GetBlockByHash holds details about calls to the GetBlockByHash method.
+		GetBlockByHash []struct {
+			// Hash is the hash argument value.
+			Hash [32]byte
+		}
 		// GetBlocks holds details about calls to the GetBlocks method.
 		GetBlocks []struct {
 			// StartingBlockHeight is the startingBlockHeight argument value.
 			StartingBlockHeight uint64
 		}
+		// GetBlocksRange holds details about calls to the GetBlocksRange method.
+		GetBlocksRange []struct {
+			// StartingBlockHeight is the startingBlockHeight argument value.
+			StartingBlockHeight uint64
+			// EndingBlockHeight is the endingBlockHeight argument value.
+			EndingBlockHeight uint64
+		}
 		// GetFirstBlockTimestamp holds details about calls to the GetFirstBlockTimestamp method.
 		GetFirstBlockTimestamp []struct {
 		}
+		// GetHeaders holds details about calls to the GetHeaders method.
+		GetHeaders []struct {
+			// StartingBlockHeight is the startingBlockHeight argument value.
+			StartingBlockHeight uint64
+		}
+		// GetNeighbors holds details about calls to the GetNeighbors method.
+		GetNeighbors []struct {
+		}
+		// GetPendingTransactions holds details about calls to the GetPendingTransactions method.
+		GetPendingTransactions []struct {
+			// TransactionIds is the transactionIds argument value.
+			TransactionIds []string
+		}
 		// GetSettings holds details about calls to the GetSettings method.
 		GetSettings []struct {
 		}
+		// GetTip holds details about calls to the GetTip method.
+		GetTip []struct {
+		}
+		// GetTransactionById holds details about calls to the GetTransactionById method.
+		GetTransactionById []struct {
+			// TransactionId is the transactionId argument value.
+			TransactionId string
+		}
+		// GetTransactionProof holds details about calls to the GetTransactionProof method.
+		GetTransactionProof []struct {
+			// TransactionId is the transactionId argument value.
+			TransactionId string
+		}
 		// GetTransactions holds details about calls to the GetTransactions method.
 		GetTransactions []struct {
 		}
@@ -98,6 +238,11 @@ type SenderMock struct {
 			// Address is the address argument value.
 			Address string
 		}
+		// Handshake holds details about calls to the Handshake method.
+		Handshake []struct {
+			// Handshake is the handshake argument value.
+			Handshake []byte
+		}
 		// SendTargets holds details about calls to the SendTargets method.
 		SendTargets []struct {
 			// Targets is the targets argument value.
@@ -108,17 +253,30 @@ type SenderMock struct {
 		}
 	}
 	lockAddTransaction         sync.RWMutex
+	lockAnnounceBlock          sync.RWMutex
+	lockAnnounceTransactions   sync.RWMutex
+	lockGetAddressHistory      sync.RWMutex
+	lockGetBlock               sync.RWMutex
+	lockGetBlockByHash         sync.RWMutex
 	lockGetBlocks              sync.RWMutex
+	lockGetBlocksRange         sync.RWMutex
 	lockGetFirstBlockTimestamp sync.RWMutex
+	lockGetHeaders             sync.RWMutex
+	lockGetNeighbors           sync.RWMutex
+	lockGetPendingTransactions sync.RWMutex
 	lockGetSettings            sync.RWMutex
+	lockGetTip                 sync.RWMutex
+	lockGetTransactionById     sync.RWMutex
+	lockGetTransactionProof    sync.RWMutex
 	lockGetTransactions        sync.RWMutex
 	lockGetUtxos               sync.RWMutex
+	lockHandshake              sync.RWMutex
 	lockSendTargets            sync.RWMutex
 	lockTarget                 sync.RWMutex
 }
 
 // AddTransaction calls AddTransactionFunc.
-func (mock *SenderMock) AddTransaction(transaction []byte) error {
+func (mock *SenderMock) AddTransaction(transaction []byte) ([]byte, error) {
 	if mock.AddTransactionFunc == nil {
 		panic("SenderMock.AddTransactionFunc: method is nil but Sender.AddTransaction was just called")
 	}
@@ -149,6 +307,174 @@ func (mock *SenderMock) AddTransactionCalls() []struct {
 	return calls
 }
 
+// AnnounceBlock calls AnnounceBlockFunc.
+func (mock *SenderMock) AnnounceBlock(announcement []byte) error {
+	if mock.AnnounceBlockFunc == nil {
+		panic("SenderMock.AnnounceBlockFunc: method is nil but Sender.AnnounceBlock was just called")
+	}
+	callInfo := struct {
+		Announcement []byte
+	}{
+		Announcement: announcement,
+	}
+	mock.lockAnnounceBlock.Lock()
+	mock.calls.AnnounceBlock = append(mock.calls.AnnounceBlock, callInfo)
+	mock.lockAnnounceBlock.Unlock()
+	return mock.AnnounceBlockFunc(announcement)
+}
+
+// AnnounceBlockCalls gets all the calls that were made to AnnounceBlock.
+// Check the length with:
+//
+//	len(mockedSender.AnnounceBlockCalls())
+func (mock *SenderMock) AnnounceBlockCalls() []struct {
+	Announcement []byte
+} {
+	var calls []struct {
+		Announcement []byte
+	}
+	mock.lockAnnounceBlock.RLock()
+	calls = mock.calls.AnnounceBlock
+	mock.lockAnnounceBlock.RUnlock()
+	return calls
+}
+
+// AnnounceTransactions calls AnnounceTransactionsFunc.
+func (mock *SenderMock) AnnounceTransactions(announcement []byte) error {
+	if mock.AnnounceTransactionsFunc == nil {
+		panic("SenderMock.AnnounceTransactionsFunc: method is nil but Sender.AnnounceTransactions was just called")
+	}
+	callInfo := struct {
+		Announcement []byte
+	}{
+		Announcement: announcement,
+	}
+	mock.lockAnnounceTransactions.Lock()
+	mock.calls.AnnounceTransactions = append(mock.calls.AnnounceTransactions, callInfo)
+	mock.lockAnnounceTransactions.Unlock()
+	return mock.AnnounceTransactionsFunc(announcement)
+}
+
+// AnnounceTransactionsCalls gets all the calls that were made to AnnounceTransactions.
+// Check the length with:
+//
+//	len(mockedSender.AnnounceTransactionsCalls())
+func (mock *SenderMock) AnnounceTransactionsCalls() []struct {
+	Announcement []byte
+} {
+	var calls []struct {
+		Announcement []byte
+	}
+	mock.lockAnnounceTransactions.RLock()
+	calls = mock.calls.AnnounceTransactions
+	mock.lockAnnounceTransactions.RUnlock()
+	return calls
+}
+
+// GetAddressHistory calls GetAddressHistoryFunc.
+func (mock *SenderMock) GetAddressHistory(address string, offset uint64, limit uint64) ([]byte, error) {
+	if mock.GetAddressHistoryFunc == nil {
+		panic("SenderMock.GetAddressHistoryFunc: method is nil but Sender.GetAddressHistory was just called")
+	}
+	callInfo := struct {
+		Address string
+		Offset  uint64
+		Limit   uint64
+	}{
+		Address: address,
+		Offset:  offset,
+		Limit:   limit,
+	}
+	mock.lockGetAddressHistory.Lock()
+	mock.calls.GetAddressHistory = append(mock.calls.GetAddressHistory, callInfo)
+	mock.lockGetAddressHistory.Unlock()
+	return mock.GetAddressHistoryFunc(address, offset, limit)
+}
+
+// GetAddressHistoryCalls gets all the calls that were made to GetAddressHistory.
+// Check the length with:
+//
+//	len(mockedSender.GetAddressHistoryCalls())
+func (mock *SenderMock) GetAddressHistoryCalls() []struct {
+	Address string
+	Offset  uint64
+	Limit   uint64
+} {
+	var calls []struct {
+		Address string
+		Offset  uint64
+		Limit   uint64
+	}
+	mock.lockGetAddressHistory.RLock()
+	calls = mock.calls.GetAddressHistory
+	mock.lockGetAddressHistory.RUnlock()
+	return calls
+}
+
+// GetBlock calls GetBlockFunc.
+func (mock *SenderMock) GetBlock(blockHeight uint64) ([]byte, error) {
+	if mock.GetBlockFunc == nil {
+		panic("SenderMock.GetBlockFunc: method is nil but Sender.GetBlock was just called")
+	}
+	callInfo := struct {
+		BlockHeight uint64
+	}{
+		BlockHeight: blockHeight,
+	}
+	mock.lockGetBlock.Lock()
+	mock.calls.GetBlock = append(mock.calls.GetBlock, callInfo)
+	mock.lockGetBlock.Unlock()
+	return mock.GetBlockFunc(blockHeight)
+}
+
+// GetBlockCalls gets all the calls that were made to GetBlock.
+// Check the length with:
+//
+//	len(mockedSender.GetBlockCalls())
+func (mock *SenderMock) GetBlockCalls() []struct {
+	BlockHeight uint64
+} {
+	var calls []struct {
+		BlockHeight uint64
+	}
+	mock.lockGetBlock.RLock()
+	calls = mock.calls.GetBlock
+	mock.lockGetBlock.RUnlock()
+	return calls
+}
+
+// GetBlockByHash calls GetBlockByHashFunc.
+func (mock *SenderMock) GetBlockByHash(hash [32]byte) ([]byte, error) {
+	if mock.GetBlockByHashFunc == nil {
+		panic("SenderMock.GetBlockByHashFunc: method is nil but Sender.GetBlockByHash was just called")
+	}
+	callInfo := struct {
+		Hash [32]byte
+	}{
+		Hash: hash,
+	}
+	mock.lockGetBlockByHash.Lock()
+	mock.calls.GetBlockByHash = append(mock.calls.GetBlockByHash, callInfo)
+	mock.lockGetBlockByHash.Unlock()
+	return mock.GetBlockByHashFunc(hash)
+}
+
+// GetBlockByHashCalls gets all the calls that were made to GetBlockByHash.
+// Check the length with:
+//
+//	len(mockedSender.GetBlockByHashCalls())
+func (mock *SenderMock) GetBlockByHashCalls() []struct {
+	Hash [32]byte
+} {
+	var calls []struct {
+		Hash [32]byte
+	}
+	mock.lockGetBlockByHash.RLock()
+	calls = mock.calls.GetBlockByHash
+	mock.lockGetBlockByHash.RUnlock()
+	return calls
+}
+
 // GetBlocks calls GetBlocksFunc.
 func (mock *SenderMock) GetBlocks(startingBlockHeight uint64) ([]byte, error) {
 	if mock.GetBlocksFunc == nil {
@@ -181,6 +507,42 @@ func (mock *SenderMock) GetBlocksCalls() []struct {
 	return calls
 }
 
+// GetBlocksRange calls GetBlocksRangeFunc.
+func (mock *SenderMock) GetBlocksRange(startingBlockHeight uint64, endingBlockHeight uint64) ([]byte, error) {
+	if mock.GetBlocksRangeFunc == nil {
+		panic("SenderMock.GetBlocksRangeFunc: method is nil but Sender.GetBlocksRange was just called")
+	}
+	callInfo := struct {
+		StartingBlockHeight uint64
+		EndingBlockHeight   uint64
+	}{
+		StartingBlockHeight: startingBlockHeight,
+		EndingBlockHeight:   endingBlockHeight,
+	}
+	mock.lockGetBlocksRange.Lock()
+	mock.calls.GetBlocksRange = append(mock.calls.GetBlocksRange, callInfo)
+	mock.lockGetBlocksRange.Unlock()
+	return mock.GetBlocksRangeFunc(startingBlockHeight, endingBlockHeight)
+}
+
+// GetBlocksRangeCalls gets all the calls that were made to GetBlocksRange.
+// Check the length with:
+//
+//	len(mockedSender.GetBlocksRangeCalls())
+func (mock *SenderMock) GetBlocksRangeCalls() []struct {
+	StartingBlockHeight uint64
+	EndingBlockHeight   uint64
+} {
+	var calls []struct {
+		StartingBlockHeight uint64
+		EndingBlockHeight   uint64
+	}
+	mock.lockGetBlocksRange.RLock()
+	calls = mock.calls.GetBlocksRange
+	mock.lockGetBlocksRange.RUnlock()
+	return calls
+}
+
 // GetFirstBlockTimestamp calls GetFirstBlockTimestampFunc.
 func (mock *SenderMock) GetFirstBlockTimestamp() (int64, error) {
 	if mock.GetFirstBlockTimestampFunc == nil {
@@ -208,6 +570,97 @@ func (mock *SenderMock) GetFirstBlockTimestampCalls() []struct {
 	return calls
 }
 
+// GetHeaders calls GetHeadersFunc.
+func (mock *SenderMock) GetHeaders(startingBlockHeight uint64) ([]byte, error) {
+	if mock.GetHeadersFunc == nil {
+		panic("SenderMock.GetHeadersFunc: method is nil but Sender.GetHeaders was just called")
+	}
+	callInfo := struct {
+		StartingBlockHeight uint64
+	}{
+		StartingBlockHeight: startingBlockHeight,
+	}
+	mock.lockGetHeaders.Lock()
+	mock.calls.GetHeaders = append(mock.calls.GetHeaders, callInfo)
+	mock.lockGetHeaders.Unlock()
+	return mock.GetHeadersFunc(startingBlockHeight)
+}
+
+// GetHeadersCalls gets all the calls that were made to GetHeaders.
+// Check the length with:
+//
+//	len(mockedSender.GetHeadersCalls())
+func (mock *SenderMock) GetHeadersCalls() []struct {
+	StartingBlockHeight uint64
+} {
+	var calls []struct {
+		StartingBlockHeight uint64
+	}
+	mock.lockGetHeaders.RLock()
+	calls = mock.calls.GetHeaders
+	mock.lockGetHeaders.RUnlock()
+	return calls
+}
+
+// GetNeighbors calls GetNeighborsFunc.
+func (mock *SenderMock) GetNeighbors() ([]byte, error) {
+	if mock.GetNeighborsFunc == nil {
+		panic("SenderMock.GetNeighborsFunc: method is nil but Sender.GetNeighbors was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetNeighbors.Lock()
+	mock.calls.GetNeighbors = append(mock.calls.GetNeighbors, callInfo)
+	mock.lockGetNeighbors.Unlock()
+	return mock.GetNeighborsFunc()
+}
+
+// GetNeighborsCalls gets all the calls that were made to GetNeighbors.
+// Check the length with:
+//
+//	len(mockedSender.GetNeighborsCalls())
+func (mock *SenderMock) GetNeighborsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetNeighbors.RLock()
+	calls = mock.calls.GetNeighbors
+	mock.lockGetNeighbors.RUnlock()
+	return calls
+}
+
+// GetPendingTransactions calls GetPendingTransactionsFunc.
+func (mock *SenderMock) GetPendingTransactions(transactionIds []string) ([]byte, error) {
+	if mock.GetPendingTransactionsFunc == nil {
+		panic("SenderMock.GetPendingTransactionsFunc: method is nil but Sender.GetPendingTransactions was just called")
+	}
+	callInfo := struct {
+		TransactionIds []string
+	}{
+		TransactionIds: transactionIds,
+	}
+	mock.lockGetPendingTransactions.Lock()
+	mock.calls.GetPendingTransactions = append(mock.calls.GetPendingTransactions, callInfo)
+	mock.lockGetPendingTransactions.Unlock()
+	return mock.GetPendingTransactionsFunc(transactionIds)
+}
+
+// GetPendingTransactionsCalls gets all the calls that were made to GetPendingTransactions.
+// Check the length with:
+//
+//	len(mockedSender.GetPendingTransactionsCalls())
+func (mock *SenderMock) GetPendingTransactionsCalls() []struct {
+	TransactionIds []string
+} {
+	var calls []struct {
+		TransactionIds []string
+	}
+	mock.lockGetPendingTransactions.RLock()
+	calls = mock.calls.GetPendingTransactions
+	mock.lockGetPendingTransactions.RUnlock()
+	return calls
+}
+
 // GetSettings calls GetSettingsFunc.
 func (mock *SenderMock) GetSettings() ([]byte, error) {
 	if mock.GetSettingsFunc == nil {
@@ -235,6 +688,97 @@ func (mock *SenderMock) GetSettingsCalls() []struct {
 	return calls
 }
 
+// GetTip calls GetTipFunc.
+func (mock *SenderMock) GetTip() ([]byte, error) {
+	if mock.GetTipFunc == nil {
+		panic("SenderMock.GetTipFunc: method is nil but Sender.GetTip was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetTip.Lock()
+	mock.calls.GetTip = append(mock.calls.GetTip, callInfo)
+	mock.lockGetTip.Unlock()
+	return mock.GetTipFunc()
+}
+
+// GetTipCalls gets all the calls that were made to GetTip.
+// Check the length with:
+//
+//	len(mockedSender.GetTipCalls())
+func (mock *SenderMock) GetTipCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetTip.RLock()
+	calls = mock.calls.GetTip
+	mock.lockGetTip.RUnlock()
+	return calls
+}
+
+// GetTransactionById calls GetTransactionByIdFunc.
+func (mock *SenderMock) GetTransactionById(transactionId string) ([]byte, error) {
+	if mock.GetTransactionByIdFunc == nil {
+		panic("SenderMock.GetTransactionByIdFunc: method is nil but Sender.GetTransactionById was just called")
+	}
+	callInfo := struct {
+		TransactionId string
+	}{
+		TransactionId: transactionId,
+	}
+	mock.lockGetTransactionById.Lock()
+	mock.calls.GetTransactionById = append(mock.calls.GetTransactionById, callInfo)
+	mock.lockGetTransactionById.Unlock()
+	return mock.GetTransactionByIdFunc(transactionId)
+}
+
+// GetTransactionByIdCalls gets all the calls that were made to GetTransactionById.
+// Check the length with:
+//
+//	len(mockedSender.GetTransactionByIdCalls())
+func (mock *SenderMock) GetTransactionByIdCalls() []struct {
+	TransactionId string
+} {
+	var calls []struct {
+		TransactionId string
+	}
+	mock.lockGetTransactionById.RLock()
+	calls = mock.calls.GetTransactionById
+	mock.lockGetTransactionById.RUnlock()
+	return calls
+}
+
+// GetTransactionProof calls GetTransactionProofFunc.
+func (mock *SenderMock) GetTransactionProof(transactionId string) ([]byte, error) {
+	if mock.GetTransactionProofFunc == nil {
+		panic("SenderMock.GetTransactionProofFunc: method is nil but Sender.GetTransactionProof was just called")
+	}
+	callInfo := struct {
+		TransactionId string
+	}{
+		TransactionId: transactionId,
+	}
+	mock.lockGetTransactionProof.Lock()
+	mock.calls.GetTransactionProof = append(mock.calls.GetTransactionProof, callInfo)
+	mock.lockGetTransactionProof.Unlock()
+	return mock.GetTransactionProofFunc(transactionId)
+}
+
+// GetTransactionProofCalls gets all the calls that were made to GetTransactionProof.
+// Check the length with:
+//
+//	len(mockedSender.GetTransactionProofCalls())
+func (mock *SenderMock) GetTransactionProofCalls() []struct {
+	TransactionId string
+} {
+	var calls []struct {
+		TransactionId string
+	}
+	mock.lockGetTransactionProof.RLock()
+	calls = mock.calls.GetTransactionProof
+	mock.lockGetTransactionProof.RUnlock()
+	return calls
+}
+
 // GetTransactions calls GetTransactionsFunc.
 func (mock *SenderMock) GetTransactions() ([]byte, error) {
 	if mock.GetTransactionsFunc == nil {
@@ -294,6 +838,38 @@ func (mock *SenderMock) GetUtxosCalls() []struct {
 	return calls
 }
 
+// Handshake calls HandshakeFunc.
+func (mock *SenderMock) Handshake(handshake []byte) ([]byte, error) {
+	if mock.HandshakeFunc == nil {
+		panic("SenderMock.HandshakeFunc: method is nil but Sender.Handshake was just called")
+	}
+	callInfo := struct {
+		Handshake []byte
+	}{
+		Handshake: handshake,
+	}
+	mock.lockHandshake.Lock()
+	mock.calls.Handshake = append(mock.calls.Handshake, callInfo)
+	mock.lockHandshake.Unlock()
+	return mock.HandshakeFunc(handshake)
+}
+
+// HandshakeCalls gets all the calls that were made to Handshake.
+// Check the length with:
+//
+//	len(mockedSender.HandshakeCalls())
+func (mock *SenderMock) HandshakeCalls() []struct {
+	Handshake []byte
+} {
+	var calls []struct {
+		Handshake []byte
+	}
+	mock.lockHandshake.RLock()
+	calls = mock.calls.Handshake
+	mock.lockHandshake.RUnlock()
+	return calls
+}
+
 // SendTargets calls SendTargetsFunc.
 func (mock *SenderMock) SendTargets(targets []string) error {
 	if mock.SendTargetsFunc == nil {
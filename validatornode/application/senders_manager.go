@@ -1,8 +1,14 @@
 package application
 
+import "github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+
 type SendersManager interface {
 	AddTargets(targets []string)
+	Handshake() (*ledger.Handshake, error)
 	HostTarget() string
 	Incentive(target string)
+	NeighborsStatus() []*ledger.NeighborStatus
+	NeighborsTipHeight() uint64
+	Penalize(target string, misbehavior Misbehavior)
 	Senders() []Sender
 }
@@ -4,6 +4,7 @@
 package application
 
 import (
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
 	"sync"
 )
 
@@ -20,12 +21,24 @@ var _ SendersManager = &SendersManagerMock{}
 //			AddTargetsFunc: func(targets []string)  {
 //				panic("mock out the AddTargets method")
 //			},
+//			HandshakeFunc: func() (*ledger.Handshake, error) {
+//				panic("mock out the Handshake method")
+//			},
 //			HostTargetFunc: func() string {
 //				panic("mock out the HostTarget method")
 //			},
 //			IncentiveFunc: func(target string)  {
 //				panic("mock out the Incentive method")
 //			},
+//			NeighborsStatusFunc: func() []*ledger.NeighborStatus {
+//				panic("mock out the NeighborsStatus method")
+//			},
+//			NeighborsTipHeightFunc: func() uint64 {
+//				panic("mock out the NeighborsTipHeight method")
+//			},
+//			PenalizeFunc: func(target string, misbehavior Misbehavior)  {
+//				panic("mock out the Penalize method")
+//			},
 //			SendersFunc: func() []Sender {
 //				panic("mock out the Senders method")
 //			},
@@ -39,12 +52,24 @@ type SendersManagerMock struct {
 	// AddTargetsFunc mocks the AddTargets method.
 	AddTargetsFunc func(targets []string)
 
+	// HandshakeFunc mocks the Handshake method.
+	HandshakeFunc func() (*ledger.Handshake, error)
+
 	// HostTargetFunc mocks the HostTarget method.
 	HostTargetFunc func() string
 
 	// IncentiveFunc mocks the Incentive method.
 	IncentiveFunc func(target string)
 
+	// NeighborsStatusFunc mocks the NeighborsStatus method.
+	NeighborsStatusFunc func() []*ledger.NeighborStatus
+
+	// NeighborsTipHeightFunc mocks the NeighborsTipHeight method.
+	NeighborsTipHeightFunc func() uint64
+
+	// PenalizeFunc mocks the Penalize method.
+	PenalizeFunc func(target string, misbehavior Misbehavior)
+
 	// SendersFunc mocks the Senders method.
 	SendersFunc func() []Sender
 
@@ -55,6 +80,9 @@ type SendersManagerMock struct {
 			// Targets is the targets argument value.
 			Targets []string
 		}
+		// Handshake holds details about calls to the Handshake method.
+		Handshake []struct {
+		}
 		// HostTarget holds details about calls to the HostTarget method.
 		HostTarget []struct {
 		}
@@ -63,14 +91,31 @@ type SendersManagerMock struct {
 			// Target is the target argument value.
 			Target string
 		}
+		// NeighborsStatus holds details about calls to the NeighborsStatus method.
+		NeighborsStatus []struct {
+		}
+		// NeighborsTipHeight holds details about calls to the NeighborsTipHeight method.
+		NeighborsTipHeight []struct {
+		}
+		// Penalize holds details about calls to the Penalize method.
+		Penalize []struct {
+			// Target is the target argument value.
+			Target string
+			// Misbehavior is the misbehavior argument value.
+			Misbehavior Misbehavior
+		}
 		// Senders holds details about calls to the Senders method.
 		Senders []struct {
 		}
 	}
-	lockAddTargets sync.RWMutex
-	lockHostTarget sync.RWMutex
-	lockIncentive  sync.RWMutex
-	lockSenders    sync.RWMutex
+	lockAddTargets         sync.RWMutex
+	lockHandshake          sync.RWMutex
+	lockHostTarget         sync.RWMutex
+	lockIncentive          sync.RWMutex
+	lockNeighborsStatus    sync.RWMutex
+	lockNeighborsTipHeight sync.RWMutex
+	lockPenalize           sync.RWMutex
+	lockSenders            sync.RWMutex
 }
 
 // AddTargets calls AddTargetsFunc.
@@ -105,6 +150,33 @@ func (mock *SendersManagerMock) AddTargetsCalls() []struct {
 	return calls
 }
 
+// Handshake calls HandshakeFunc.
+func (mock *SendersManagerMock) Handshake() (*ledger.Handshake, error) {
+	if mock.HandshakeFunc == nil {
+		panic("SendersManagerMock.HandshakeFunc: method is nil but SendersManager.Handshake was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockHandshake.Lock()
+	mock.calls.Handshake = append(mock.calls.Handshake, callInfo)
+	mock.lockHandshake.Unlock()
+	return mock.HandshakeFunc()
+}
+
+// HandshakeCalls gets all the calls that were made to Handshake.
+// Check the length with:
+//
+//	len(mockedSendersManager.HandshakeCalls())
+func (mock *SendersManagerMock) HandshakeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockHandshake.RLock()
+	calls = mock.calls.Handshake
+	mock.lockHandshake.RUnlock()
+	return calls
+}
+
 // HostTarget calls HostTargetFunc.
 func (mock *SendersManagerMock) HostTarget() string {
 	if mock.HostTargetFunc == nil {
@@ -164,6 +236,96 @@ func (mock *SendersManagerMock) IncentiveCalls() []struct {
 	return calls
 }
 
+// NeighborsStatus calls NeighborsStatusFunc.
+func (mock *SendersManagerMock) NeighborsStatus() []*ledger.NeighborStatus {
+	if mock.NeighborsStatusFunc == nil {
+		panic("SendersManagerMock.NeighborsStatusFunc: method is nil but SendersManager.NeighborsStatus was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockNeighborsStatus.Lock()
+	mock.calls.NeighborsStatus = append(mock.calls.NeighborsStatus, callInfo)
+	mock.lockNeighborsStatus.Unlock()
+	return mock.NeighborsStatusFunc()
+}
+
+// NeighborsStatusCalls gets all the calls that were made to NeighborsStatus.
+// Check the length with:
+//
+//	len(mockedSendersManager.NeighborsStatusCalls())
+func (mock *SendersManagerMock) NeighborsStatusCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockNeighborsStatus.RLock()
+	calls = mock.calls.NeighborsStatus
+	mock.lockNeighborsStatus.RUnlock()
+	return calls
+}
+
+// NeighborsTipHeight calls NeighborsTipHeightFunc.
+func (mock *SendersManagerMock) NeighborsTipHeight() uint64 {
+	if mock.NeighborsTipHeightFunc == nil {
+		panic("SendersManagerMock.NeighborsTipHeightFunc: method is nil but SendersManager.NeighborsTipHeight was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockNeighborsTipHeight.Lock()
+	mock.calls.NeighborsTipHeight = append(mock.calls.NeighborsTipHeight, callInfo)
+	mock.lockNeighborsTipHeight.Unlock()
+	return mock.NeighborsTipHeightFunc()
+}
+
+// NeighborsTipHeightCalls gets all the calls that were made to NeighborsTipHeight.
+// Check the length with:
+//
+//	len(mockedSendersManager.NeighborsTipHeightCalls())
+func (mock *SendersManagerMock) NeighborsTipHeightCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockNeighborsTipHeight.RLock()
+	calls = mock.calls.NeighborsTipHeight
+	mock.lockNeighborsTipHeight.RUnlock()
+	return calls
+}
+
+// Penalize calls PenalizeFunc.
+func (mock *SendersManagerMock) Penalize(target string, misbehavior Misbehavior) {
+	if mock.PenalizeFunc == nil {
+		panic("SendersManagerMock.PenalizeFunc: method is nil but SendersManager.Penalize was just called")
+	}
+	callInfo := struct {
+		Target      string
+		Misbehavior Misbehavior
+	}{
+		Target:      target,
+		Misbehavior: misbehavior,
+	}
+	mock.lockPenalize.Lock()
+	mock.calls.Penalize = append(mock.calls.Penalize, callInfo)
+	mock.lockPenalize.Unlock()
+	mock.PenalizeFunc(target, misbehavior)
+}
+
+// PenalizeCalls gets all the calls that were made to Penalize.
+// Check the length with:
+//
+//	len(mockedSendersManager.PenalizeCalls())
+func (mock *SendersManagerMock) PenalizeCalls() []struct {
+	Target      string
+	Misbehavior Misbehavior
+} {
+	var calls []struct {
+		Target      string
+		Misbehavior Misbehavior
+	}
+	mock.lockPenalize.RLock()
+	calls = mock.calls.Penalize
+	mock.lockPenalize.RUnlock()
+	return calls
+}
+
 // Senders calls SendersFunc.
 func (mock *SendersManagerMock) Senders() []Sender {
 	if mock.SendersFunc == nil {
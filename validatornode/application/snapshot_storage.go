@@ -0,0 +1,6 @@
+package application
+
+type SnapshotStorage interface {
+	SaveSnapshot(snapshot []byte) error
+	Snapshot() ([]byte, error)
+}
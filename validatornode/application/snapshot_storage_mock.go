@@ -0,0 +1,111 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package application
+
+import (
+	"sync"
+)
+
+// Ensure, that SnapshotStorageMock does implement SnapshotStorage.
+// If this is not the case, regenerate this file with moq.
+var _ SnapshotStorage = &SnapshotStorageMock{}
+
+// SnapshotStorageMock is a mock implementation of SnapshotStorage.
+//
+//	func TestSomethingThatUsesSnapshotStorage(t *testing.T) {
+//
+//		// make and configure a mocked SnapshotStorage
+//		mockedSnapshotStorage := &SnapshotStorageMock{
+//			SaveSnapshotFunc: func(snapshot []byte) error {
+//				panic("mock out the SaveSnapshot method")
+//			},
+//			SnapshotFunc: func() ([]byte, error) {
+//				panic("mock out the Snapshot method")
+//			},
+//		}
+//
+//		// use mockedSnapshotStorage in code that requires SnapshotStorage
+//		// and then make assertions.
+//
+//	}
+type SnapshotStorageMock struct {
+	// SaveSnapshotFunc mocks the SaveSnapshot method.
+	SaveSnapshotFunc func(snapshot []byte) error
+
+	// SnapshotFunc mocks the Snapshot method.
+	SnapshotFunc func() ([]byte, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// SaveSnapshot holds details about calls to the SaveSnapshot method.
+		SaveSnapshot []struct {
+			// Snapshot is the snapshot argument value.
+			Snapshot []byte
+		}
+		// Snapshot holds details about calls to the Snapshot method.
+		Snapshot []struct {
+		}
+	}
+	lockSaveSnapshot sync.RWMutex
+	lockSnapshot     sync.RWMutex
+}
+
+// SaveSnapshot calls SaveSnapshotFunc.
+func (mock *SnapshotStorageMock) SaveSnapshot(snapshot []byte) error {
+	if mock.SaveSnapshotFunc == nil {
+		panic("SnapshotStorageMock.SaveSnapshotFunc: method is nil but SnapshotStorage.SaveSnapshot was just called")
+	}
+	callInfo := struct {
+		Snapshot []byte
+	}{
+		Snapshot: snapshot,
+	}
+	mock.lockSaveSnapshot.Lock()
+	mock.calls.SaveSnapshot = append(mock.calls.SaveSnapshot, callInfo)
+	mock.lockSaveSnapshot.Unlock()
+	return mock.SaveSnapshotFunc(snapshot)
+}
+
+// SaveSnapshotCalls gets all the calls that were made to SaveSnapshot.
+// Check the length with:
+//
+//	len(mockedSnapshotStorage.SaveSnapshotCalls())
+func (mock *SnapshotStorageMock) SaveSnapshotCalls() []struct {
+	Snapshot []byte
+} {
+	var calls []struct {
+		Snapshot []byte
+	}
+	mock.lockSaveSnapshot.RLock()
+	calls = mock.calls.SaveSnapshot
+	mock.lockSaveSnapshot.RUnlock()
+	return calls
+}
+
+// Snapshot calls SnapshotFunc.
+func (mock *SnapshotStorageMock) Snapshot() ([]byte, error) {
+	if mock.SnapshotFunc == nil {
+		panic("SnapshotStorageMock.SnapshotFunc: method is nil but SnapshotStorage.Snapshot was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockSnapshot.Lock()
+	mock.calls.Snapshot = append(mock.calls.Snapshot, callInfo)
+	mock.lockSnapshot.Unlock()
+	return mock.SnapshotFunc()
+}
+
+// SnapshotCalls gets all the calls that were made to Snapshot.
+// Check the length with:
+//
+//	len(mockedSnapshotStorage.SnapshotCalls())
+func (mock *SnapshotStorageMock) SnapshotCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockSnapshot.RLock()
+	calls = mock.calls.Snapshot
+	mock.lockSnapshot.RUnlock()
+	return calls
+}
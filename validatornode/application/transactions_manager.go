@@ -3,6 +3,7 @@ package application
 import "github.com/my-cloud/ruthenium/validatornode/domain/ledger"
 
 type TransactionsManager interface {
-	AddTransaction(transaction *ledger.Transaction, broadcasterTarget string, hostTarget string)
+	AddTransaction(transaction *ledger.Transaction, broadcasterTarget string) *ledger.TransactionResult
+	PendingTransactions(transactionIds []string) []*ledger.Transaction
 	Transactions() []*ledger.Transaction
 }
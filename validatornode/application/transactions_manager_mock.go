@@ -18,9 +18,12 @@ var _ TransactionsManager = &TransactionsManagerMock{}
 //
 //		// make and configure a mocked TransactionsManager
 //		mockedTransactionsManager := &TransactionsManagerMock{
-//			AddTransactionFunc: func(transaction *ledger.Transaction, broadcasterTarget string, hostTarget string)  {
+//			AddTransactionFunc: func(transaction *ledger.Transaction, broadcasterTarget string) *ledger.TransactionResult {
 //				panic("mock out the AddTransaction method")
 //			},
+//			PendingTransactionsFunc: func(transactionIds []string) []*ledger.Transaction {
+//				panic("mock out the PendingTransactions method")
+//			},
 //			TransactionsFunc: func() []*ledger.Transaction {
 //				panic("mock out the Transactions method")
 //			},
@@ -32,7 +35,10 @@ var _ TransactionsManager = &TransactionsManagerMock{}
 //	}
 type TransactionsManagerMock struct {
 	// AddTransactionFunc mocks the AddTransaction method.
-	AddTransactionFunc func(transaction *ledger.Transaction, broadcasterTarget string, hostTarget string)
+	AddTransactionFunc func(transaction *ledger.Transaction, broadcasterTarget string) *ledger.TransactionResult
+
+	// PendingTransactionsFunc mocks the PendingTransactions method.
+	PendingTransactionsFunc func(transactionIds []string) []*ledger.Transaction
 
 	// TransactionsFunc mocks the Transactions method.
 	TransactionsFunc func() []*ledger.Transaction
@@ -45,35 +51,37 @@ type TransactionsManagerMock struct {
 			Transaction *ledger.Transaction
 			// BroadcasterTarget is the broadcasterTarget argument value.
 			BroadcasterTarget string
-			// HostTarget is the hostTarget argument value.
-			HostTarget string
+		}
+		// PendingTransactions holds details about calls to the PendingTransactions method.
+		PendingTransactions []struct {
+			// TransactionIds is the transactionIds argument value.
+			TransactionIds []string
 		}
 		// Transactions holds details about calls to the Transactions method.
 		Transactions []struct {
 		}
 	}
-	lockAddTransaction sync.RWMutex
-	lockTransactions   sync.RWMutex
+	lockAddTransaction      sync.RWMutex
+	lockPendingTransactions sync.RWMutex
+	lockTransactions        sync.RWMutex
 }
 
 // AddTransaction calls AddTransactionFunc.
-func (mock *TransactionsManagerMock) AddTransaction(transaction *ledger.Transaction, broadcasterTarget string, hostTarget string) {
+func (mock *TransactionsManagerMock) AddTransaction(transaction *ledger.Transaction, broadcasterTarget string) *ledger.TransactionResult {
 	if mock.AddTransactionFunc == nil {
 		panic("TransactionsManagerMock.AddTransactionFunc: method is nil but TransactionsManager.AddTransaction was just called")
 	}
 	callInfo := struct {
 		Transaction       *ledger.Transaction
 		BroadcasterTarget string
-		HostTarget        string
 	}{
 		Transaction:       transaction,
 		BroadcasterTarget: broadcasterTarget,
-		HostTarget:        hostTarget,
 	}
 	mock.lockAddTransaction.Lock()
 	mock.calls.AddTransaction = append(mock.calls.AddTransaction, callInfo)
 	mock.lockAddTransaction.Unlock()
-	mock.AddTransactionFunc(transaction, broadcasterTarget, hostTarget)
+	return mock.AddTransactionFunc(transaction, broadcasterTarget)
 }
 
 // AddTransactionCalls gets all the calls that were made to AddTransaction.
@@ -83,12 +91,10 @@ func (mock *TransactionsManagerMock) AddTransaction(transaction *ledger.Transact
 func (mock *TransactionsManagerMock) AddTransactionCalls() []struct {
 	Transaction       *ledger.Transaction
 	BroadcasterTarget string
-	HostTarget        string
 } {
 	var calls []struct {
 		Transaction       *ledger.Transaction
 		BroadcasterTarget string
-		HostTarget        string
 	}
 	mock.lockAddTransaction.RLock()
 	calls = mock.calls.AddTransaction
@@ -96,6 +102,38 @@ func (mock *TransactionsManagerMock) AddTransactionCalls() []struct {
 	return calls
 }
 
+// PendingTransactions calls PendingTransactionsFunc.
+func (mock *TransactionsManagerMock) PendingTransactions(transactionIds []string) []*ledger.Transaction {
+	if mock.PendingTransactionsFunc == nil {
+		panic("TransactionsManagerMock.PendingTransactionsFunc: method is nil but TransactionsManager.PendingTransactions was just called")
+	}
+	callInfo := struct {
+		TransactionIds []string
+	}{
+		TransactionIds: transactionIds,
+	}
+	mock.lockPendingTransactions.Lock()
+	mock.calls.PendingTransactions = append(mock.calls.PendingTransactions, callInfo)
+	mock.lockPendingTransactions.Unlock()
+	return mock.PendingTransactionsFunc(transactionIds)
+}
+
+// PendingTransactionsCalls gets all the calls that were made to PendingTransactions.
+// Check the length with:
+//
+//	len(mockedTransactionsManager.PendingTransactionsCalls())
+func (mock *TransactionsManagerMock) PendingTransactionsCalls() []struct {
+	TransactionIds []string
+} {
+	var calls []struct {
+		TransactionIds []string
+	}
+	mock.lockPendingTransactions.RLock()
+	calls = mock.calls.PendingTransactions
+	mock.lockPendingTransactions.RUnlock()
+	return calls
+}
+
 // Transactions calls TransactionsFunc.
 func (mock *TransactionsManagerMock) Transactions() []*ledger.Transaction {
 	if mock.TransactionsFunc == nil {
@@ -0,0 +1,8 @@
+package application
+
+import "github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+
+type TransactionsRelayer interface {
+	AnnounceTransaction(transactionId string)
+	FetchTransactions(transactionIds []string, broadcasterTarget string, remoteIp string) []*ledger.Transaction
+}
@@ -0,0 +1,131 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package application
+
+import (
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"sync"
+)
+
+// Ensure, that TransactionsRelayerMock does implement TransactionsRelayer.
+// If this is not the case, regenerate this file with moq.
+var _ TransactionsRelayer = &TransactionsRelayerMock{}
+
+// TransactionsRelayerMock is a mock implementation of TransactionsRelayer.
+//
+//	func TestSomethingThatUsesTransactionsRelayer(t *testing.T) {
+//
+//		// make and configure a mocked TransactionsRelayer
+//		mockedTransactionsRelayer := &TransactionsRelayerMock{
+//			AnnounceTransactionFunc: func(transactionId string)  {
+//				panic("mock out the AnnounceTransaction method")
+//			},
+//			FetchTransactionsFunc: func(transactionIds []string, broadcasterTarget string, remoteIp string) []*ledger.Transaction {
+//				panic("mock out the FetchTransactions method")
+//			},
+//		}
+//
+//		// use mockedTransactionsRelayer in code that requires TransactionsRelayer
+//		// and then make assertions.
+//
+//	}
+type TransactionsRelayerMock struct {
+	// AnnounceTransactionFunc mocks the AnnounceTransaction method.
+	AnnounceTransactionFunc func(transactionId string)
+
+	// FetchTransactionsFunc mocks the FetchTransactions method.
+	FetchTransactionsFunc func(transactionIds []string, broadcasterTarget string, remoteIp string) []*ledger.Transaction
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AnnounceTransaction holds details about calls to the AnnounceTransaction method.
+		AnnounceTransaction []struct {
+			// TransactionId is the transactionId argument value.
+			TransactionId string
+		}
+		// FetchTransactions holds details about calls to the FetchTransactions method.
+		FetchTransactions []struct {
+			// TransactionIds is the transactionIds argument value.
+			TransactionIds []string
+			// BroadcasterTarget is the broadcasterTarget argument value.
+			BroadcasterTarget string
+			// RemoteIp is the remoteIp argument value.
+			RemoteIp string
+		}
+	}
+	lockAnnounceTransaction sync.RWMutex
+	lockFetchTransactions   sync.RWMutex
+}
+
+// AnnounceTransaction calls AnnounceTransactionFunc.
+func (mock *TransactionsRelayerMock) AnnounceTransaction(transactionId string) {
+	if mock.AnnounceTransactionFunc == nil {
+		panic("TransactionsRelayerMock.AnnounceTransactionFunc: method is nil but TransactionsRelayer.AnnounceTransaction was just called")
+	}
+	callInfo := struct {
+		TransactionId string
+	}{
+		TransactionId: transactionId,
+	}
+	mock.lockAnnounceTransaction.Lock()
+	mock.calls.AnnounceTransaction = append(mock.calls.AnnounceTransaction, callInfo)
+	mock.lockAnnounceTransaction.Unlock()
+	mock.AnnounceTransactionFunc(transactionId)
+}
+
+// AnnounceTransactionCalls gets all the calls that were made to AnnounceTransaction.
+// Check the length with:
+//
+//	len(mockedTransactionsRelayer.AnnounceTransactionCalls())
+func (mock *TransactionsRelayerMock) AnnounceTransactionCalls() []struct {
+	TransactionId string
+} {
+	var calls []struct {
+		TransactionId string
+	}
+	mock.lockAnnounceTransaction.RLock()
+	calls = mock.calls.AnnounceTransaction
+	mock.lockAnnounceTransaction.RUnlock()
+	return calls
+}
+
+// FetchTransactions calls FetchTransactionsFunc.
+func (mock *TransactionsRelayerMock) FetchTransactions(transactionIds []string, broadcasterTarget string, remoteIp string) []*ledger.Transaction {
+	if mock.FetchTransactionsFunc == nil {
+		panic("TransactionsRelayerMock.FetchTransactionsFunc: method is nil but TransactionsRelayer.FetchTransactions was just called")
+	}
+	callInfo := struct {
+		TransactionIds    []string
+		BroadcasterTarget string
+		RemoteIp          string
+	}{
+		TransactionIds:    transactionIds,
+		BroadcasterTarget: broadcasterTarget,
+		RemoteIp:          remoteIp,
+	}
+	mock.lockFetchTransactions.Lock()
+	mock.calls.FetchTransactions = append(mock.calls.FetchTransactions, callInfo)
+	mock.lockFetchTransactions.Unlock()
+	return mock.FetchTransactionsFunc(transactionIds, broadcasterTarget, remoteIp)
+}
+
+// FetchTransactionsCalls gets all the calls that were made to FetchTransactions.
+// Check the length with:
+//
+//	len(mockedTransactionsRelayer.FetchTransactionsCalls())
+func (mock *TransactionsRelayerMock) FetchTransactionsCalls() []struct {
+	TransactionIds    []string
+	BroadcasterTarget string
+	RemoteIp          string
+} {
+	var calls []struct {
+		TransactionIds    []string
+		BroadcasterTarget string
+		RemoteIp          string
+	}
+	mock.lockFetchTransactions.RLock()
+	calls = mock.calls.FetchTransactions
+	mock.lockFetchTransactions.RUnlock()
+	return calls
+}
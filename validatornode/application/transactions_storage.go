@@ -0,0 +1,9 @@
+package application
+
+import "github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+
+type TransactionsStorage interface {
+	AddTransaction(transaction *ledger.Transaction) error
+	SaveTransactions(transactions []*ledger.Transaction) error
+	Transactions() ([]*ledger.Transaction, error)
+}
@@ -0,0 +1,156 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package application
+
+import (
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"sync"
+)
+
+// Ensure, that TransactionsStorageMock does implement TransactionsStorage.
+// If this is not the case, regenerate this file with moq.
+var _ TransactionsStorage = &TransactionsStorageMock{}
+
+// TransactionsStorageMock is a mock implementation of TransactionsStorage.
+//
+//	func TestSomethingThatUsesTransactionsStorage(t *testing.T) {
+//
+//		// make and configure a mocked TransactionsStorage
+//		mockedTransactionsStorage := &TransactionsStorageMock{
+//			AddTransactionFunc: func(transaction *ledger.Transaction) error {
+//				panic("mock out the AddTransaction method")
+//			},
+//			SaveTransactionsFunc: func(transactions []*ledger.Transaction) error {
+//				panic("mock out the SaveTransactions method")
+//			},
+//			TransactionsFunc: func() ([]*ledger.Transaction, error) {
+//				panic("mock out the Transactions method")
+//			},
+//		}
+//
+//		// use mockedTransactionsStorage in code that requires TransactionsStorage
+//		// and then make assertions.
+//
+//	}
+type TransactionsStorageMock struct {
+	// AddTransactionFunc mocks the AddTransaction method.
+	AddTransactionFunc func(transaction *ledger.Transaction) error
+
+	// SaveTransactionsFunc mocks the SaveTransactions method.
+	SaveTransactionsFunc func(transactions []*ledger.Transaction) error
+
+	// TransactionsFunc mocks the Transactions method.
+	TransactionsFunc func() ([]*ledger.Transaction, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddTransaction holds details about calls to the AddTransaction method.
+		AddTransaction []struct {
+			// Transaction is the transaction argument value.
+			Transaction *ledger.Transaction
+		}
+		// SaveTransactions holds details about calls to the SaveTransactions method.
+		SaveTransactions []struct {
+			// Transactions is the transactions argument value.
+			Transactions []*ledger.Transaction
+		}
+		// Transactions holds details about calls to the Transactions method.
+		Transactions []struct {
+		}
+	}
+	lockAddTransaction   sync.RWMutex
+	lockSaveTransactions sync.RWMutex
+	lockTransactions     sync.RWMutex
+}
+
+// AddTransaction calls AddTransactionFunc.
+func (mock *TransactionsStorageMock) AddTransaction(transaction *ledger.Transaction) error {
+	if mock.AddTransactionFunc == nil {
+		panic("TransactionsStorageMock.AddTransactionFunc: method is nil but TransactionsStorage.AddTransaction was just called")
+	}
+	callInfo := struct {
+		Transaction *ledger.Transaction
+	}{
+		Transaction: transaction,
+	}
+	mock.lockAddTransaction.Lock()
+	mock.calls.AddTransaction = append(mock.calls.AddTransaction, callInfo)
+	mock.lockAddTransaction.Unlock()
+	return mock.AddTransactionFunc(transaction)
+}
+
+// AddTransactionCalls gets all the calls that were made to AddTransaction.
+// Check the length with:
+//
+//	len(mockedTransactionsStorage.AddTransactionCalls())
+func (mock *TransactionsStorageMock) AddTransactionCalls() []struct {
+	Transaction *ledger.Transaction
+} {
+	var calls []struct {
+		Transaction *ledger.Transaction
+	}
+	mock.lockAddTransaction.RLock()
+	calls = mock.calls.AddTransaction
+	mock.lockAddTransaction.RUnlock()
+	return calls
+}
+
+// SaveTransactions calls SaveTransactionsFunc.
+func (mock *TransactionsStorageMock) SaveTransactions(transactions []*ledger.Transaction) error {
+	if mock.SaveTransactionsFunc == nil {
+		panic("TransactionsStorageMock.SaveTransactionsFunc: method is nil but TransactionsStorage.SaveTransactions was just called")
+	}
+	callInfo := struct {
+		Transactions []*ledger.Transaction
+	}{
+		Transactions: transactions,
+	}
+	mock.lockSaveTransactions.Lock()
+	mock.calls.SaveTransactions = append(mock.calls.SaveTransactions, callInfo)
+	mock.lockSaveTransactions.Unlock()
+	return mock.SaveTransactionsFunc(transactions)
+}
+
+// SaveTransactionsCalls gets all the calls that were made to SaveTransactions.
+// Check the length with:
+//
+//	len(mockedTransactionsStorage.SaveTransactionsCalls())
+func (mock *TransactionsStorageMock) SaveTransactionsCalls() []struct {
+	Transactions []*ledger.Transaction
+} {
+	var calls []struct {
+		Transactions []*ledger.Transaction
+	}
+	mock.lockSaveTransactions.RLock()
+	calls = mock.calls.SaveTransactions
+	mock.lockSaveTransactions.RUnlock()
+	return calls
+}
+
+// Transactions calls TransactionsFunc.
+func (mock *TransactionsStorageMock) Transactions() ([]*ledger.Transaction, error) {
+	if mock.TransactionsFunc == nil {
+		panic("TransactionsStorageMock.TransactionsFunc: method is nil but TransactionsStorage.Transactions was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockTransactions.Lock()
+	mock.calls.Transactions = append(mock.calls.Transactions, callInfo)
+	mock.lockTransactions.Unlock()
+	return mock.TransactionsFunc()
+}
+
+// TransactionsCalls gets all the calls that were made to Transactions.
+// Check the length with:
+//
+//	len(mockedTransactionsStorage.TransactionsCalls())
+func (mock *TransactionsStorageMock) TransactionsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTransactions.RLock()
+	calls = mock.calls.Transactions
+	mock.lockTransactions.RUnlock()
+	return calls
+}
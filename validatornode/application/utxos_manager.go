@@ -6,6 +6,10 @@ type UtxosManager interface {
 	CalculateFee(transaction *ledger.Transaction, timestamp int64) (uint64, error)
 	Clear()
 	Copy() UtxosManager
+	MarshalJSON() ([]byte, error)
+	RevertUtxos(transactions []*ledger.Transaction, spentUtxos []*ledger.Utxo) error
+	SpentUtxos(transactions []*ledger.Transaction) []*ledger.Utxo
+	UnmarshalJSON(data []byte) error
 	UpdateUtxos(transactions []*ledger.Transaction, timestamp int64) error
 	Utxos(address string) []*ledger.Utxo
 }
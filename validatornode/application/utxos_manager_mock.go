@@ -27,6 +27,18 @@ var _ UtxosManager = &UtxosManagerMock{}
 //			CopyFunc: func() UtxosManager {
 //				panic("mock out the Copy method")
 //			},
+//			MarshalJSONFunc: func() ([]byte, error) {
+//				panic("mock out the MarshalJSON method")
+//			},
+//			RevertUtxosFunc: func(transactions []*ledger.Transaction, spentUtxos []*ledger.Utxo) error {
+//				panic("mock out the RevertUtxos method")
+//			},
+//			SpentUtxosFunc: func(transactions []*ledger.Transaction) []*ledger.Utxo {
+//				panic("mock out the SpentUtxos method")
+//			},
+//			UnmarshalJSONFunc: func(data []byte) error {
+//				panic("mock out the UnmarshalJSON method")
+//			},
 //			UpdateUtxosFunc: func(transactions []*ledger.Transaction, timestamp int64) error {
 //				panic("mock out the UpdateUtxos method")
 //			},
@@ -49,6 +61,18 @@ type UtxosManagerMock struct {
 	// CopyFunc mocks the Copy method.
 	CopyFunc func() UtxosManager
 
+	// MarshalJSONFunc mocks the MarshalJSON method.
+	MarshalJSONFunc func() ([]byte, error)
+
+	// RevertUtxosFunc mocks the RevertUtxos method.
+	RevertUtxosFunc func(transactions []*ledger.Transaction, spentUtxos []*ledger.Utxo) error
+
+	// SpentUtxosFunc mocks the SpentUtxos method.
+	SpentUtxosFunc func(transactions []*ledger.Transaction) []*ledger.Utxo
+
+	// UnmarshalJSONFunc mocks the UnmarshalJSON method.
+	UnmarshalJSONFunc func(data []byte) error
+
 	// UpdateUtxosFunc mocks the UpdateUtxos method.
 	UpdateUtxosFunc func(transactions []*ledger.Transaction, timestamp int64) error
 
@@ -70,6 +94,26 @@ type UtxosManagerMock struct {
 		// Copy holds details about calls to the Copy method.
 		Copy []struct {
 		}
+		// MarshalJSON holds details about calls to the MarshalJSON method.
+		MarshalJSON []struct {
+		}
+		// RevertUtxos holds details about calls to the RevertUtxos method.
+		RevertUtxos []struct {
+			// Transactions is the transactions argument value.
+			Transactions []*ledger.Transaction
+			// SpentUtxos is the spentUtxos argument value.
+			SpentUtxos []*ledger.Utxo
+		}
+		// SpentUtxos holds details about calls to the SpentUtxos method.
+		SpentUtxos []struct {
+			// Transactions is the transactions argument value.
+			Transactions []*ledger.Transaction
+		}
+		// UnmarshalJSON holds details about calls to the UnmarshalJSON method.
+		UnmarshalJSON []struct {
+			// Data is the data argument value.
+			Data []byte
+		}
 		// UpdateUtxos holds details about calls to the UpdateUtxos method.
 		UpdateUtxos []struct {
 			// Transactions is the transactions argument value.
@@ -83,11 +127,15 @@ type UtxosManagerMock struct {
 			Address string
 		}
 	}
-	lockCalculateFee sync.RWMutex
-	lockClear        sync.RWMutex
-	lockCopy         sync.RWMutex
-	lockUpdateUtxos  sync.RWMutex
-	lockUtxos        sync.RWMutex
+	lockCalculateFee  sync.RWMutex
+	lockClear         sync.RWMutex
+	lockCopy          sync.RWMutex
+	lockMarshalJSON   sync.RWMutex
+	lockRevertUtxos   sync.RWMutex
+	lockSpentUtxos    sync.RWMutex
+	lockUnmarshalJSON sync.RWMutex
+	lockUpdateUtxos   sync.RWMutex
+	lockUtxos         sync.RWMutex
 }
 
 // CalculateFee calls CalculateFeeFunc.
@@ -180,6 +228,133 @@ func (mock *UtxosManagerMock) CopyCalls() []struct {
 	return calls
 }
 
+// MarshalJSON calls MarshalJSONFunc.
+func (mock *UtxosManagerMock) MarshalJSON() ([]byte, error) {
+	if mock.MarshalJSONFunc == nil {
+		panic("UtxosManagerMock.MarshalJSONFunc: method is nil but UtxosManager.MarshalJSON was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockMarshalJSON.Lock()
+	mock.calls.MarshalJSON = append(mock.calls.MarshalJSON, callInfo)
+	mock.lockMarshalJSON.Unlock()
+	return mock.MarshalJSONFunc()
+}
+
+// MarshalJSONCalls gets all the calls that were made to MarshalJSON.
+// Check the length with:
+//
+//	len(mockedUtxosManager.MarshalJSONCalls())
+func (mock *UtxosManagerMock) MarshalJSONCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockMarshalJSON.RLock()
+	calls = mock.calls.MarshalJSON
+	mock.lockMarshalJSON.RUnlock()
+	return calls
+}
+
+// RevertUtxos calls RevertUtxosFunc.
+func (mock *UtxosManagerMock) RevertUtxos(transactions []*ledger.Transaction, spentUtxos []*ledger.Utxo) error {
+	if mock.RevertUtxosFunc == nil {
+		panic("UtxosManagerMock.RevertUtxosFunc: method is nil but UtxosManager.RevertUtxos was just called")
+	}
+	callInfo := struct {
+		Transactions []*ledger.Transaction
+		SpentUtxos   []*ledger.Utxo
+	}{
+		Transactions: transactions,
+		SpentUtxos:   spentUtxos,
+	}
+	mock.lockRevertUtxos.Lock()
+	mock.calls.RevertUtxos = append(mock.calls.RevertUtxos, callInfo)
+	mock.lockRevertUtxos.Unlock()
+	return mock.RevertUtxosFunc(transactions, spentUtxos)
+}
+
+// RevertUtxosCalls gets all the calls that were made to RevertUtxos.
+// Check the length with:
+//
+//	len(mockedUtxosManager.RevertUtxosCalls())
+func (mock *UtxosManagerMock) RevertUtxosCalls() []struct {
+	Transactions []*ledger.Transaction
+	SpentUtxos   []*ledger.Utxo
+} {
+	var calls []struct {
+		Transactions []*ledger.Transaction
+		SpentUtxos   []*ledger.Utxo
+	}
+	mock.lockRevertUtxos.RLock()
+	calls = mock.calls.RevertUtxos
+	mock.lockRevertUtxos.RUnlock()
+	return calls
+}
+
+// SpentUtxos calls SpentUtxosFunc.
+func (mock *UtxosManagerMock) SpentUtxos(transactions []*ledger.Transaction) []*ledger.Utxo {
+	if mock.SpentUtxosFunc == nil {
+		panic("UtxosManagerMock.SpentUtxosFunc: method is nil but UtxosManager.SpentUtxos was just called")
+	}
+	callInfo := struct {
+		Transactions []*ledger.Transaction
+	}{
+		Transactions: transactions,
+	}
+	mock.lockSpentUtxos.Lock()
+	mock.calls.SpentUtxos = append(mock.calls.SpentUtxos, callInfo)
+	mock.lockSpentUtxos.Unlock()
+	return mock.SpentUtxosFunc(transactions)
+}
+
+// SpentUtxosCalls gets all the calls that were made to SpentUtxos.
+// Check the length with:
+//
+//	len(mockedUtxosManager.SpentUtxosCalls())
+func (mock *UtxosManagerMock) SpentUtxosCalls() []struct {
+	Transactions []*ledger.Transaction
+} {
+	var calls []struct {
+		Transactions []*ledger.Transaction
+	}
+	mock.lockSpentUtxos.RLock()
+	calls = mock.calls.SpentUtxos
+	mock.lockSpentUtxos.RUnlock()
+	return calls
+}
+
+// UnmarshalJSON calls UnmarshalJSONFunc.
+func (mock *UtxosManagerMock) UnmarshalJSON(data []byte) error {
+	if mock.UnmarshalJSONFunc == nil {
+		panic("UtxosManagerMock.UnmarshalJSONFunc: method is nil but UtxosManager.UnmarshalJSON was just called")
+	}
+	callInfo := struct {
+		Data []byte
+	}{
+		Data: data,
+	}
+	mock.lockUnmarshalJSON.Lock()
+	mock.calls.UnmarshalJSON = append(mock.calls.UnmarshalJSON, callInfo)
+	mock.lockUnmarshalJSON.Unlock()
+	return mock.UnmarshalJSONFunc(data)
+}
+
+// UnmarshalJSONCalls gets all the calls that were made to UnmarshalJSON.
+// Check the length with:
+//
+//	len(mockedUtxosManager.UnmarshalJSONCalls())
+func (mock *UtxosManagerMock) UnmarshalJSONCalls() []struct {
+	Data []byte
+} {
+	var calls []struct {
+		Data []byte
+	}
+	mock.lockUnmarshalJSON.RLock()
+	calls = mock.calls.UnmarshalJSON
+	mock.lockUnmarshalJSON.RUnlock()
+	return calls
+}
+
 // UpdateUtxos calls UpdateUtxosFunc.
 func (mock *UtxosManagerMock) UpdateUtxos(transactions []*ledger.Transaction, timestamp int64) error {
 	if mock.UpdateUtxosFunc == nil {
@@ -0,0 +1,18 @@
+package validation
+
+type transactionRejection struct {
+	reasonCode string
+	err        error
+}
+
+func newTransactionRejection(reasonCode string, err error) *transactionRejection {
+	return &transactionRejection{reasonCode, err}
+}
+
+func (rejection *transactionRejection) Error() string {
+	return rejection.err.Error()
+}
+
+func (rejection *transactionRejection) Unwrap() error {
+	return rejection.err
+}
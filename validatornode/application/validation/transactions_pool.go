@@ -1,68 +1,119 @@
 package validation
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/my-cloud/ruthenium/validatornode/application"
-	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/my-cloud/ruthenium/validatornode/domain/encryption"
 	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 )
 
 type TransactionsPool struct {
-	transactions []*ledger.Transaction
-	mutex        sync.RWMutex
+	transactions            []*ledger.Transaction
+	feeRatesById            map[string]float64
+	feesById                map[string]uint64
+	transactionsByInputInfo map[ledger.InputInfo]*ledger.Transaction
+	mutex                   sync.RWMutex
 
-	blocksManager    application.BlocksManager
-	settings         application.ProtocolSettingsProvider
-	sendersManager   application.SendersManager
-	utxosManager     application.UtxosManager
-	validatorAddress string
+	blocksManager           application.BlocksManager
+	settings                application.ProtocolSettingsProvider
+	sendersManager          application.SendersManager
+	transactionsRelayer     application.TransactionsRelayer
+	transactionsStorage     application.TransactionsStorage
+	utxosManager            application.UtxosManager
+	maxTransactionsCount    int
+	maxTransactionsPerBlock int
+	privateKey              *encryption.PrivateKey
+	validatorAddress        string
 
 	logger log.Logger
 }
 
-func NewTransactionsPool(blocksManager application.BlocksManager, settings application.ProtocolSettingsProvider, sendersManager application.SendersManager, utxosManager application.UtxosManager, validatorAddress string, logger log.Logger) *TransactionsPool {
+func NewTransactionsPool(blocksManager application.BlocksManager, settings application.ProtocolSettingsProvider, sendersManager application.SendersManager, transactionsRelayer application.TransactionsRelayer, transactionsStorage application.TransactionsStorage, utxosManager application.UtxosManager, maxTransactionsCount int, maxTransactionsPerBlock int, privateKey *encryption.PrivateKey, logger log.Logger) *TransactionsPool {
 	pool := new(TransactionsPool)
+	pool.feeRatesById = make(map[string]float64)
+	pool.feesById = make(map[string]uint64)
+	pool.transactionsByInputInfo = make(map[ledger.InputInfo]*ledger.Transaction)
 	pool.blocksManager = blocksManager
 	pool.settings = settings
 	pool.sendersManager = sendersManager
+	pool.transactionsRelayer = transactionsRelayer
+	pool.transactionsStorage = transactionsStorage
 	pool.utxosManager = utxosManager
-	pool.validatorAddress = validatorAddress
+	pool.maxTransactionsCount = maxTransactionsCount
+	pool.maxTransactionsPerBlock = maxTransactionsPerBlock
+	pool.privateKey = privateKey
+	pool.validatorAddress = encryption.NewPublicKey(privateKey).Address()
 	pool.logger = logger
 	return pool
 }
 
-func (pool *TransactionsPool) AddTransaction(transaction *ledger.Transaction, broadcasterTarget string, hostTarget string) {
-	err := pool.addTransaction(transaction)
+func (pool *TransactionsPool) AddTransaction(transaction *ledger.Transaction, broadcasterTarget string) *ledger.TransactionResult {
+	err := pool.addTransaction(transaction, true, false)
 	if err != nil {
-		pool.logger.Debug(fmt.Errorf("failed to add transaction: %w", err).Error())
-		return
+		pool.logger.With(log.TransactionIdKey, transaction.Id()).With(log.ErrorKey, err).Debug(fmt.Errorf("failed to add transaction: %w", err).Error())
+		reasonCode := ledger.InternalErrorReasonCode
+		var rejection *transactionRejection
+		if errors.As(err, &rejection) {
+			reasonCode = rejection.reasonCode
+		}
+		return ledger.NewRejectedTransactionResult(reasonCode, err.Error())
 	}
 	pool.sendersManager.Incentive(broadcasterTarget)
-	newTransactionRequest := ledger.NewTransactionRequest(transaction, hostTarget)
-	marshaledTransactionRequest, err := json.Marshal(newTransactionRequest)
+	pool.transactionsRelayer.AnnounceTransaction(transaction.Id())
+	return ledger.NewAcceptedTransactionResult()
+}
+
+func (pool *TransactionsPool) Load() error {
+	transactions, err := pool.transactionsStorage.Transactions()
 	if err != nil {
-		pool.logger.Debug(fmt.Errorf("failed to marshal transaction request: %w", err).Error())
-		return
+		return fmt.Errorf("failed to load stored transactions: %w", err)
 	}
-	senders := pool.sendersManager.Senders()
-	for _, sender := range senders {
-		go func(sender application.Sender) {
-			_ = sender.AddTransaction(marshaledTransactionRequest)
-		}(sender)
+	// The transactions are stored by decreasing fee rate, a parent is added before its children so that its outputs are spendable
+	for _, transaction := range parentsFirst(transactions) {
+		if err = pool.addTransaction(transaction, false, false); err != nil {
+			pool.logger.Warn(fmt.Errorf("stored transaction discarded, transaction: %v\n %w", transaction, err).Error())
+		}
+	}
+	// The journal is rewritten once all the stored transactions are validated, so that it is not lost if the node stops while loading
+	if err = pool.transactionsStorage.SaveTransactions(pool.transactions); err != nil {
+		return fmt.Errorf("failed to store validated transactions: %w", err)
+	}
+	pool.logger.Info(fmt.Sprintf("stored transactions loaded: %d transactions, %d discarded", len(pool.transactions), len(transactions)-len(pool.transactions)))
+	return nil
+}
+
+func (pool *TransactionsPool) PendingTransactions(transactionIds []string) []*ledger.Transaction {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+	var transactions []*ledger.Transaction
+	for _, transactionId := range transactionIds {
+		if _, isPending := pool.feesById[transactionId]; !isPending {
+			continue
+		}
+		for _, transaction := range pool.transactions {
+			if transaction.Id() == transactionId {
+				transactions = append(transactions, transaction)
+				break
+			}
+		}
 	}
+	return transactions
 }
 
 func (pool *TransactionsPool) Transactions() []*ledger.Transaction {
 	return pool.transactions
 }
 
-func (pool *TransactionsPool) Validate(timestamp int64) {
+func (pool *TransactionsPool) Validate(ctx context.Context, timestamp int64) error {
+	pool.restoreAbandonedTransactions()
 	lastBlockTimestamp := pool.blocksManager.LastBlockTimestamp()
 	nextBlockTimestamp := lastBlockTimestamp + pool.settings.ValidationTimestamp()
 	var reward uint64
@@ -73,58 +124,64 @@ func (pool *TransactionsPool) Validate(timestamp int64) {
 		newAddresses = []string{pool.validatorAddress}
 		isYielding = true
 	} else if lastBlockTimestamp == timestamp {
-		pool.logger.Error("unable to create block, a block with the same timestamp is already in the blockchain")
-		return
+		return errors.New("unable to create block, a block with the same timestamp is already in the blockchain")
 	} else if timestamp > nextBlockTimestamp {
-		pool.logger.Error("unable to create block, a block is missing in the blockchain")
-		return
+		return errors.New("unable to create block, a block is missing in the blockchain")
 	}
 	lastBlockTransactions := pool.blocksManager.LastBlockTransactions()
 	utxosManagerCopy := pool.utxosManager.Copy()
 	if err := utxosManagerCopy.UpdateUtxos(lastBlockTransactions, nextBlockTimestamp); err != nil {
-		pool.logger.Error(fmt.Errorf("failed to update UTXOs: %w", err).Error())
-		return
+		return fmt.Errorf("failed to update UTXOs: %w", err)
 	}
 	pool.mutex.Lock()
 	defer pool.mutex.Unlock()
-	transactions := pool.transactions
-	rand.Seed(timestamp)
-	rand.Shuffle(len(transactions), func(i, j int) {
-		transactions[i], transactions[j] = transactions[j], transactions[i]
-	})
+	// The pool transactions are sorted by decreasing fee rate, a transaction spending the output of a pending one is validated right after it
+	pendingIds := map[string]bool{}
+	for _, transaction := range pool.transactions {
+		pendingIds[transaction.Id()] = true
+	}
+	childrenByParentId := map[string][]*ledger.Transaction{}
+	var transactions []*ledger.Transaction
 	var rejectedTransactions []*ledger.Transaction
-	for _, transaction := range transactions {
-		if timestamp < transaction.Timestamp() {
-			pool.logger.Warn(fmt.Sprintf("transaction removed from the transactions pool, the transaction timestamp is too far in the future, transaction: %v", transaction))
-			rejectedTransactions = append(rejectedTransactions, transaction)
-			continue
+	for _, transaction := range pool.transactions {
+		if pool.maxTransactionsPerBlock > 0 && len(transactions) == pool.maxTransactionsPerBlock {
+			break
 		}
-		if transaction.Timestamp() < lastBlockTimestamp {
-			pool.logger.Warn(fmt.Sprintf("transaction removed from the transactions pool, the transaction timestamp is too old, transaction: %v", transaction))
-			rejectedTransactions = append(rejectedTransactions, transaction)
+		if parentId, hasPendingParent := pendingParentId(transaction, pendingIds); hasPendingParent {
+			childrenByParentId[parentId] = append(childrenByParentId[parentId], transaction)
 			continue
 		}
-		if err := transaction.VerifySignatures(); err != nil {
-			pool.logger.Warn(fmt.Errorf("transaction removed from the transactions pool, failed to verify signature, transaction: %v\n %w", transaction, err).Error())
-			rejectedTransactions = append(rejectedTransactions, transaction)
-			continue
-		}
-		fee, err := utxosManagerCopy.CalculateFee(transaction, timestamp)
-		if err != nil {
-			pool.logger.Warn(fmt.Errorf("transaction removed from the transactions pool, failed to calculate fee, transaction: %v\n %w", transaction, err).Error())
-			rejectedTransactions = append(rejectedTransactions, transaction)
-			continue
-		}
-		if err = utxosManagerCopy.UpdateUtxos([]*ledger.Transaction{transaction}, nextBlockTimestamp); err != nil {
-			pool.logger.Warn(fmt.Errorf("transaction removed from the transactions pool, failed to update UTXOs, transaction: %v\n %w", transaction, err).Error())
-			rejectedTransactions = append(rejectedTransactions, transaction)
-			continue
+		queue := []*ledger.Transaction{transaction}
+		for len(queue) > 0 {
+			if pool.maxTransactionsPerBlock > 0 && len(transactions) == pool.maxTransactionsPerBlock {
+				break
+			}
+			transaction = queue[0]
+			queue = queue[1:]
+			fee, err := pool.validateTransaction(transaction, utxosManagerCopy, timestamp, lastBlockTimestamp, nextBlockTimestamp)
+			if err != nil {
+				pool.logger.With(log.TransactionIdKey, transaction.Id()).With(log.ErrorKey, err).Warn(fmt.Errorf("transaction removed from the transactions pool, %w, transaction: %v", err, transaction).Error())
+				rejectedTransactions = append(rejectedTransactions, transaction)
+				continue
+			}
+			reward += fee
+			transactions = append(transactions, transaction)
+			delete(pendingIds, transaction.Id())
+			for _, child := range childrenByParentId[transaction.Id()] {
+				if parentId, hasPendingParent := pendingParentId(child, pendingIds); hasPendingParent {
+					childrenByParentId[parentId] = append(childrenByParentId[parentId], child)
+				} else {
+					queue = append(queue, child)
+				}
+			}
+			delete(childrenByParentId, transaction.Id())
 		}
-		reward += fee
 	}
-	for _, transaction := range rejectedTransactions {
-		transactions = removeTransaction(transactions, transaction)
+	// The descendants of a rejected transaction would spend missing outputs
+	for _, transaction := range pool.withDescendants(rejectedTransactions) {
+		pool.removeTransaction(transaction)
 	}
+	defer pool.saveTransactions()
 	for _, transaction := range transactions {
 		for _, output := range transaction.Outputs() {
 			if output.IsYielding() {
@@ -134,69 +191,251 @@ func (pool *TransactionsPool) Validate(timestamp int64) {
 	}
 	rewardTransaction, err := ledger.NewRewardTransaction(pool.validatorAddress, isYielding, timestamp, reward)
 	if err != nil {
-		pool.logger.Error(fmt.Errorf("unable to create block, failed to create reward transaction: %w", err).Error())
-		return
+		return fmt.Errorf("unable to create block, failed to create reward transaction: %w", err)
+	}
+	// A block added after the deadline would be rejected by the neighbors
+	if err = ctx.Err(); err != nil {
+		return fmt.Errorf("unable to create block: %w", err)
 	}
-	transactions = append(transactions, rewardTransaction)
-	err = pool.blocksManager.AddBlock(timestamp, transactions, newAddresses)
+	err = pool.blocksManager.AddBlock(timestamp, append(transactions, rewardTransaction), newAddresses, pool.privateKey)
 	if err != nil {
-		pool.logger.Error(fmt.Errorf("unable to create block: %w", err).Error())
-		return
+		return fmt.Errorf("unable to create block: %w", err)
+	}
+	for _, transaction := range transactions {
+		pool.removeTransaction(transaction)
 	}
-	pool.clear()
 	pool.logger.Debug(fmt.Sprintf("reward: %d", reward))
+	return nil
 }
 
-func (pool *TransactionsPool) addTransaction(transaction *ledger.Transaction) error {
+func (pool *TransactionsPool) validateTransaction(transaction *ledger.Transaction, utxosManager application.UtxosManager, timestamp int64, lastBlockTimestamp int64, nextBlockTimestamp int64) (uint64, error) {
+	if timestamp < transaction.Timestamp() {
+		return 0, errors.New("the transaction timestamp is too far in the future")
+	}
+	if transaction.Timestamp() < lastBlockTimestamp-pool.settings.ValidationTimestamp() {
+		return 0, errors.New("the transaction timestamp is too old")
+	}
+	if err := transaction.VerifySignatures(); err != nil {
+		return 0, fmt.Errorf("failed to verify signature: %w", err)
+	}
+	fee, err := utxosManager.CalculateFee(transaction, timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate fee: %w", err)
+	}
+	if err = utxosManager.UpdateUtxos([]*ledger.Transaction{transaction}, nextBlockTimestamp); err != nil {
+		return 0, fmt.Errorf("failed to update UTXOs: %w", err)
+	}
+	return fee, nil
+}
+
+func (pool *TransactionsPool) addTransaction(transaction *ledger.Transaction, isJournaled bool, isAbandoned bool) error {
 	lastBlockTimestamp := pool.blocksManager.LastBlockTimestamp()
 	if lastBlockTimestamp == 0 {
-		return errors.New("the blockchain is empty")
+		return newTransactionRejection(ledger.BlockchainIsEmptyReasonCode, errors.New("the blockchain is empty"))
 	}
 	nextBlockTimestamp := lastBlockTimestamp + pool.settings.ValidationTimestamp()
 	timestamp := transaction.Timestamp()
 	if nextBlockTimestamp < timestamp {
-		return fmt.Errorf("the transaction timestamp is too far in the future: %v, now: %v", time.Unix(0, timestamp), time.Unix(0, nextBlockTimestamp))
+		return newTransactionRejection(ledger.TimestampIsInTheFutureReasonCode, fmt.Errorf("the transaction timestamp is too far in the future: %v, now: %v", time.Unix(0, timestamp), time.Unix(0, nextBlockTimestamp)))
+	}
+	// An abandoned transaction has already been validated, it is kept as long as the next block can include it
+	oldestTimestamp := lastBlockTimestamp
+	if isAbandoned {
+		oldestTimestamp -= pool.settings.ValidationTimestamp()
+	}
+	if timestamp < oldestTimestamp {
+		return newTransactionRejection(ledger.TimestampIsTooOldReasonCode, fmt.Errorf("the transaction timestamp is too old: %v, oldest timestamp: %v", time.Unix(0, timestamp), time.Unix(0, oldestTimestamp)))
 	}
-	currentBlockTimestamp := lastBlockTimestamp
-	if timestamp < currentBlockTimestamp {
-		return fmt.Errorf("the transaction timestamp is too old: %v, current block timestamp: %v", time.Unix(0, timestamp), time.Unix(0, currentBlockTimestamp))
+	if err := transaction.VerifySignatures(); err != nil {
+		return newTransactionRejection(ledger.InvalidSignatureReasonCode, fmt.Errorf("failed to verify signature: %w", err))
 	}
+	// The lock is held from the conflicts check to the insertion, otherwise concurrent transactions spending the same UTXO could both be added
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
 	for _, pendingTransaction := range pool.transactions {
 		if transaction.Equals(pendingTransaction) {
-			return errors.New("the transaction is already in the transactions pool")
+			return newTransactionRejection(ledger.AlreadyPendingReasonCode, errors.New("the transaction is already in the transactions pool"))
 		}
 	}
-	if err := transaction.VerifySignatures(); err != nil {
-		return fmt.Errorf("failed to verify signature: %w", err)
+	// The descendants of the conflicting transactions are replaced along with them since they would spend missing outputs
+	conflictingTransactions := pool.withDescendants(pool.conflictingTransactions(transaction))
+	var pendingTransactions []*ledger.Transaction
+	for _, pendingTransaction := range pool.transactions {
+		if !containsTransaction(conflictingTransactions, pendingTransaction) {
+			pendingTransactions = append(pendingTransactions, pendingTransaction)
+		}
+	}
+	var conflictingTransactionsFee uint64
+	for _, conflictingTransaction := range conflictingTransactions {
+		conflictingTransactionsFee += pool.feesById[conflictingTransaction.Id()]
 	}
 	utxoManagerCopy := pool.utxosManager.Copy()
 	lastBlockTransactions := pool.blocksManager.LastBlockTransactions()
 	if err := utxoManagerCopy.UpdateUtxos(lastBlockTransactions, nextBlockTimestamp); err != nil {
 		return fmt.Errorf("failed to update UTXOs: %w", err)
 	}
-	if err := utxoManagerCopy.UpdateUtxos(pool.transactions, nextBlockTimestamp); err != nil {
+	if err := utxoManagerCopy.UpdateUtxos(parentsFirst(pendingTransactions), nextBlockTimestamp); err != nil {
 		return fmt.Errorf("failed to update UTXOs: %w", err)
 	}
-	_, err := utxoManagerCopy.CalculateFee(transaction, nextBlockTimestamp)
+	fee, err := utxoManagerCopy.CalculateFee(transaction, nextBlockTimestamp)
 	if err != nil {
-		return fmt.Errorf("failed to verify fee: %w", err)
+		return newTransactionRejection(ledger.InvalidFeeReasonCode, fmt.Errorf("failed to verify fee: %w", err))
+	}
+	if len(conflictingTransactions) > 0 && fee <= conflictingTransactionsFee {
+		return newTransactionRejection(ledger.DoubleSpendReasonCode, fmt.Errorf("the transaction inputs are already spent by pending transactions and its fee is not higher than theirs: fee: %d, pending transactions fee: %d", fee, conflictingTransactionsFee))
+	}
+	marshaledTransaction, err := json.Marshal(transaction)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction: %w", err)
+	}
+	feeRate := float64(fee) / float64(len(marshaledTransaction))
+	for _, conflictingTransaction := range conflictingTransactions {
+		pool.removeTransaction(conflictingTransaction)
+		pool.logger.With(log.TransactionIdKey, conflictingTransaction.Id()).Debug(fmt.Sprintf("transaction replaced in the transactions pool, transaction: %v", conflictingTransaction))
+	}
+	if len(conflictingTransactions) > 0 && isJournaled {
+		pool.saveTransactions()
+	}
+	if pool.maxTransactionsCount > 0 && len(pool.transactions) >= pool.maxTransactionsCount {
+		lowestFeeRateTransaction := pool.transactions[len(pool.transactions)-1]
+		if feeRate <= pool.feeRatesById[lowestFeeRateTransaction.Id()] {
+			return newTransactionRejection(ledger.PoolIsFullReasonCode, errors.New("the transactions pool is full and the transaction fee rate is too low"))
+		}
+		evictedTransactions := pool.withDescendants([]*ledger.Transaction{lowestFeeRateTransaction})
+		evictedIds := map[string]bool{}
+		for _, evictedTransaction := range evictedTransactions {
+			evictedIds[evictedTransaction.Id()] = true
+		}
+		if _, spendsEvictedOutput := pendingParentId(transaction, evictedIds); spendsEvictedOutput {
+			return newTransactionRejection(ledger.PoolIsFullReasonCode, errors.New("the transactions pool is full and the transaction spends an output of the lowest fee rate one"))
+		}
+		for _, evictedTransaction := range evictedTransactions {
+			pool.removeTransaction(evictedTransaction)
+			pool.logger.With(log.TransactionIdKey, evictedTransaction.Id()).Debug(fmt.Sprintf("transaction evicted from the full transactions pool, transaction: %v", evictedTransaction))
+		}
+		if isJournaled {
+			pool.saveTransactions()
+		}
+	}
+	pool.feeRatesById[transaction.Id()] = feeRate
+	pool.feesById[transaction.Id()] = fee
+	for _, input := range transaction.Inputs() {
+		pool.transactionsByInputInfo[*input.InputInfo] = transaction
 	}
-	pool.mutex.Lock()
-	defer pool.mutex.Unlock()
 	pool.transactions = append(pool.transactions, transaction)
+	sort.SliceStable(pool.transactions, func(i, j int) bool {
+		return pool.feeRatesById[pool.transactions[i].Id()] > pool.feeRatesById[pool.transactions[j].Id()]
+	})
+	if !isJournaled {
+		return nil
+	}
+	if err = pool.transactionsStorage.AddTransaction(transaction); err != nil {
+		pool.logger.Error(fmt.Errorf("failed to store transaction: %w", err).Error())
+	}
 	return nil
 }
 
-func (pool *TransactionsPool) clear() {
-	pool.transactions = nil
+func (pool *TransactionsPool) conflictingTransactions(transaction *ledger.Transaction) []*ledger.Transaction {
+	var conflictingTransactions []*ledger.Transaction
+	for _, input := range transaction.Inputs() {
+		pendingTransaction, ok := pool.transactionsByInputInfo[*input.InputInfo]
+		if ok && !containsTransaction(conflictingTransactions, pendingTransaction) {
+			conflictingTransactions = append(conflictingTransactions, pendingTransaction)
+		}
+	}
+	return conflictingTransactions
+}
+
+func (pool *TransactionsPool) restoreAbandonedTransactions() {
+	for _, transaction := range pool.blocksManager.AbandonedTransactions() {
+		if err := pool.addTransaction(transaction, true, true); err != nil {
+			pool.logger.With(log.TransactionIdKey, transaction.Id()).With(log.ErrorKey, err).Warn(fmt.Errorf("abandoned transaction dropped, transaction: %v\n %w", transaction, err).Error())
+		}
+	}
+}
+
+func (pool *TransactionsPool) saveTransactions() {
+	if err := pool.transactionsStorage.SaveTransactions(pool.transactions); err != nil {
+		pool.logger.Error(fmt.Errorf("failed to store transactions: %w", err).Error())
+	}
+}
+
+func (pool *TransactionsPool) removeTransaction(removedTransaction *ledger.Transaction) {
+	for i := 0; i < len(pool.transactions); i++ {
+		if pool.transactions[i] == removedTransaction {
+			pool.transactions = append(pool.transactions[:i], pool.transactions[i+1:]...)
+			delete(pool.feeRatesById, removedTransaction.Id())
+			delete(pool.feesById, removedTransaction.Id())
+			for _, input := range removedTransaction.Inputs() {
+				if pool.transactionsByInputInfo[*input.InputInfo] == removedTransaction {
+					delete(pool.transactionsByInputInfo, *input.InputInfo)
+				}
+			}
+			return
+		}
+	}
 }
 
-func removeTransaction(transactions []*ledger.Transaction, removedTransaction *ledger.Transaction) []*ledger.Transaction {
-	for i := 0; i < len(transactions); i++ {
-		if transactions[i] == removedTransaction {
-			transactions = append(transactions[:i], transactions[i+1:]...)
-			return transactions
+func (pool *TransactionsPool) withDescendants(transactions []*ledger.Transaction) []*ledger.Transaction {
+	transactions = append([]*ledger.Transaction{}, transactions...)
+	ids := map[string]bool{}
+	for _, transaction := range transactions {
+		ids[transaction.Id()] = true
+	}
+	for isDescendantFound := true; isDescendantFound; {
+		isDescendantFound = false
+		for _, pendingTransaction := range pool.transactions {
+			if ids[pendingTransaction.Id()] {
+				continue
+			}
+			if _, hasParent := pendingParentId(pendingTransaction, ids); hasParent {
+				ids[pendingTransaction.Id()] = true
+				transactions = append(transactions, pendingTransaction)
+				isDescendantFound = true
+			}
 		}
 	}
 	return transactions
 }
+
+func containsTransaction(transactions []*ledger.Transaction, searchedTransaction *ledger.Transaction) bool {
+	for _, transaction := range transactions {
+		if transaction == searchedTransaction {
+			return true
+		}
+	}
+	return false
+}
+
+func pendingParentId(transaction *ledger.Transaction, pendingIds map[string]bool) (string, bool) {
+	for _, input := range transaction.Inputs() {
+		if pendingIds[input.TransactionId()] {
+			return input.TransactionId(), true
+		}
+	}
+	return "", false
+}
+
+func parentsFirst(transactions []*ledger.Transaction) []*ledger.Transaction {
+	pendingIds := map[string]bool{}
+	for _, transaction := range transactions {
+		pendingIds[transaction.Id()] = true
+	}
+	orderedTransactions := make([]*ledger.Transaction, 0, len(transactions))
+	for len(transactions) > 0 {
+		var remainingTransactions []*ledger.Transaction
+		for _, transaction := range transactions {
+			if _, hasPendingParent := pendingParentId(transaction, pendingIds); hasPendingParent {
+				remainingTransactions = append(remainingTransactions, transaction)
+			} else {
+				orderedTransactions = append(orderedTransactions, transaction)
+				delete(pendingIds, transaction.Id())
+			}
+		}
+		if len(remainingTransactions) == len(transactions) {
+			return append(orderedTransactions, remainingTransactions...)
+		}
+		transactions = remainingTransactions
+	}
+	return orderedTransactions
+}
@@ -1,8 +1,11 @@
 package validation
 
 import (
+	"context"
 	"fmt"
 	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/application/verification"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,9 +17,7 @@ import (
 
 func Test_AddTransaction_TransactionTimestampIsInTheFuture_TransactionNotAdded(t *testing.T) {
 	// Arrange
-	validatorWalletAddress := test.Address
 	sendersManagerMock := new(application.SendersManagerMock)
-	sendersManagerMock.SendersFunc = func() []application.Sender { return nil }
 	sendersManagerMock.IncentiveFunc = func(string) {}
 	watchMock := new(application.TimeProviderMock)
 	var now int64 = 2
@@ -28,16 +29,21 @@ func Test_AddTransaction_TransactionTimestampIsInTheFuture_TransactionNotAdded(t
 	blocksManagerMock := new(application.BlocksManagerMock)
 	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
 	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
-	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string) error { return nil }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
 	utxosManagerMock := new(application.UtxosManagerMock)
-	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, utxosManagerMock, validatorWalletAddress, logger)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
 	var genesisValue uint64 = 0
 	transaction := ledger.NewSignedTransaction(genesisValue, transactionFee, 0, "A", privateKey, publicKey, now+2, "0", genesisValue, false)
 
 	// Act
-	pool.AddTransaction(transaction, "0", "0")
+	pool.AddTransaction(transaction, "0")
 
 	// Assert
 	expectedTransactionsLength := 0
@@ -48,9 +54,7 @@ func Test_AddTransaction_TransactionTimestampIsInTheFuture_TransactionNotAdded(t
 
 func Test_AddTransaction_TransactionTimestampIsTooOld_TransactionNotAdded(t *testing.T) {
 	// Arrange
-	validatorWalletAddress := test.Address
 	sendersManagerMock := new(application.SendersManagerMock)
-	sendersManagerMock.SendersFunc = func() []application.Sender { return nil }
 	sendersManagerMock.IncentiveFunc = func(string) {}
 	var now int64 = 2
 	logger := log.NewLoggerMock()
@@ -60,28 +64,36 @@ func Test_AddTransaction_TransactionTimestampIsTooOld_TransactionNotAdded(t *tes
 	blocksManagerMock := new(application.BlocksManagerMock)
 	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
 	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
-	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string) error { return nil }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
 	utxosManagerMock := new(application.UtxosManagerMock)
-	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, utxosManagerMock, validatorWalletAddress, logger)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
 	var genesisValue uint64 = 0
 	transaction := ledger.NewSignedTransaction(genesisValue, transactionFee, 0, "A", privateKey, publicKey, now-2, "0", genesisValue, false)
 
 	// Act
-	pool.AddTransaction(transaction, "0", "0")
+	result := pool.AddTransaction(transaction, "0")
 
 	// Assert
 	expectedTransactionsLength := 0
 	actualTransactionsLength := len(pool.Transactions())
 	test.Assert(t, actualTransactionsLength == expectedTransactionsLength, fmt.Sprintf("Wrong transactions count. Expected: %d - Actual: %d", expectedTransactionsLength, actualTransactionsLength))
+	test.Assert(t, !result.IsAccepted(), "Transaction is accepted whereas it should not.")
+	expectedReasonCode := ledger.TimestampIsTooOldReasonCode
+	actualReasonCode := result.ReasonCode()
+	test.Assert(t, actualReasonCode == expectedReasonCode, fmt.Sprintf("Wrong reason code. Expected: %s - Actual: %s", expectedReasonCode, actualReasonCode))
 	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), "failed to add transaction: the transaction timestamp is too old")
 }
 
 func Test_AddTransaction_InvalidSignature_TransactionNotAdded(t *testing.T) {
 	// Arrange
 	sendersManagerMock := new(application.SendersManagerMock)
-	sendersManagerMock.SendersFunc = func() []application.Sender { return nil }
 	sendersManagerMock.IncentiveFunc = func(string) {}
 	var now int64 = 2
 	transactionFee := 0
@@ -89,22 +101,26 @@ func Test_AddTransaction_InvalidSignature_TransactionNotAdded(t *testing.T) {
 	blocksManagerMock := new(application.BlocksManagerMock)
 	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
 	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
-	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string) error { return nil }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
 	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
 	publicKey := encryption.NewPublicKey(privateKey)
-	walletAddress := publicKey.Address()
 	var outputIndex uint16 = 0
 	transactionId := ""
 	privateKey2, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey2)
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
 	utxosManagerMock := new(application.UtxosManagerMock)
-	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, utxosManagerMock, walletAddress, logger)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
 	var genesisValue uint64 = 0
 	transaction := ledger.NewSignedTransaction(genesisValue, transactionFee, outputIndex, "A", privateKey2, publicKey, now, transactionId, genesisValue, false)
 
 	// Act
-	pool.AddTransaction(transaction, "0", "0")
+	pool.AddTransaction(transaction, "0")
 
 	// Assert
 	expectedTransactionsLength := 0
@@ -115,10 +131,7 @@ func Test_AddTransaction_InvalidSignature_TransactionNotAdded(t *testing.T) {
 
 func Test_AddTransaction_ValidTransaction_TransactionAdded(t *testing.T) {
 	// Arrange
-	senderMock := new(application.SenderMock)
-	senderMock.AddTransactionFunc = func([]byte) error { return nil }
 	sendersManagerMock := new(application.SendersManagerMock)
-	sendersManagerMock.SendersFunc = func() []application.Sender { return []application.Sender{senderMock} }
 	sendersManagerMock.IncentiveFunc = func(string) {}
 	var now int64 = 2
 	transactionFee := 0
@@ -126,9 +139,14 @@ func Test_AddTransaction_ValidTransaction_TransactionAdded(t *testing.T) {
 	blocksManagerMock := new(application.BlocksManagerMock)
 	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
 	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
-	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string) error { return nil }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
@@ -138,88 +156,543 @@ func Test_AddTransaction_ValidTransaction_TransactionAdded(t *testing.T) {
 	walletAddress := publicKey.Address()
 	var outputIndex uint16 = 0
 	transactionId := ""
-	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, utxosManagerMock, walletAddress, logger)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
 	var genesisValue uint64 = 0
 	transaction := ledger.NewSignedTransaction(genesisValue, transactionFee, outputIndex, walletAddress, privateKey, publicKey, now, transactionId, genesisValue, false)
 
 	// Act
-	pool.AddTransaction(transaction, "0", "0")
+	pool.AddTransaction(transaction, "0")
 
 	// Assert
 	expectedTransactionsLength := 1
 	actualTransactionsLength := len(pool.Transactions())
 	test.Assert(t, actualTransactionsLength == expectedTransactionsLength, fmt.Sprintf("Wrong transactions count. Expected: %d - Actual: %d", expectedTransactionsLength, actualTransactionsLength))
+	isTransactionAnnounced := len(transactionsRelayerMock.AnnounceTransactionCalls()) == 1
+	test.Assert(t, isTransactionAnnounced, "Transaction is not announced whereas it should be.")
+}
+
+func Test_AddTransaction_TransactionsHaveDifferentFeeRates_TransactionsSortedByDecreasingFeeRate(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.IncentiveFunc = func(string) {}
+	var now int64 = 2
+	logger := log.NewLoggerMock()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	lowFeeTransaction := ledger.NewSignedTransaction(1, 1, 0, "A", privateKey, publicKey, now, "0", 2, false)
+	highFeeTransaction := ledger.NewSignedTransaction(1, 2, 0, "A", privateKey, publicKey, now, "1", 3, false)
+	feesById := map[string]uint64{lowFeeTransaction.Id(): 1, highFeeTransaction.Id(): 2}
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, _ int64) (uint64, error) { return feesById[transaction.Id()], nil }
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
+
+	// Act
+	pool.AddTransaction(lowFeeTransaction, "0")
+	pool.AddTransaction(highFeeTransaction, "0")
+
+	// Assert
+	transactions := pool.Transactions()
+	test.Assert(t, len(transactions) == 2, fmt.Sprintf("Wrong transactions count. Expected: 2 - Actual: %d", len(transactions)))
+	test.Assert(t, transactions[0] == highFeeTransaction, "The first transaction is not the one with the highest fee rate.")
+}
+
+func Test_AddTransaction_PoolIsFullAndFeeRateIsTooLow_TransactionNotAdded(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.IncentiveFunc = func(string) {}
+	var now int64 = 2
+	logger := log.NewLoggerMock()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	lowFeeTransaction := ledger.NewSignedTransaction(1, 1, 0, "A", privateKey, publicKey, now, "0", 2, false)
+	highFeeTransaction := ledger.NewSignedTransaction(1, 2, 0, "A", privateKey, publicKey, now, "1", 3, false)
+	feesById := map[string]uint64{lowFeeTransaction.Id(): 1, highFeeTransaction.Id(): 2}
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, _ int64) (uint64, error) { return feesById[transaction.Id()], nil }
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 1, 0, privateKey, logger)
+	pool.AddTransaction(highFeeTransaction, "0")
+
+	// Act
+	pool.AddTransaction(lowFeeTransaction, "0")
+
+	// Assert
+	transactions := pool.Transactions()
+	test.Assert(t, len(transactions) == 1 && transactions[0] == highFeeTransaction, "The pool transactions are not the expected ones.")
+	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), "failed to add transaction: the transactions pool is full and the transaction fee rate is too low")
+}
+
+func Test_AddTransaction_PoolIsFullAndFeeRateIsHigher_LowestFeeRateTransactionEvicted(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.IncentiveFunc = func(string) {}
+	var now int64 = 2
+	logger := log.NewLoggerMock()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	lowFeeTransaction := ledger.NewSignedTransaction(1, 1, 0, "A", privateKey, publicKey, now, "0", 2, false)
+	highFeeTransaction := ledger.NewSignedTransaction(1, 2, 0, "A", privateKey, publicKey, now, "1", 3, false)
+	feesById := map[string]uint64{lowFeeTransaction.Id(): 1, highFeeTransaction.Id(): 2}
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, _ int64) (uint64, error) { return feesById[transaction.Id()], nil }
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 1, 0, privateKey, logger)
+	pool.AddTransaction(lowFeeTransaction, "0")
+
+	// Act
+	pool.AddTransaction(highFeeTransaction, "0")
+
+	// Assert
+	transactions := pool.Transactions()
+	test.Assert(t, len(transactions) == 1 && transactions[0] == highFeeTransaction, "The lowest fee rate transaction is not evicted.")
+}
+
+func Test_AddTransaction_TransactionDoubleSpendsWithoutHigherFee_TransactionNotAdded(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.IncentiveFunc = func(string) {}
+	var now int64 = 2
+	logger := log.NewLoggerMock()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	pendingTransaction := ledger.NewSignedTransaction(1, 1, 0, "A", privateKey, publicKey, now, "0", 2, false)
+	doubleSpendingTransaction := ledger.NewSignedTransaction(1, 1, 0, "B", privateKey, publicKey, now, "0", 1+1, false)
+	feesById := map[string]uint64{pendingTransaction.Id(): 1, doubleSpendingTransaction.Id(): 1}
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, _ int64) (uint64, error) { return feesById[transaction.Id()], nil }
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
+	pool.AddTransaction(pendingTransaction, "0")
+
+	// Act
+	pool.AddTransaction(doubleSpendingTransaction, "0")
+
+	// Assert
+	transactions := pool.Transactions()
+	test.Assert(t, len(transactions) == 1 && transactions[0] == pendingTransaction, "The pending transaction should not be replaced.")
+	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), "failed to add transaction: the transaction inputs are already spent by pending transactions")
+}
+
+func Test_AddTransaction_TransactionDoubleSpendsWithHigherFee_PendingTransactionReplaced(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.IncentiveFunc = func(string) {}
+	var now int64 = 2
+	logger := log.NewLoggerMock()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	pendingTransaction := ledger.NewSignedTransaction(1, 1, 0, "A", privateKey, publicKey, now, "0", 2, false)
+	doubleSpendingTransaction := ledger.NewSignedTransaction(1, 2, 0, "B", privateKey, publicKey, now, "0", 1+2, false)
+	feesById := map[string]uint64{pendingTransaction.Id(): 1, doubleSpendingTransaction.Id(): 2}
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, _ int64) (uint64, error) { return feesById[transaction.Id()], nil }
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
+	pool.AddTransaction(pendingTransaction, "0")
+
+	// Act
+	pool.AddTransaction(doubleSpendingTransaction, "0")
+
+	// Assert
+	transactions := pool.Transactions()
+	test.Assert(t, len(transactions) == 1 && transactions[0] == doubleSpendingTransaction, "The pending transaction should be replaced.")
+	saveTransactionsCalls := transactionsStorageMock.SaveTransactionsCalls()
+	test.Assert(t, len(saveTransactionsCalls) == 1 && len(saveTransactionsCalls[0].Transactions) == 0, "The replaced transaction should be removed from the storage.")
+}
+
+func Test_AddTransaction_DoubleSpendingTransactionsAddedConcurrently_OnlyOneTransactionAdded(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.IncentiveFunc = func(string) {}
+	var now int64 = 2
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.CalculateFeeFunc = func(*ledger.Transaction, int64) (uint64, error) { return 1, nil }
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, log.NewLoggerMock())
+	transactionsCount := 10
+	var transactions []*ledger.Transaction
+	for i := 0; i < transactionsCount; i++ {
+		transactions = append(transactions, ledger.NewSignedTransaction(1, 1, 0, fmt.Sprintf("%d", i), privateKey, publicKey, now, "0", 2, false))
+	}
+	waitGroup := sync.WaitGroup{}
+	waitGroup.Add(transactionsCount)
+
+	// Act
+	for _, transaction := range transactions {
+		go func(transaction *ledger.Transaction) {
+			defer waitGroup.Done()
+			pool.AddTransaction(transaction, "0")
+		}(transaction)
+	}
+
+	// Assert
+	waitGroup.Wait()
+	actualTransactionsCount := len(pool.Transactions())
+	test.Assert(t, actualTransactionsCount == 1, fmt.Sprintf("Wrong transactions count. Expected: 1 - Actual: %d", actualTransactionsCount))
+}
+
+func Test_Load_StoredTransactions_ValidTransactionsRestored(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	var now int64 = 2
+	logger := log.NewLoggerMock()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	privateKey2, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey2)
+	validTransaction := ledger.NewSignedTransaction(0, 0, 0, "A", privateKey, publicKey, now, "0", 0, false)
+	tooOldTransaction := ledger.NewSignedTransaction(0, 0, 0, "A", privateKey, publicKey, now-2, "1", 0, false)
+	invalidSignatureTransaction := ledger.NewSignedTransaction(0, 0, 0, "A", privateKey2, publicKey, now, "2", 0, false)
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	transactionsStorageMock.TransactionsFunc = func() ([]*ledger.Transaction, error) {
+		return []*ledger.Transaction{tooOldTransaction, validTransaction, invalidSignatureTransaction}, nil
+	}
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.CalculateFeeFunc = func(*ledger.Transaction, int64) (uint64, error) { return 0, nil }
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
+
+	// Act
+	err := pool.Load()
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	transactions := pool.Transactions()
+	test.Assert(t, len(transactions) == 1 && transactions[0] == validTransaction, "Only the valid stored transaction should be restored.")
+	test.Assert(t, len(transactionsStorageMock.AddTransactionCalls()) == 0, "Stored transactions are journaled one by one whereas the journal should be rewritten once.")
+	saveTransactionsCalls := transactionsStorageMock.SaveTransactionsCalls()
+	test.Assert(t, len(saveTransactionsCalls) == 1, fmt.Sprintf("Wrong journal rewrites count. Expected: 1 - Actual: %d", len(saveTransactionsCalls)))
+	savedTransactions := saveTransactionsCalls[0].Transactions
+	test.Assert(t, len(savedTransactions) == 1 && savedTransactions[0] == validTransaction, "Only the valid stored transaction should be stored again.")
+	test.AssertThatMessageIsLogged(t, logger.WarnCalls(), "the transaction timestamp is too old", "failed to verify signature")
+}
+
+func Test_AddTransaction_PendingChildHasHigherFeeRateThanParent_TransactionAdded(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.IncentiveFunc = func(string) {}
+	var now int64 = 2
+	logger := log.NewLoggerMock()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
+	settings := newSettingsMock()
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	var genesisValue uint64 = 1000000
+	genesisTransaction1, _ := ledger.NewRewardTransaction(test.Address, false, 0, genesisValue)
+	genesisTransaction2, _ := ledger.NewRewardTransaction(test.Address, false, 0, 2*genesisValue)
+	parentTransaction := ledger.NewSignedTransaction(genesisValue, 1, 0, test.Address, privateKey, publicKey, now, genesisTransaction1.Id(), 1, false)
+	childTransaction := ledger.NewSignedTransaction(genesisValue-2, 1000, 1, test.Address, privateKey, publicKey, now, parentTransaction.Id(), 0, false)
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosRegistry := verification.NewUtxosRegistry(settings)
+	_ = utxosRegistry.UpdateUtxos([]*ledger.Transaction{genesisTransaction1, genesisTransaction2}, 0)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosRegistry, 0, 0, privateKey, logger)
+	pool.AddTransaction(parentTransaction, "0")
+	pool.AddTransaction(childTransaction, "0")
+	transaction := ledger.NewSignedTransaction(2*genesisValue, 1, 0, test.Address, privateKey, publicKey, now, genesisTransaction2.Id(), 1, false)
+
+	// Act
+	result := pool.AddTransaction(transaction, "0")
+
+	// Assert
+	test.Assert(t, result.IsAccepted(), fmt.Sprintf("The transaction is rejected whereas it should be accepted: %v", result))
+	test.Assert(t, len(pool.Transactions()) == 3, fmt.Sprintf("Wrong pending transactions count. Expected: 3 - Actual: %d", len(pool.Transactions())))
+}
+
+func Test_AddTransaction_PoolIsFullAndParentIsEvicted_ChildEvicted(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.IncentiveFunc = func(string) {}
+	var now int64 = 2
+	logger := log.NewLoggerMock()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
+	settings := newSettingsMock()
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	var genesisValue uint64 = 1000000
+	genesisTransaction1, _ := ledger.NewRewardTransaction(test.Address, false, 0, genesisValue)
+	genesisTransaction2, _ := ledger.NewRewardTransaction(test.Address, false, 0, 2*genesisValue)
+	parentTransaction := ledger.NewSignedTransaction(genesisValue, 1, 0, test.Address, privateKey, publicKey, now, genesisTransaction1.Id(), 1, false)
+	childTransaction := ledger.NewSignedTransaction(genesisValue-2, 1000, 1, test.Address, privateKey, publicKey, now, parentTransaction.Id(), 0, false)
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosRegistry := verification.NewUtxosRegistry(settings)
+	_ = utxosRegistry.UpdateUtxos([]*ledger.Transaction{genesisTransaction1, genesisTransaction2}, 0)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosRegistry, 2, 0, privateKey, logger)
+	pool.AddTransaction(parentTransaction, "0")
+	pool.AddTransaction(childTransaction, "0")
+	transaction := ledger.NewSignedTransaction(2*genesisValue, 100, 0, test.Address, privateKey, publicKey, now, genesisTransaction2.Id(), 1, false)
+
+	// Act
+	pool.AddTransaction(transaction, "0")
+
+	// Assert
+	transactions := pool.Transactions()
+	test.Assert(t, len(transactions) == 1 && transactions[0] == transaction, "The child of the evicted transaction is not evicted.")
+}
+
+func Test_AddTransaction_ParentIsReplaced_ChildReplaced(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.IncentiveFunc = func(string) {}
+	var now int64 = 2
+	logger := log.NewLoggerMock()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
+	settings := newSettingsMock()
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	var genesisValue uint64 = 1000000
+	genesisTransaction1, _ := ledger.NewRewardTransaction(test.Address, false, 0, genesisValue)
+	genesisTransaction2, _ := ledger.NewRewardTransaction(test.Address, false, 0, 2*genesisValue)
+	parentTransaction := ledger.NewSignedTransaction(genesisValue, 1, 0, test.Address, privateKey, publicKey, now, genesisTransaction1.Id(), 1, false)
+	childTransaction := ledger.NewSignedTransaction(genesisValue-2, 1000, 1, test.Address, privateKey, publicKey, now, parentTransaction.Id(), 0, false)
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosRegistry := verification.NewUtxosRegistry(settings)
+	_ = utxosRegistry.UpdateUtxos([]*ledger.Transaction{genesisTransaction1, genesisTransaction2}, 0)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosRegistry, 0, 0, privateKey, logger)
+	pool.AddTransaction(parentTransaction, "0")
+	pool.AddTransaction(childTransaction, "0")
+	doubleSpendingTransaction := ledger.NewSignedTransaction(genesisValue, 2000, 0, test.Address2, privateKey, publicKey, now, genesisTransaction1.Id(), 1, false)
+
+	// Act
+	pool.AddTransaction(doubleSpendingTransaction, "0")
+
+	// Assert
+	transactions := pool.Transactions()
+	test.Assert(t, len(transactions) == 1 && transactions[0] == doubleSpendingTransaction, "The child of the replaced transaction is not replaced.")
+}
+
+func Test_Load_StoredChildHasHigherFeeRateThanParent_ChildRestored(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.IncentiveFunc = func(string) {}
+	var now int64 = 2
+	logger := log.NewLoggerMock()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
+	settings := newSettingsMock()
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	var genesisValue uint64 = 1000000
+	genesisTransaction1, _ := ledger.NewRewardTransaction(test.Address, false, 0, genesisValue)
+	genesisTransaction2, _ := ledger.NewRewardTransaction(test.Address, false, 0, 2*genesisValue)
+	parentTransaction := ledger.NewSignedTransaction(genesisValue, 1, 0, test.Address, privateKey, publicKey, now, genesisTransaction1.Id(), 1, false)
+	childTransaction := ledger.NewSignedTransaction(genesisValue-2, 1000, 1, test.Address, privateKey, publicKey, now, parentTransaction.Id(), 0, false)
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosRegistry := verification.NewUtxosRegistry(settings)
+	_ = utxosRegistry.UpdateUtxos([]*ledger.Transaction{genesisTransaction1, genesisTransaction2}, 0)
+	transactionsStorageMock.TransactionsFunc = func() ([]*ledger.Transaction, error) {
+		return []*ledger.Transaction{childTransaction, parentTransaction}, nil
+	}
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosRegistry, 0, 0, privateKey, logger)
+
+	// Act
+	err := pool.Load()
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	transactions := pool.Transactions()
+	test.Assert(t, len(transactions) == 2 && transactions[0] == childTransaction && transactions[1] == parentTransaction, "The stored child transaction is not restored.")
 }
 
 func Test_Validate_BlockAlreadyExist_TransactionsNotValidated(t *testing.T) {
 	// Arrange
-	validatorWalletAddress := test.Address
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
 	sendersManagerMock := new(application.SendersManagerMock)
 	var now int64 = 2
 	logger := log.NewLoggerMock()
 	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
 	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now }
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
 	utxosManagerMock := new(application.UtxosManagerMock)
-	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, utxosManagerMock, validatorWalletAddress, logger)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
 
 	// Act
-	pool.Validate(now)
+	err := pool.Validate(context.Background(), now)
 
 	// Assert
-	test.AssertThatMessageIsLogged(t, logger.ErrorCalls(), "unable to create block, a block with the same timestamp is already in the blockchain")
+	test.Assert(t, err != nil && err.Error() == "unable to create block, a block with the same timestamp is already in the blockchain", "error is not returned whereas it should be")
 }
 
 func Test_Validate_BlockIsMissing_TransactionsNotValidated(t *testing.T) {
 	// Arrange
-	validatorWalletAddress := test.Address
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
 	sendersManagerMock := new(application.SendersManagerMock)
 	var now int64 = 3
 	logger := log.NewLoggerMock()
 	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
 	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 2 }
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
 	utxosManagerMock := new(application.UtxosManagerMock)
-	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, utxosManagerMock, validatorWalletAddress, logger)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
 
 	// Act
-	pool.Validate(now)
+	err := pool.Validate(context.Background(), now)
 
 	// Assert
-	test.AssertThatMessageIsLogged(t, logger.ErrorCalls(), "unable to create block, a block is missing in the blockchain")
+	test.Assert(t, err != nil && err.Error() == "unable to create block, a block is missing in the blockchain", "error is not returned whereas it should be")
 }
 
 func Test_Validate_TransactionTimestampIsInTheFuture_TransactionsNotValidated(t *testing.T) {
 	// Arrange
 	sendersManagerMock := new(application.SendersManagerMock)
-	sendersManagerMock.SendersFunc = func() []application.Sender { return nil }
 	sendersManagerMock.IncentiveFunc = func(string) {}
 	var now int64 = 2
 	transactionFee := 0
 	logger := log.NewLoggerMock()
 	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
 	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
 	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now }
-	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string) error { return nil }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
 	utxosManagerMock.CalculateFeeFunc = func(*ledger.Transaction, int64) (uint64, error) { return 0, nil }
 	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
 	publicKey := encryption.NewPublicKey(privateKey)
-	walletAddress := publicKey.Address()
-	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, utxosManagerMock, walletAddress, logger)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
 	var genesisValue uint64 = 0
 	transaction := ledger.NewSignedTransaction(genesisValue, transactionFee, 0, "A", privateKey, publicKey, now+1, "0", genesisValue, false)
-	pool.AddTransaction(transaction, "0", "0")
+	pool.AddTransaction(transaction, "0")
 	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
 
 	// Act
-	pool.Validate(now)
+	_ = pool.Validate(context.Background(), now)
 
 	// Assert
 	test.AssertThatMessageIsLogged(t, logger.WarnCalls(), "transaction removed from the transactions pool, the transaction timestamp is too far in the future")
@@ -228,32 +701,36 @@ func Test_Validate_TransactionTimestampIsInTheFuture_TransactionsNotValidated(t
 func Test_Validate_TransactionTimestampIsTooOld_TransactionsNotValidated(t *testing.T) {
 	// Arrange
 	sendersManagerMock := new(application.SendersManagerMock)
-	sendersManagerMock.SendersFunc = func() []application.Sender { return nil }
 	sendersManagerMock.IncentiveFunc = func(string) {}
-	var now int64 = 3
+	var now int64 = 4
 	transactionFee := 0
 	logger := log.NewLoggerMock()
 	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
 	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
-	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 2 }
-	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string) error { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 3 }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
 	utxosManagerMock.CalculateFeeFunc = func(*ledger.Transaction, int64) (uint64, error) { return 0, nil }
 	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
 	publicKey := encryption.NewPublicKey(privateKey)
-	walletAddress := publicKey.Address()
-	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, utxosManagerMock, walletAddress, logger)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
 	var genesisValue uint64 = 0
-	transaction := ledger.NewSignedTransaction(genesisValue, transactionFee, 0, "A", privateKey, publicKey, now-2, "0", genesisValue, false)
-	pool.AddTransaction(transaction, "0", "0")
+	transaction := ledger.NewSignedTransaction(genesisValue, transactionFee, 0, "A", privateKey, publicKey, now-3, "0", genesisValue, false)
+	pool.AddTransaction(transaction, "0")
 	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
 
 	// Act
-	pool.Validate(now)
+	_ = pool.Validate(context.Background(), now)
 
 	// Assert
 	test.AssertThatMessageIsLogged(t, logger.WarnCalls(), "transaction removed from the transactions pool, the transaction timestamp is too old")
@@ -262,31 +739,35 @@ func Test_Validate_TransactionTimestampIsTooOld_TransactionsNotValidated(t *test
 func Test_Validate_ValidTransaction_TransactionsValidated(t *testing.T) {
 	// Arrange
 	sendersManagerMock := new(application.SendersManagerMock)
-	sendersManagerMock.SendersFunc = func() []application.Sender { return nil }
 	sendersManagerMock.IncentiveFunc = func(string) {}
 	var now int64 = 2
 	transactionFee := 0
 	logger := log.NewLoggerMock()
 	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
 	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
 	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
-	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string) error { return nil }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
 	utxosManagerMock.CalculateFeeFunc = func(*ledger.Transaction, int64) (uint64, error) { return 0, nil }
 	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
 	publicKey := encryption.NewPublicKey(privateKey)
-	walletAddress := publicKey.Address()
-	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, utxosManagerMock, walletAddress, logger)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
 	var genesisValue uint64 = 0
 	transaction := ledger.NewSignedTransaction(genesisValue, transactionFee, 0, "A", privateKey, publicKey, now, "0", genesisValue, false)
-	pool.AddTransaction(transaction, "0", "0")
+	pool.AddTransaction(transaction, "0")
 
 	// Act
-	pool.Validate(now)
+	_ = pool.Validate(context.Background(), now)
 
 	// Assert
 	addBlockCalls := blocksManagerMock.AddBlockCalls()
@@ -300,3 +781,133 @@ func Test_Validate_ValidTransaction_TransactionsValidated(t *testing.T) {
 	isRewardTransaction := rewardTransaction.HasReward()
 	test.Assert(t, isRewardTransaction, "The second validated transaction should be the reward.")
 }
+
+func Test_Validate_TransactionsCountExceedsMaxTransactionsPerBlock_HighestFeeRateTransactionsValidated(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.IncentiveFunc = func(string) {}
+	var now int64 = 2
+	logger := log.NewLoggerMock()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	lowFeeTransaction := ledger.NewSignedTransaction(1, 1, 0, "A", privateKey, publicKey, now, "0", 2, false)
+	highFeeTransaction := ledger.NewSignedTransaction(1, 2, 0, "A", privateKey, publicKey, now, "1", 3, false)
+	feesById := map[string]uint64{lowFeeTransaction.Id(): 1, highFeeTransaction.Id(): 2}
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, _ int64) (uint64, error) { return feesById[transaction.Id()], nil }
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 1, privateKey, logger)
+	pool.AddTransaction(lowFeeTransaction, "0")
+	pool.AddTransaction(highFeeTransaction, "0")
+
+	// Act
+	_ = pool.Validate(context.Background(), now)
+
+	// Assert
+	addBlockCalls := blocksManagerMock.AddBlockCalls()
+	test.Assert(t, len(addBlockCalls) == 1, fmt.Sprintf("AddBlock method should be called only once whereas it's called %d times", len(addBlockCalls)))
+	transactions := addBlockCalls[0].Transactions
+	test.Assert(t, len(transactions) == 2, "Validated transactions should contain exactly 2 transactions.")
+	test.Assert(t, transactions[0] == highFeeTransaction, "The validated transaction is not the one with the highest fee rate.")
+	pendingTransactions := pool.Transactions()
+	test.Assert(t, len(pendingTransactions) == 1 && pendingTransactions[0] == lowFeeTransaction, "The transaction exceeding the block capacity is not kept in the pool.")
+}
+
+func Test_Validate_AbandonedTransactionIsOlderThanLastBlock_TransactionValidated(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	var now int64 = 3
+	logger := log.NewLoggerMock()
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	abandonedTransaction := ledger.NewSignedTransaction(0, 0, 0, "A", privateKey, publicKey, now-2, "0", 0, false)
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return []*ledger.Transaction{abandonedTransaction} }
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.CalculateFeeFunc = func(*ledger.Transaction, int64) (uint64, error) { return 0, nil }
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosManagerMock, 0, 0, privateKey, logger)
+
+	// Act
+	_ = pool.Validate(context.Background(), now)
+
+	// Assert
+	addBlockCalls := blocksManagerMock.AddBlockCalls()
+	test.Assert(t, len(addBlockCalls) == 1, fmt.Sprintf("AddBlock method should be called only once whereas it's called %d times", len(addBlockCalls)))
+	transactions := addBlockCalls[0].Transactions
+	test.Assert(t, len(transactions) == 2 && transactions[0] == abandonedTransaction, "The abandoned transaction is not validated again.")
+}
+
+func Test_Validate_ChildHasHigherFeeRateThanParent_ParentValidatedBeforeChild(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.IncentiveFunc = func(string) {}
+	var now int64 = 2
+	logger := log.NewLoggerMock()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AbandonedTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTransactionsFunc = func() []*ledger.Transaction { return nil }
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return now - 1 }
+	blocksManagerMock.AddBlockFunc = func(int64, []*ledger.Transaction, []string, *encryption.PrivateKey) error { return nil }
+	settings := newSettingsMock()
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	var genesisValue uint64 = 1000000
+	genesisTransaction1, _ := ledger.NewRewardTransaction(test.Address, false, 0, genesisValue)
+	genesisTransaction2, _ := ledger.NewRewardTransaction(test.Address, false, 0, 2*genesisValue)
+	parentTransaction := ledger.NewSignedTransaction(genesisValue, 1, 0, test.Address, privateKey, publicKey, now, genesisTransaction1.Id(), 1, false)
+	childTransaction := ledger.NewSignedTransaction(genesisValue-2, 1000, 1, test.Address, privateKey, publicKey, now, parentTransaction.Id(), 0, false)
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.AnnounceTransactionFunc = func(string) {}
+	transactionsStorageMock := new(application.TransactionsStorageMock)
+	transactionsStorageMock.AddTransactionFunc = func(*ledger.Transaction) error { return nil }
+	transactionsStorageMock.SaveTransactionsFunc = func([]*ledger.Transaction) error { return nil }
+	utxosRegistry := verification.NewUtxosRegistry(settings)
+	_ = utxosRegistry.UpdateUtxos([]*ledger.Transaction{genesisTransaction1, genesisTransaction2}, 0)
+	pool := NewTransactionsPool(blocksManagerMock, settings, sendersManagerMock, transactionsRelayerMock, transactionsStorageMock, utxosRegistry, 0, 0, privateKey, logger)
+	pool.AddTransaction(parentTransaction, "0")
+	pool.AddTransaction(childTransaction, "0")
+
+	// Act
+	_ = pool.Validate(context.Background(), now)
+
+	// Assert
+	addBlockCalls := blocksManagerMock.AddBlockCalls()
+	test.Assert(t, len(addBlockCalls) == 1, fmt.Sprintf("AddBlock method should be called only once whereas it's called %d times", len(addBlockCalls)))
+	transactions := addBlockCalls[0].Transactions
+	test.Assert(t, len(transactions) == 3, fmt.Sprintf("Wrong validated transactions count. Expected: 3 - Actual: %d", len(transactions)))
+	test.Assert(t, transactions[0] == parentTransaction && transactions[1] == childTransaction, "The parent transaction is not validated before its child.")
+}
+
+func newSettingsMock() *application.ProtocolSettingsProviderMock {
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.HalfLifeInNanosecondsFunc = func() float64 { return 1e18 }
+	settings.IncomeBaseFunc = func() uint64 { return 0 }
+	settings.IncomeLimitFunc = func() uint64 { return 0 }
+	settings.MinimalTransactionFeeFunc = func() uint64 { return 0 }
+	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	return settings
+}
@@ -0,0 +1,83 @@
+package verification
+
+import (
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+)
+
+type addressesIndex struct {
+	entriesByAddress map[string][]*ledger.AddressHistoryEntry
+}
+
+func newAddressesIndex(blocks []*ledger.Block) *addressesIndex {
+	index := &addressesIndex{make(map[string][]*ledger.AddressHistoryEntry)}
+	index.addBlocks(blocks, 0)
+	return index
+}
+
+func (index *addressesIndex) addBlocks(blocks []*ledger.Block, startingBlockHeight uint64) {
+	for i, block := range blocks {
+		blockHeight := startingBlockHeight + uint64(i)
+		for _, transaction := range block.Transactions() {
+			entry := ledger.NewAddressHistoryEntry(transaction, blockHeight, block.Timestamp())
+			for _, address := range transactionAddresses(transaction) {
+				index.entriesByAddress[address] = append(index.entriesByAddress[address], entry)
+			}
+		}
+	}
+}
+
+func (index *addressesIndex) history(address string, offset uint64, limit uint64) []*ledger.AddressHistoryEntry {
+	entries := index.entriesByAddress[address]
+	entriesCount := uint64(len(entries))
+	if offset >= entriesCount {
+		return []*ledger.AddressHistoryEntry{}
+	}
+	if limit > entriesCount-offset {
+		limit = entriesCount - offset
+	}
+	// The most recent entries come first
+	history := make([]*ledger.AddressHistoryEntry, limit)
+	for i := range history {
+		history[i] = entries[entriesCount-1-offset-uint64(i)]
+	}
+	return history
+}
+
+func (index *addressesIndex) removeBlocks(blocks []*ledger.Block, startingBlockHeight uint64) {
+	for _, block := range blocks {
+		for _, transaction := range block.Transactions() {
+			for _, address := range transactionAddresses(transaction) {
+				entries := index.entriesByAddress[address]
+				keptEntriesCount := len(entries)
+				for keptEntriesCount > 0 && entries[keptEntriesCount-1].BlockHeight() >= startingBlockHeight {
+					keptEntriesCount--
+				}
+				if keptEntriesCount == 0 {
+					delete(index.entriesByAddress, address)
+				} else {
+					index.entriesByAddress[address] = entries[:keptEntriesCount]
+				}
+			}
+		}
+	}
+}
+
+func transactionAddresses(transaction *ledger.Transaction) []string {
+	var addresses []string
+	isAdded := make(map[string]bool)
+	for _, input := range transaction.Inputs() {
+		address := input.Address()
+		if !isAdded[address] {
+			isAdded[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	for _, output := range transaction.Outputs() {
+		address := output.Address()
+		if !isAdded[address] {
+			isAdded[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
@@ -1,14 +1,24 @@
 package verification
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/my-cloud/ruthenium/validatornode/application"
+	"sort"
 	"sync"
 
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 )
 
+type addressesRegistryDto struct {
+	RegisteredAddresses []string `json:"registered_addresses"`
+	RemovedAddresses    []string `json:"removed_addresses"`
+}
+
 type AddressesRegistry struct {
 	humansManager       HumansManager
+	metricsRecorder     application.MetricsRecorder
 	registeredMutex     sync.RWMutex
 	temporaryMutex      sync.RWMutex
 	removedMutex        sync.RWMutex
@@ -17,9 +27,10 @@ type AddressesRegistry struct {
 	logger              log.Logger
 }
 
-func NewAddressesRegistry(humansManager HumansManager, logger log.Logger) *AddressesRegistry {
+func NewAddressesRegistry(humansManager HumansManager, metricsRecorder application.MetricsRecorder, logger log.Logger) *AddressesRegistry {
 	registry := &AddressesRegistry{}
 	registry.humansManager = humansManager
+	registry.metricsRecorder = metricsRecorder
 	registry.registeredAddresses = make(map[string]bool)
 	registry.logger = logger
 	return registry
@@ -45,6 +56,7 @@ func (registry *AddressesRegistry) Copy() application.AddressesManager {
 	defer registry.removedMutex.RUnlock()
 	registryCopy := &AddressesRegistry{}
 	registryCopy.humansManager = registry.humansManager
+	registryCopy.metricsRecorder = registry.metricsRecorder
 	registryCopy.registeredAddresses = copyAddressesMap(registry.registeredAddresses)
 	registryCopy.removedAddresses = registry.removedAddresses
 	registryCopy.logger = registry.logger
@@ -65,25 +77,72 @@ func (registry *AddressesRegistry) IsRegistered(address string) bool {
 	return registry.registeredAddresses[address]
 }
 
+func (registry *AddressesRegistry) MarshalJSON() ([]byte, error) {
+	registry.registeredMutex.RLock()
+	defer registry.registeredMutex.RUnlock()
+	registeredAddresses := make([]string, 0, len(registry.registeredAddresses))
+	for address := range registry.registeredAddresses {
+		registeredAddresses = append(registeredAddresses, address)
+	}
+	sort.Strings(registeredAddresses)
+	registry.removedMutex.RLock()
+	defer registry.removedMutex.RUnlock()
+	return json.Marshal(addressesRegistryDto{
+		RegisteredAddresses: registeredAddresses,
+		RemovedAddresses:    registry.removedAddresses,
+	})
+}
+
 func (registry *AddressesRegistry) RemovedAddresses() []string {
 	return registry.removedAddresses
 }
 
-func (registry *AddressesRegistry) Synchronize(_ int64) {
+func (registry *AddressesRegistry) Synchronize(ctx context.Context, _ int64) error {
 	registry.registeredMutex.RLock()
 	defer registry.registeredMutex.RUnlock()
 	registry.removedMutex.Lock()
 	defer registry.removedMutex.Unlock()
+	var keptAddressesCount, removedAddressesCount, failedChecksCount int
 	for address := range registry.registeredAddresses {
+		if ctx.Err() != nil {
+			break
+		}
 		isPohValid, err := registry.humansManager.IsRegistered(address)
 		if err != nil {
 			registry.logger.Debug(err.Error())
+			failedChecksCount++
 		} else if !isPohValid {
 			registry.removedAddresses = append(registry.removedAddresses, address)
+			removedAddressesCount++
+		} else {
+			keptAddressesCount++
 		}
 	}
+	registry.metricsRecorder.ObserveRegistrySynchronization(keptAddressesCount, removedAddressesCount, failedChecksCount)
 	registry.temporaryMutex.Lock()
 	defer registry.temporaryMutex.Unlock()
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("failed to synchronize registry: %w", err)
+	}
+	return nil
+}
+
+func (registry *AddressesRegistry) UnmarshalJSON(data []byte) error {
+	var dto *addressesRegistryDto
+	err := json.Unmarshal(data, &dto)
+	if err != nil {
+		return err
+	}
+	registry.registeredMutex.Lock()
+	defer registry.registeredMutex.Unlock()
+	registry.registeredAddresses = make(map[string]bool, len(dto.RegisteredAddresses))
+	for _, address := range dto.RegisteredAddresses {
+		registry.registeredAddresses[address] = true
+	}
+	registry.removedMutex.Lock()
+	defer registry.removedMutex.Unlock()
+	registry.removedAddresses = dto.RemovedAddresses
+	return nil
 }
 
 func (registry *AddressesRegistry) Update(addedAddresses []string, removedAddresses []string) {
@@ -1,7 +1,11 @@
 package verification
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
 	"testing"
 )
@@ -39,6 +43,40 @@ func Test_Filter_OneOfTwoAddressesIsAlreadyRegistered_ReturnsOneAddress(t *testi
 	test.Assert(t, actualAddress == expectedAddress, fmt.Sprintf("newAddresses should contain %s whereas it contains %s", expectedAddress, actualAddress))
 }
 
+func Test_UnmarshalJSON_MarshaledRegistry_RegisteredAddressesRestored(t *testing.T) {
+	// Arrange
+	registry := &AddressesRegistry{
+		registeredAddresses: map[string]bool{"test": true},
+	}
+	registryBytes, _ := registry.MarshalJSON()
+	restoredRegistry := NewAddressesRegistry(nil, nil, nil)
+
+	// Act
+	err := restoredRegistry.UnmarshalJSON(registryBytes)
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	test.Assert(t, restoredRegistry.IsRegistered("test"), "address is not registered whereas it should be")
+}
+
+func Test_UnmarshalJSON_MarshaledRegistry_RemovedAddressesRestored(t *testing.T) {
+	// Arrange
+	registry := &AddressesRegistry{
+		registeredAddresses: map[string]bool{"test": true},
+		removedAddresses:    []string{"test"},
+	}
+	registryBytes, _ := registry.MarshalJSON()
+	restoredRegistry := NewAddressesRegistry(nil, nil, nil)
+
+	// Act
+	err := restoredRegistry.UnmarshalJSON(registryBytes)
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	removedAddresses := restoredRegistry.RemovedAddresses()
+	test.Assert(t, len(removedAddresses) == 1 && removedAddresses[0] == "test", "removed address is not restored whereas it should be")
+}
+
 func Test_IsRegistered_Registered_ReturnsTrue(t *testing.T) {
 	// Arrange
 	registry := &AddressesRegistry{
@@ -54,7 +92,7 @@ func Test_IsRegistered_Registered_ReturnsTrue(t *testing.T) {
 
 func Test_IsRegistered_NotRegistered_ReturnsFalse(t *testing.T) {
 	// Arrange
-	registry := NewAddressesRegistry(nil, nil)
+	registry := NewAddressesRegistry(nil, nil, nil)
 
 	// Act
 	isRegistered := !registry.IsRegistered("new")
@@ -91,3 +129,28 @@ func Test_Update_AddingAndRemovingAddresses_AddressesCorrectlyUpdated(t *testing
 	test.Assert(t, !registry.registeredAddresses["test"], "registeredAddresses has not been correctly updated")
 	test.Assert(t, len(registry.removedAddresses) == 0, "removedAddresses has not been correctly updated")
 }
+
+func Test_Synchronize_AddressesChecked_SynchronizationObserved(t *testing.T) {
+	// Arrange
+	humansManagerMock := new(HumansManagerMock)
+	humansManagerMock.IsRegisteredFunc = func(address string) (bool, error) {
+		if address == "failed" {
+			return false, errors.New("")
+		}
+		return address == "kept", nil
+	}
+	metricsRecorderMock := new(application.MetricsRecorderMock)
+	metricsRecorderMock.ObserveRegistrySynchronizationFunc = func(int, int, int) {}
+	registry := NewAddressesRegistry(humansManagerMock, metricsRecorderMock, log.NewLoggerMock())
+	registry.Update([]string{"kept", "removed", "failed"}, nil)
+
+	// Act
+	_ = registry.Synchronize(context.Background(), 0)
+
+	// Assert
+	removedAddresses := registry.RemovedAddresses()
+	test.Assert(t, len(removedAddresses) == 1 && removedAddresses[0] == "removed", "removedAddresses has not been correctly updated")
+	calls := metricsRecorderMock.ObserveRegistrySynchronizationCalls()
+	test.Assert(t, len(calls) == 1, "Synchronization is not observed whereas it should be.")
+	test.Assert(t, calls[0].KeptAddressesCount == 1 && calls[0].RemovedAddressesCount == 1 && calls[0].FailedChecksCount == 1, "Synchronization results are wrong.")
+}
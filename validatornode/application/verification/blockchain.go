@@ -1,6 +1,7 @@
 package verification
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,37 +9,90 @@ import (
 	"sync"
 	"time"
 
+	"github.com/my-cloud/ruthenium/validatornode/domain/encryption"
 	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 )
 
+type snapshotDto struct {
+	BlockHash   [32]byte        `json:"block_hash"`
+	BlockHeight uint64          `json:"block_height"`
+	Registry    json.RawMessage `json:"registry"`
+	Utxos       json.RawMessage `json:"utxos"`
+}
+
 type Blockchain struct {
-	blocks         []*ledger.Block
-	mutex          sync.RWMutex
-	registry       application.AddressesManager
-	sendersManager application.SendersManager
-	utxosManager   application.UtxosManager
-	settings       application.ProtocolSettingsProvider
-	logger         log.Logger
+	abandonedTransactions   []*ledger.Transaction
+	addressesIndex          *addressesIndex
+	announcedBlocksByTarget map[string][]*ledger.Block
+	announcedBlocksMutex    sync.Mutex
+	blocks                  []*ledger.Block
+	blocksRelayer           application.BlocksRelayer
+	blocksStorage           application.BlocksStorage
+	isStorageDirty          bool
+	mutex                   sync.RWMutex
+	registry                application.AddressesManager
+	sendersManager          application.SendersManager
+	snapshotStorage         application.SnapshotStorage
+	snapshotInterval        uint64
+	lastSnapshotBlocksCount uint64
+	metricsRecorder         application.MetricsRecorder
+	transactionsIndex       *transactionsIndex
+	utxosManager            application.UtxosManager
+	settings                application.ProtocolSettingsProvider
+	undoRecords             []*undoRecord
+	logger                  log.Logger
 }
 
-func NewBlockchain(registry application.AddressesManager, settings application.ProtocolSettingsProvider, sendersManager application.SendersManager, utxosManager application.UtxosManager, logger log.Logger) *Blockchain {
-	blockchain := newBlockchain(nil, registry, settings, sendersManager, utxosManager, logger)
+func NewBlockchain(blocksStorage application.BlocksStorage, snapshotStorage application.SnapshotStorage, snapshotInterval uint64, isAddressIndexEnabled bool, registry application.AddressesManager, settings application.ProtocolSettingsProvider, sendersManager application.SendersManager, blocksRelayer application.BlocksRelayer, utxosManager application.UtxosManager, metricsRecorder application.MetricsRecorder, logger log.Logger) *Blockchain {
+	blockchain := newBlockchain(nil, blocksStorage, registry, settings, sendersManager, utxosManager, logger)
+	blockchain.blocksRelayer = blocksRelayer
+	blockchain.metricsRecorder = metricsRecorder
+	blockchain.snapshotStorage = snapshotStorage
+	blockchain.snapshotInterval = snapshotInterval
+	if isAddressIndexEnabled {
+		blockchain.addressesIndex = newAddressesIndex(nil)
+	}
 	return blockchain
 }
 
-func newBlockchain(blocks []*ledger.Block, registry application.AddressesManager, settings application.ProtocolSettingsProvider, sendersManager application.SendersManager, utxosManager application.UtxosManager, logger log.Logger) *Blockchain {
+func newBlockchain(blocks []*ledger.Block, blocksStorage application.BlocksStorage, registry application.AddressesManager, settings application.ProtocolSettingsProvider, sendersManager application.SendersManager, utxosManager application.UtxosManager, logger log.Logger) *Blockchain {
 	blockchain := new(Blockchain)
+	blockchain.announcedBlocksByTarget = map[string][]*ledger.Block{}
 	blockchain.blocks = blocks
+	blockchain.blocksStorage = blocksStorage
+	blockchain.undoRecords = make([]*undoRecord, len(blocks))
 	blockchain.registry = registry
 	blockchain.settings = settings
+	blockchain.transactionsIndex = newTransactionsIndex(blocks)
 	blockchain.sendersManager = sendersManager
 	blockchain.utxosManager = utxosManager
 	blockchain.logger = logger
 	return blockchain
 }
 
-func (blockchain *Blockchain) AddBlock(timestamp int64, transactions []*ledger.Transaction, newAddresses []string) error {
+func (blockchain *Blockchain) AbandonedTransactions() []*ledger.Transaction {
+	blockchain.mutex.Lock()
+	defer blockchain.mutex.Unlock()
+	abandonedTransactions := blockchain.abandonedTransactions
+	blockchain.abandonedTransactions = nil
+	return abandonedTransactions
+}
+
+func (blockchain *Blockchain) AddressHistory(address string, offset uint64, limit uint64) ([]*ledger.AddressHistoryEntry, error) {
+	blockchain.mutex.RLock()
+	defer blockchain.mutex.RUnlock()
+	if blockchain.addressesIndex == nil {
+		return nil, errors.New("address index is disabled")
+	}
+	blocksCountLimit := blockchain.settings.BlocksCountLimit()
+	if limit == 0 || limit > blocksCountLimit {
+		limit = blocksCountLimit
+	}
+	return blockchain.addressesIndex.history(address, offset, limit), nil
+}
+
+func (blockchain *Blockchain) AddBlock(timestamp int64, transactions []*ledger.Transaction, newAddresses []string, privateKey *encryption.PrivateKey) error {
 	blockchain.mutex.Lock()
 	defer blockchain.mutex.Unlock()
 	var previousHash [32]byte
@@ -50,10 +104,93 @@ func (blockchain *Blockchain) AddBlock(timestamp int64, transactions []*ledger.T
 			return fmt.Errorf("unable to calculate last block hash: %w", err)
 		}
 	}
+	if blockchain.isStorageDirty {
+		if err := blockchain.store(nil, blockchain.blocks); err != nil {
+			return fmt.Errorf("failed to rewrite stored blocks: %w", err)
+		}
+	}
 	addedAddresses := blockchain.registry.Filter(newAddresses)
 	removedAddresses := blockchain.registry.RemovedAddresses()
-	block := ledger.NewBlock(previousHash, addedAddresses, removedAddresses, timestamp, transactions)
-	return blockchain.addBlock(block)
+	block, err := ledger.NewSignedBlock(previousHash, addedAddresses, removedAddresses, timestamp, transactions, privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to create block: %w", err)
+	}
+	if err = blockchain.blocksStorage.AddBlock(block); err != nil {
+		return fmt.Errorf("failed to store block: %w", err)
+	}
+	if err = blockchain.addBlock(block); err != nil {
+		if truncateError := blockchain.blocksStorage.Truncate(uint64(len(blockchain.blocks))); truncateError != nil {
+			blockchain.logger.Error(fmt.Errorf("failed to remove stored block: %w", truncateError).Error())
+		}
+		blockchain.metricsRecorder.IncrementRejectedBlocks()
+		return err
+	}
+	blockchain.metricsRecorder.IncrementProducedBlocks()
+	blockchain.saveSnapshot()
+	blockchain.announceTip()
+	return nil
+}
+
+func (blockchain *Blockchain) AddAnnouncedBlock(block *ledger.Block, blockHeight uint64, broadcasterTarget string) {
+	blockchain.mutex.RLock()
+	defer blockchain.mutex.RUnlock()
+	hostBlocks := blockchain.blocks
+	// Only a block competing with or following the host tip can be verified without fetching its ancestors
+	if blockHeight == 0 || blockHeight+1 < uint64(len(hostBlocks)) || blockHeight > uint64(len(hostBlocks)) {
+		return
+	}
+	previousHostBlockHash, err := hostBlocks[blockHeight-1].Hash()
+	if err != nil || block.PreviousHash() != previousHostBlockHash {
+		return
+	}
+	// The last host block is always verified again since its UTXOs are not applied yet
+	ancestorBlocksCount := len(hostBlocks) - 1
+	oldHostBlocks := make([]*ledger.Block, ancestorBlocksCount)
+	copy(oldHostBlocks, hostBlocks[:ancestorBlocksCount])
+	neighborBlocks := append(append([]*ledger.Block{}, hostBlocks[ancestorBlocksCount:blockHeight]...), block)
+	// The block timestamp is checked against the verification timestamp when the block is used
+	verifiedBlocks, err := blockchain.verify(hostBlocks[ancestorBlocksCount:], neighborBlocks, oldHostBlocks, block.Timestamp())
+	if err != nil {
+		blockchain.logger.With(log.TargetKey, broadcasterTarget).With(log.BlockHeightKey, blockHeight).With(log.ErrorKey, err).Debug(fmt.Errorf("failed to verify block announced by target %s: %w", broadcasterTarget, err).Error())
+		blockchain.sendersManager.Penalize(broadcasterTarget, application.InvalidBlocksMisbehavior)
+		blockchain.metricsRecorder.IncrementRejectedBlocks()
+		return
+	}
+	blockchain.announcedBlocksMutex.Lock()
+	defer blockchain.announcedBlocksMutex.Unlock()
+	blockchain.announcedBlocksByTarget[broadcasterTarget] = append(oldHostBlocks, verifiedBlocks...)
+}
+
+func (blockchain *Blockchain) Block(blockHeight uint64) (*ledger.Block, error) {
+	blockchain.mutex.RLock()
+	defer blockchain.mutex.RUnlock()
+	if blockHeight >= uint64(len(blockchain.blocks)) {
+		return nil, fmt.Errorf("block not found: height: %d", blockHeight)
+	}
+	return blockchain.blocks[blockHeight], nil
+}
+
+func (blockchain *Blockchain) BlockByHash(hash [32]byte) (*ledger.Block, error) {
+	blockchain.mutex.RLock()
+	defer blockchain.mutex.RUnlock()
+	if blockchain.isEmpty() {
+		return nil, fmt.Errorf("block not found: hash: %x", hash)
+	}
+	lastBlock := blockchain.blocks[len(blockchain.blocks)-1]
+	lastBlockHash, err := lastBlock.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate last block hash: %w", err)
+	}
+	if lastBlockHash == hash {
+		return lastBlock, nil
+	}
+	// The hash of each block but the last one is the previous hash of the next block
+	for i := len(blockchain.blocks) - 1; i > 0; i-- {
+		if blockchain.blocks[i].PreviousHash() == hash {
+			return blockchain.blocks[i-1], nil
+		}
+	}
+	return nil, fmt.Errorf("block not found: hash: %x", hash)
 }
 
 func (blockchain *Blockchain) Blocks(startingBlockHeight uint64) []*ledger.Block {
@@ -72,6 +209,22 @@ func (blockchain *Blockchain) Blocks(startingBlockHeight uint64) []*ledger.Block
 	return blockchain.blocks[startingBlockHeight:endingBlockHeight]
 }
 
+func (blockchain *Blockchain) BlocksRange(startingBlockHeight uint64, endingBlockHeight uint64) ([]*ledger.Block, error) {
+	blockchain.mutex.RLock()
+	defer blockchain.mutex.RUnlock()
+	if startingBlockHeight > endingBlockHeight {
+		return nil, fmt.Errorf("starting block height %d is greater than ending block height %d", startingBlockHeight, endingBlockHeight)
+	}
+	if endingBlockHeight >= uint64(len(blockchain.blocks)) {
+		return nil, fmt.Errorf("block not found: height: %d", endingBlockHeight)
+	}
+	blocksCountLimit := blockchain.settings.BlocksCountLimit()
+	if endingBlockHeight-startingBlockHeight >= blocksCountLimit {
+		return nil, fmt.Errorf("blocks range exceeds the blocks count limit %d", blocksCountLimit)
+	}
+	return blockchain.blocks[startingBlockHeight : endingBlockHeight+1], nil
+}
+
 func (blockchain *Blockchain) FirstBlockTimestamp() int64 {
 	if blockchain.isEmpty() {
 		return 0
@@ -80,6 +233,26 @@ func (blockchain *Blockchain) FirstBlockTimestamp() int64 {
 	}
 }
 
+func (blockchain *Blockchain) Flush() error {
+	blockchain.mutex.Lock()
+	defer blockchain.mutex.Unlock()
+	if blockchain.isStorageDirty {
+		if err := blockchain.store(nil, blockchain.blocks); err != nil {
+			return fmt.Errorf("failed to rewrite stored blocks: %w", err)
+		}
+	}
+	// The registries are snapshotted at the tip so that the next start does not replay the blocks applied since the last snapshot
+	if blockchain.snapshotInterval == 0 || len(blockchain.blocks) < 2 || uint64(len(blockchain.blocks)-1) == blockchain.lastSnapshotBlocksCount {
+		return nil
+	}
+	return blockchain.writeSnapshot()
+}
+
+func (blockchain *Blockchain) Headers(startingBlockHeight uint64) ([]*ledger.BlockHeader, error) {
+	blocks := blockchain.Blocks(startingBlockHeight)
+	return ledger.NewBlockHeaders(blocks, startingBlockHeight)
+}
+
 func (blockchain *Blockchain) LastBlockTimestamp() int64 {
 	if blockchain.isEmpty() {
 		return 0
@@ -96,57 +269,131 @@ func (blockchain *Blockchain) LastBlockTransactions() []*ledger.Transaction {
 	}
 }
 
-func (blockchain *Blockchain) Update(timestamp int64) {
+func (blockchain *Blockchain) Load(timestamp int64) error {
+	blocks, err := blockchain.blocksStorage.Blocks()
+	if err != nil {
+		return fmt.Errorf("failed to load stored blocks: %w", err)
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+	blockchain.mutex.Lock()
+	defer blockchain.mutex.Unlock()
+	appliedBlocksCount := blockchain.loadSnapshot(blocks)
+	if appliedBlocksCount == 0 {
+		blockchain.registry.Clear()
+		blockchain.utxosManager.Clear()
+	}
+	if len(blocks) > 1 {
+		oldBlocks := blocks[:appliedBlocksCount]
+		if _, err = blockchain.verify(nil, blocks[appliedBlocksCount:], oldBlocks, timestamp); err != nil {
+			blockchain.logger.Warn(fmt.Errorf("stored blocks discarded: %w", err).Error())
+			blockchain.registry.Clear()
+			blockchain.utxosManager.Clear()
+			blockchain.lastSnapshotBlocksCount = 0
+			return blockchain.blocksStorage.Truncate(0)
+		}
+	}
+	// The undo records of the blocks restored from the snapshot are unknown
+	undoRecords := make([]*undoRecord, appliedBlocksCount)
+	for _, block := range blocks[appliedBlocksCount : len(blocks)-1] {
+		record, err := applyBlock(block, blockchain.utxosManager, blockchain.registry)
+		if err != nil {
+			return err
+		}
+		undoRecords = append(undoRecords, record)
+	}
+	blockchain.blocks = blocks
+	blockchain.transactionsIndex = newTransactionsIndex(blocks)
+	if blockchain.addressesIndex != nil {
+		blockchain.addressesIndex = newAddressesIndex(blocks)
+	}
+	blockchain.undoRecords = undoRecords
+	blockchain.logger.Info(fmt.Sprintf("stored blocks loaded: %d blocks, %d replayed", len(blocks), len(blocks)-1-int(appliedBlocksCount)))
+	return nil
+}
+
+func (blockchain *Blockchain) Tip() (*ledger.BlockHeader, error) {
+	blockchain.mutex.RLock()
+	defer blockchain.mutex.RUnlock()
+	if blockchain.isEmpty() {
+		return nil, errors.New("blockchain is empty")
+	}
+	lastBlockHeight := len(blockchain.blocks) - 1
+	return ledger.NewBlockHeader(blockchain.blocks[lastBlockHeight], uint64(lastBlockHeight))
+}
+
+func (blockchain *Blockchain) TransactionProof(transactionId string) (*ledger.MerkleProof, error) {
+	blockchain.mutex.RLock()
+	defer blockchain.mutex.RUnlock()
+	location, ok := blockchain.transactionsIndex.location(transactionId)
+	if !ok {
+		return nil, fmt.Errorf("transaction not found: %s", transactionId)
+	}
+	transactions := blockchain.blocks[location.blockHeight].Transactions()
+	return ledger.NewMerkleProof(transactions, transactionId, location.blockHeight)
+}
+
+func (blockchain *Blockchain) TransactionById(transactionId string) (*ledger.IndexedTransaction, error) {
+	blockchain.mutex.RLock()
+	defer blockchain.mutex.RUnlock()
+	location, ok := blockchain.transactionsIndex.location(transactionId)
+	if !ok {
+		return nil, fmt.Errorf("transaction not found: %s", transactionId)
+	}
+	transaction := blockchain.blocks[location.blockHeight].Transactions()[location.position]
+	confirmationsCount := uint64(len(blockchain.blocks)) - location.blockHeight
+	return ledger.NewIndexedTransaction(transaction, location.blockHeight, confirmationsCount), nil
+}
+
+func (blockchain *Blockchain) Update(ctx context.Context, timestamp int64) error {
+	startTime := time.Now()
 	// Verify neighbor blockchains
 	neighbors := blockchain.sendersManager.Senders()
 	blocksByTarget := make(map[string][]*ledger.Block)
 	hostBlocks := blockchain.blocks
 	var waitGroup sync.WaitGroup
 	var mutex sync.RWMutex
+	hostTarget := "host"
 	if len(hostBlocks) > 2 {
-		hostTarget := "host"
 		blocksByTarget[hostTarget] = hostBlocks
-		oldHostBlocks := make([]*ledger.Block, len(hostBlocks)-1)
-		copy(oldHostBlocks, hostBlocks[:len(hostBlocks)-1])
-		lastHostBlocks := []*ledger.Block{hostBlocks[len(hostBlocks)-1]}
-		startingBlockHeight := uint64(len(hostBlocks) - 1)
+	}
+	announcedBlocksByTarget := blockchain.announcedBlocks(hostBlocks, timestamp)
+	if len(hostBlocks) > 0 {
 		for _, neighbor := range neighbors {
-			waitGroup.Add(1)
-			verifiedBlocks, err := blockchain.verifyNeighborBlockchain(timestamp, neighbor, startingBlockHeight, lastHostBlocks, oldHostBlocks)
+			if ctx.Err() != nil {
+				break
+			}
 			target := neighbor.Target()
-			if err != nil {
-				blockchain.logger.Debug(fmt.Errorf("failed to verify last neighbor blocks for target %s: %w", target, err).Error())
-			} else {
-				mutex.Lock()
-				blocksByTarget[target] = append(oldHostBlocks, verifiedBlocks...)
-				mutex.Unlock()
+			if announcedBlocks, isAnnounced := announcedBlocksByTarget[target]; isAnnounced {
+				// The neighbor blocks are already verified, there is no need to poll the neighbor
+				blocksByTarget[target] = announcedBlocks
+				continue
 			}
-			waitGroup.Done()
-		}
-		waitGroup.Wait()
-	}
-	waitGroup.Wait()
-	var isFork bool
-	if len(hostBlocks) > 0 && len(blocksByTarget) < 2 && len(neighbors) > 0 {
-		isFork = true
-		blockchain.logger.Debug("all neighbor blockchains are forks, verifying the whole blockchains")
-		lastHostBlocks := hostBlocks[:len(hostBlocks)-1]
-		var startingBlockHeight uint64 = 0
-		for _, neighbor := range neighbors {
 			waitGroup.Add(1)
-			verifiedBlocks, err := blockchain.verifyNeighborBlockchain(timestamp, neighbor, startingBlockHeight, lastHostBlocks, nil)
-			target := neighbor.Target()
+			neighborBlocks, err := blockchain.synchronize(ctx, timestamp, neighbor, hostBlocks)
 			if err != nil {
-				blockchain.logger.Debug(fmt.Errorf("failed to verify whole neighbor blocks for target %s: %w", target, err).Error())
+				blockchain.logger.With(log.TargetKey, target).With(log.ErrorKey, err).Debug(fmt.Errorf("failed to verify neighbor blocks for target %s: %w", target, err).Error())
+				var misbehavior *neighborMisbehavior
+				if errors.As(err, &misbehavior) {
+					blockchain.sendersManager.Penalize(target, misbehavior.misbehavior)
+					if misbehavior.misbehavior == application.InvalidBlocksMisbehavior {
+						blockchain.metricsRecorder.IncrementRejectedBlocks()
+					}
+				}
 			} else {
 				mutex.Lock()
-				blocksByTarget[target] = verifiedBlocks
+				blocksByTarget[target] = neighborBlocks
 				mutex.Unlock()
 			}
 			waitGroup.Done()
 		}
 	}
 	waitGroup.Wait()
+	if err := ctx.Err(); err != nil {
+		blockchain.metricsRecorder.ObserveBlockchainUpdate(time.Since(startTime), false, false)
+		return fmt.Errorf("failed to verify neighbor blocks: %w", err)
+	}
 	var selectedBlocks []*ledger.Block
 	var isDifferent bool
 	if len(blocksByTarget) > 0 {
@@ -177,6 +424,9 @@ func (blockchain *Blockchain) Update(timestamp int64) {
 		}
 		for _, rejectedTarget := range rejectedTargets {
 			delete(blocksByTarget, rejectedTarget)
+			if rejectedTarget != hostTarget {
+				blockchain.sendersManager.Penalize(rejectedTarget, application.ForkMisbehavior)
+			}
 		}
 		// Keep the longest blockchains
 		rejectedTargets = nil
@@ -237,54 +487,266 @@ func (blockchain *Blockchain) Update(timestamp int64) {
 		}
 	}
 	isReplaced := isDifferent && len(selectedBlocks) != 0
+	var isForked bool
+	var verificationError error
 	if isReplaced {
 		blockchain.mutex.Lock()
 		defer blockchain.mutex.Unlock()
-		var newBlocks []*ledger.Block
-		if isFork {
+		forkBlocksCount := commonBlocksCount(hostBlocks, selectedBlocks)
+		isForked = forkBlocksCount < len(hostBlocks)
+		// Revert the applied host blocks down to the fork point, the last block of each blockchain is not applied
+		revertedBlocksCount := len(hostBlocks) - 1
+		if forkBlocksCount < revertedBlocksCount {
+			revertedBlocksCount = forkBlocksCount
+		}
+		if len(selectedBlocks)-1 < revertedBlocksCount {
+			revertedBlocksCount = len(selectedBlocks) - 1
+		}
+		revertedBlocks := hostBlocks[revertedBlocksCount : len(hostBlocks)-1]
+		if err := revertBlocks(revertedBlocks, blockchain.undoRecordsFrom(revertedBlocksCount), blockchain.utxosManager, blockchain.registry); err != nil {
+			blockchain.logger.Debug(fmt.Errorf("failed to revert blocks, replaying the whole blockchain: %w", err).Error())
 			blockchain.registry.Clear()
 			blockchain.utxosManager.Clear()
-			newBlocks = selectedBlocks[:len(selectedBlocks)-1]
-		} else if len(hostBlocks) < len(selectedBlocks) {
-			newBlocks = selectedBlocks[len(hostBlocks)-1 : len(selectedBlocks)-1]
+			revertedBlocksCount = 0
 		}
-		for _, newBlock := range newBlocks {
-			if err := blockchain.utxosManager.UpdateUtxos(newBlock.Transactions(), newBlock.Timestamp()); err != nil {
-				blockchain.logger.Error(fmt.Errorf("verification failed: failed to add UTXO: %w", err).Error())
+		blockchain.undoRecords = blockchain.undoRecords[:revertedBlocksCount]
+		for i, newBlock := range selectedBlocks[revertedBlocksCount : len(selectedBlocks)-1] {
+			record, err := applyBlock(newBlock, blockchain.utxosManager, blockchain.registry)
+			if err != nil {
+				verificationError = fmt.Errorf("verification failed: %w", err)
 				isReplaced = false
-			} else {
-				blockchain.registry.Update(newBlock.AddedRegisteredAddresses(), newBlock.RemovedRegisteredAddresses())
+				blockchain.restore(hostBlocks, selectedBlocks[revertedBlocksCount:revertedBlocksCount+i], revertedBlocksCount)
+				break
+			}
+			blockchain.undoRecords = append(blockchain.undoRecords, record)
+		}
+		if isReplaced {
+			blockchain.abandon(hostBlocks[forkBlocksCount:], selectedBlocks[forkBlocksCount:])
+			blockchain.transactionsIndex.removeBlocks(hostBlocks[forkBlocksCount:])
+			blockchain.transactionsIndex.addBlocks(selectedBlocks[forkBlocksCount:], uint64(forkBlocksCount))
+			if blockchain.addressesIndex != nil {
+				blockchain.addressesIndex.removeBlocks(hostBlocks[forkBlocksCount:], uint64(forkBlocksCount))
+				blockchain.addressesIndex.addBlocks(selectedBlocks[forkBlocksCount:], uint64(forkBlocksCount))
 			}
 		}
 	}
 	if isReplaced {
+		if err := blockchain.store(hostBlocks, selectedBlocks); err != nil {
+			blockchain.logger.Error(fmt.Errorf("%w, the stored blocks will be rewritten", err).Error())
+		}
 		blockchain.blocks = selectedBlocks
-		blockchain.logger.Debug("verification done: blockchain replaced")
+		blockchain.saveSnapshot()
+		blockchain.announceTip()
+		blockchain.logger.With(log.BlockHeightKey, len(selectedBlocks)-1).Debug("verification done: blockchain replaced")
 	} else {
 		blockchain.logger.Debug("verification done: blockchain kept")
 	}
+	blockchain.metricsRecorder.ObserveBlockchainUpdate(time.Since(startTime), isReplaced, isReplaced && isForked)
+	return verificationError
+}
+
+// restore brings the UTXOs and the registry back to the host blocks state after a replacement failed midway
+func (blockchain *Blockchain) restore(hostBlocks []*ledger.Block, appliedBlocks []*ledger.Block, forkBlockHeight int) {
+	err := revertBlocks(appliedBlocks, blockchain.undoRecordsFrom(forkBlockHeight), blockchain.utxosManager, blockchain.registry)
+	if err == nil {
+		blockchain.undoRecords = blockchain.undoRecords[:forkBlockHeight]
+		err = blockchain.applyBlocks(hostBlocks[forkBlockHeight : len(hostBlocks)-1])
+	}
+	if err != nil {
+		blockchain.logger.Debug(fmt.Errorf("failed to restore host blocks, replaying the whole blockchain: %w", err).Error())
+		blockchain.registry.Clear()
+		blockchain.utxosManager.Clear()
+		blockchain.undoRecords = nil
+		if err = blockchain.applyBlocks(hostBlocks[:len(hostBlocks)-1]); err != nil {
+			blockchain.logger.Error(fmt.Errorf("failed to replay host blocks: %w", err).Error())
+		}
+	}
+}
+
+func (blockchain *Blockchain) applyBlocks(blocks []*ledger.Block) error {
+	for _, block := range blocks {
+		record, err := applyBlock(block, blockchain.utxosManager, blockchain.registry)
+		if err != nil {
+			return err
+		}
+		blockchain.undoRecords = append(blockchain.undoRecords, record)
+	}
+	return nil
 }
 
 func (blockchain *Blockchain) addBlock(block *ledger.Block) error {
 	if !blockchain.isEmpty() {
 		lastBlock := blockchain.blocks[len(blockchain.blocks)-1]
-		if err := blockchain.utxosManager.UpdateUtxos(lastBlock.Transactions(), lastBlock.Timestamp()); err != nil {
-			return fmt.Errorf("failed to add UTXO: %w", err)
+		record, err := applyBlock(lastBlock, blockchain.utxosManager, blockchain.registry)
+		if err != nil {
+			return err
 		}
-		blockchain.registry.Update(lastBlock.AddedRegisteredAddresses(), lastBlock.RemovedRegisteredAddresses())
+		blockchain.undoRecords = append(blockchain.undoRecords, record)
+	}
+	blockchain.transactionsIndex.addBlocks([]*ledger.Block{block}, uint64(len(blockchain.blocks)))
+	if blockchain.addressesIndex != nil {
+		blockchain.addressesIndex.addBlocks([]*ledger.Block{block}, uint64(len(blockchain.blocks)))
 	}
 	blockchain.blocks = append(blockchain.blocks, block)
 	return nil
 }
 
+func (blockchain *Blockchain) announceTip() {
+	header, err := ledger.NewBlockHeader(blockchain.blocks[len(blockchain.blocks)-1], uint64(len(blockchain.blocks)-1))
+	if err != nil {
+		blockchain.logger.Error(fmt.Errorf("failed to create tip header: %w", err).Error())
+		return
+	}
+	blockchain.blocksRelayer.AnnounceBlock(header)
+}
+
+func (blockchain *Blockchain) announcedBlocks(hostBlocks []*ledger.Block, timestamp int64) map[string][]*ledger.Block {
+	blockchain.announcedBlocksMutex.Lock()
+	defer blockchain.announcedBlocksMutex.Unlock()
+	announcedBlocksByTarget := map[string][]*ledger.Block{}
+	for target, blocks := range blockchain.announcedBlocksByTarget {
+		// The announced blocks are outdated if the host blockchain moved on since their verification
+		parentHeight := len(blocks) - 2
+		isOutdated := len(blocks) < len(hostBlocks) || parentHeight >= len(hostBlocks) || blocks[parentHeight] != hostBlocks[parentHeight]
+		if isOutdated {
+			delete(blockchain.announcedBlocksByTarget, target)
+		} else if blocks[len(blocks)-1].Timestamp() <= timestamp {
+			announcedBlocksByTarget[target] = blocks
+		}
+	}
+	return announcedBlocksByTarget
+}
+
+func (blockchain *Blockchain) abandon(oldBlocks []*ledger.Block, newBlocks []*ledger.Block) {
+	keptTransactionIds := make(map[string]bool)
+	for _, block := range newBlocks {
+		for _, transaction := range block.Transactions() {
+			keptTransactionIds[transaction.Id()] = true
+		}
+	}
+	for _, block := range oldBlocks {
+		for _, transaction := range block.Transactions() {
+			if !transaction.HasReward() && !keptTransactionIds[transaction.Id()] {
+				blockchain.abandonedTransactions = append(blockchain.abandonedTransactions, transaction)
+			}
+		}
+	}
+}
+
 func (blockchain *Blockchain) isEmpty() bool {
 	return len(blockchain.blocks) == 0
 }
 
+func (blockchain *Blockchain) loadSnapshot(blocks []*ledger.Block) uint64 {
+	snapshotBytes, err := blockchain.snapshotStorage.Snapshot()
+	if err != nil {
+		blockchain.logger.Warn(fmt.Errorf("failed to load snapshot: %w", err).Error())
+		return 0
+	} else if snapshotBytes == nil {
+		return 0
+	}
+	var snapshot *snapshotDto
+	if err = json.Unmarshal(snapshotBytes, &snapshot); err != nil {
+		blockchain.logger.Warn(fmt.Errorf("failed to unmarshal snapshot: %w", err).Error())
+		return 0
+	}
+	if snapshot.BlockHeight+1 >= uint64(len(blocks)) {
+		blockchain.logger.Warn(fmt.Sprintf("snapshot ignored: block height %d is not below the stored blocks count %d", snapshot.BlockHeight, len(blocks)))
+		return 0
+	}
+	blockHash, err := blocks[snapshot.BlockHeight].Hash()
+	if err != nil || blockHash != snapshot.BlockHash {
+		blockchain.logger.Warn(fmt.Sprintf("snapshot ignored: block hash at height %d does not match", snapshot.BlockHeight))
+		return 0
+	}
+	if err = blockchain.registry.UnmarshalJSON(snapshot.Registry); err != nil {
+		blockchain.logger.Warn(fmt.Errorf("snapshot ignored: failed to restore registry: %w", err).Error())
+		return 0
+	}
+	if err = blockchain.utxosManager.UnmarshalJSON(snapshot.Utxos); err != nil {
+		blockchain.logger.Warn(fmt.Errorf("snapshot ignored: failed to restore UTXOs: %w", err).Error())
+		return 0
+	}
+	appliedBlocksCount := snapshot.BlockHeight + 1
+	blockchain.lastSnapshotBlocksCount = appliedBlocksCount
+	return appliedBlocksCount
+}
+
+func (blockchain *Blockchain) saveSnapshot() {
+	if blockchain.snapshotInterval == 0 || len(blockchain.blocks) < 2 {
+		return
+	}
+	appliedBlocksCount := uint64(len(blockchain.blocks) - 1)
+	if appliedBlocksCount < blockchain.lastSnapshotBlocksCount+blockchain.snapshotInterval {
+		return
+	}
+	if err := blockchain.writeSnapshot(); err != nil {
+		blockchain.logger.Error(err.Error())
+	}
+}
+
+func (blockchain *Blockchain) writeSnapshot() error {
+	appliedBlocksCount := uint64(len(blockchain.blocks) - 1)
+	blockHeight := appliedBlocksCount - 1
+	blockHash, err := blockchain.blocks[blockHeight].Hash()
+	if err != nil {
+		return fmt.Errorf("failed to calculate snapshot block hash: %w", err)
+	}
+	registryBytes, err := blockchain.registry.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal registry snapshot: %w", err)
+	}
+	utxosBytes, err := blockchain.utxosManager.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal UTXOs snapshot: %w", err)
+	}
+	snapshotBytes, err := json.Marshal(snapshotDto{
+		BlockHash:   blockHash,
+		BlockHeight: blockHeight,
+		Registry:    registryBytes,
+		Utxos:       utxosBytes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if err = blockchain.snapshotStorage.SaveSnapshot(snapshotBytes); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	blockchain.lastSnapshotBlocksCount = appliedBlocksCount
+	blockchain.logger.With(log.BlockHeightKey, blockHeight).Debug(fmt.Sprintf("snapshot saved at block height %d", blockHeight))
+	return nil
+}
+
+// store writes the new blocks replacing the old ones, the storage is marked as dirty until it is fully rewritten if a write fails
+func (blockchain *Blockchain) store(oldBlocks []*ledger.Block, newBlocks []*ledger.Block) error {
+	var storedBlocksCount int
+	if !blockchain.isStorageDirty {
+		storedBlocksCount = commonBlocksCount(oldBlocks, newBlocks)
+	}
+	blockchain.isStorageDirty = true
+	if err := blockchain.blocksStorage.Truncate(uint64(storedBlocksCount)); err != nil {
+		return fmt.Errorf("failed to remove replaced stored blocks: %w", err)
+	}
+	for _, block := range newBlocks[storedBlocksCount:] {
+		if err := blockchain.blocksStorage.AddBlock(block); err != nil {
+			return fmt.Errorf("failed to store block: %w", err)
+		}
+	}
+	blockchain.isStorageDirty = false
+	return nil
+}
+
+func (blockchain *Blockchain) undoRecordsFrom(blockHeight int) []*undoRecord {
+	if blockHeight > len(blockchain.undoRecords) {
+		return nil
+	}
+	return blockchain.undoRecords[blockHeight:]
+}
+
 func (blockchain *Blockchain) verify(lastHostBlocks []*ledger.Block, neighborBlocks []*ledger.Block, oldHostBlocks []*ledger.Block, timestamp int64) ([]*ledger.Block, error) {
 	if len(oldHostBlocks) == 0 && len(neighborBlocks) < 2 {
 		return nil, errors.New("neighbor's blockchain is too short")
-	} else if len(oldHostBlocks) > 0 && (len(neighborBlocks) == 0 || lastHostBlocks[0].PreviousHash() != neighborBlocks[0].PreviousHash()) {
+	} else if len(oldHostBlocks) > 0 && (len(neighborBlocks) == 0 || len(lastHostBlocks) > 0 && lastHostBlocks[0].PreviousHash() != neighborBlocks[0].PreviousHash()) {
 		return nil, errors.New("neighbor's blockchain is a fork")
 	}
 	neighborUtxosPool := blockchain.utxosManager.Copy()
@@ -292,8 +754,20 @@ func (blockchain *Blockchain) verify(lastHostBlocks []*ledger.Block, neighborBlo
 	if len(oldHostBlocks) == 0 {
 		neighborUtxosPool.Clear()
 		neighborRegistry.Clear()
+	} else if appliedBlocksCount := len(blockchain.blocks) - 1; len(oldHostBlocks) < appliedBlocksCount {
+		// The host state is ahead of the common ancestor, revert it down to the common ancestor
+		revertedBlocks := blockchain.blocks[len(oldHostBlocks):appliedBlocksCount]
+		if err := revertBlocks(revertedBlocks, blockchain.undoRecordsFrom(len(oldHostBlocks)), neighborUtxosPool, neighborRegistry); err != nil {
+			neighborUtxosPool.Clear()
+			neighborRegistry.Clear()
+			for _, block := range oldHostBlocks {
+				if _, err = applyBlock(block, neighborUtxosPool, neighborRegistry); err != nil {
+					return nil, err
+				}
+			}
+		}
 	}
-	neighborBlockchain := newBlockchain(oldHostBlocks, neighborRegistry, blockchain.settings, blockchain.sendersManager, neighborUtxosPool, blockchain.logger)
+	neighborBlockchain := newBlockchain(oldHostBlocks, nil, neighborRegistry, blockchain.settings, blockchain.sendersManager, neighborUtxosPool, blockchain.logger)
 	var verifiedBlocks []*ledger.Block
 	for i := 0; i < len(neighborBlocks); i++ {
 		neighborBlock := neighborBlocks[i]
@@ -343,56 +817,155 @@ func (blockchain *Blockchain) verify(lastHostBlocks []*ledger.Block, neighborBlo
 				isNewBlock = true
 			}
 		}
-		if isNewBlock && !isGenesisBlock {
-			if err := neighborBlockchain.verifyBlock(neighborBlock, previousBlockTimestamp, timestamp); err != nil {
-				return nil, err
-			}
-		}
+		// The previous block is applied first so that the block can spend its outputs
 		if i == 0 {
 			neighborBlockchain.blocks = append(neighborBlockchain.blocks, neighborBlock)
 		} else if err := neighborBlockchain.addBlock(neighborBlock); err != nil {
 			return nil, err
 		}
+		if isNewBlock && !isGenesisBlock {
+			if err := neighborBlockchain.verifyBlock(neighborBlock, previousBlockTimestamp, timestamp); err != nil {
+				return nil, err
+			}
+		}
 		verifiedBlocks = append(verifiedBlocks, neighborBlock)
 	}
 	lastNeighborBlock := neighborBlocks[len(neighborBlocks)-1]
 	currentBlockTimestamp := lastNeighborBlock.Timestamp()
 	nextBlockTimestamp := currentBlockTimestamp + neighborBlockchain.settings.ValidationTimestamp()
-	if err := neighborBlockchain.AddBlock(nextBlockTimestamp, nil, nil); err != nil {
+	nextBlock := ledger.NewBlock([32]byte{}, nil, nil, nextBlockTimestamp, nil)
+	if err := neighborBlockchain.addBlock(nextBlock); err != nil {
 		return nil, err
 	}
 	return verifiedBlocks, nil
 }
 
-func (blockchain *Blockchain) verifyNeighborBlockchain(timestamp int64, neighbor application.Sender, startingBlockHeight uint64, lastHostBlocks []*ledger.Block, oldHostBlocks []*ledger.Block) ([]*ledger.Block, error) {
-	type ChanResult struct {
-		Blocks []*ledger.Block
-		Err    error
+func (blockchain *Blockchain) synchronize(ctx context.Context, timestamp int64, neighbor application.Sender, hostBlocks []*ledger.Block) ([]*ledger.Block, error) {
+	ancestorBlocksCount, err := blockchain.findCommonBlocksCount(neighbor, hostBlocks)
+	if err != nil {
+		return nil, err
+	}
+	// The last host block is always verified again since its UTXOs are not applied yet
+	if ancestorBlocksCount > uint64(len(hostBlocks)-1) {
+		ancestorBlocksCount = uint64(len(hostBlocks) - 1)
 	}
-	blocksChannel := make(chan *ChanResult)
-	go func(neighbor application.Sender) {
-		defer close(blocksChannel)
-		neighborBlocksBytes, err := neighbor.GetBlocks(startingBlockHeight)
+	neighborBlocks, err := blockchain.getBlocks(ctx, timestamp, neighbor, ancestorBlocksCount, hostBlocks[0].Timestamp())
+	if err != nil {
+		return nil, err
+	}
+	oldHostBlocks := make([]*ledger.Block, ancestorBlocksCount)
+	copy(oldHostBlocks, hostBlocks[:ancestorBlocksCount])
+	lastHostBlocks := hostBlocks[ancestorBlocksCount:]
+	verifiedBlocks, err := blockchain.verify(lastHostBlocks, neighborBlocks, oldHostBlocks, timestamp)
+	if err != nil {
+		return nil, newNeighborMisbehavior(application.InvalidBlocksMisbehavior, err)
+	}
+	return append(oldHostBlocks, verifiedBlocks...), nil
+}
+
+func (blockchain *Blockchain) findCommonBlocksCount(neighbor application.Sender, hostBlocks []*ledger.Block) (uint64, error) {
+	// Step back exponentially from the last host block until a neighbor header matches a host block
+	startingBlockHeight := uint64(len(hostBlocks) - 1)
+	var step uint64 = 1
+	for {
+		headers, err := blockchain.getHeaders(neighbor, startingBlockHeight)
 		if err != nil {
-			blocksChannel <- &ChanResult{Err: fmt.Errorf("failed to get neighbor's blockchain: %w", err)}
+			return 0, err
 		}
-		var neighborBlocks []*ledger.Block
-		err = json.Unmarshal(neighborBlocksBytes, &neighborBlocks)
-		if err != nil {
-			blocksChannel <- &ChanResult{Err: fmt.Errorf("failed to get neighbor's blockchain: %w", err)}
+		var commonBlocksCount uint64
+		for i, header := range headers {
+			blockHeight := startingBlockHeight + uint64(i)
+			if blockHeight >= uint64(len(hostBlocks)) || header.Height() != blockHeight {
+				break
+			}
+			hostBlockHash, err := hostBlocks[blockHeight].Hash()
+			if err != nil {
+				return 0, fmt.Errorf("failed to calculate host block hash: %w", err)
+			}
+			if header.Hash() != hostBlockHash {
+				break
+			}
+			commonBlocksCount = blockHeight + 1
+		}
+		if commonBlocksCount > 0 || startingBlockHeight == 0 {
+			return commonBlocksCount, nil
+		}
+		if startingBlockHeight > step {
+			startingBlockHeight -= step
 		} else {
-			blocksChannel <- &ChanResult{Blocks: neighborBlocks}
+			startingBlockHeight = 0
 		}
-	}(neighbor)
-	timeout := blockchain.settings.ValidationTimeout()
-	select {
-	case chanResult := <-blocksChannel:
-		if chanResult.Err != nil {
-			return nil, chanResult.Err
+		step *= 2
+	}
+}
+
+func (blockchain *Blockchain) getBlocks(ctx context.Context, timestamp int64, neighbor application.Sender, startingBlockHeight uint64, genesisTimestamp int64) ([]*ledger.Block, error) {
+	var blocks []*ledger.Block
+	blocksCountLimit := blockchain.settings.BlocksCountLimit()
+	// The blocks are validated at a fixed interval since the genesis block, a longer blockchain can't be valid
+	var maxBlocksCount uint64
+	if validationTimestamp := blockchain.settings.ValidationTimestamp(); validationTimestamp > 0 && timestamp >= genesisTimestamp {
+		maxBlocksCount = uint64((timestamp-genesisTimestamp)/validationTimestamp) + 1
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("failed to get neighbor's blocks: %w", err)
+		}
+		blockHeight := startingBlockHeight + uint64(len(blocks))
+		blocksBytes, err := blockchain.request(func() ([]byte, error) { return neighbor.GetBlocks(blockHeight) })
+		if err != nil {
+			return nil, fmt.Errorf("failed to get neighbor's blocks: %w", err)
+		}
+		var neighborBlocks []*ledger.Block
+		if err = json.Unmarshal(blocksBytes, &neighborBlocks); err != nil {
+			return nil, newNeighborMisbehavior(application.MalformedResponseMisbehavior, fmt.Errorf("failed to unmarshal neighbor's blocks: %w", err))
 		}
-		return blockchain.verify(lastHostBlocks, chanResult.Blocks, oldHostBlocks, timestamp)
-	case <-time.After(timeout):
-		return nil, errors.New("neighbor's response timeout")
+		for _, neighborBlock := range neighborBlocks {
+			if neighborBlock == nil {
+				return nil, newNeighborMisbehavior(application.MalformedResponseMisbehavior, errors.New("neighbor's blocks contain a null block"))
+			}
+		}
+		if len(blocks) > 0 && len(neighborBlocks) > 0 && neighborBlocks[0].Timestamp() <= blocks[len(blocks)-1].Timestamp() {
+			return nil, newNeighborMisbehavior(application.InvalidBlocksMisbehavior, fmt.Errorf("neighbor's blocks timestamps are not increasing at block height %d", blockHeight))
+		}
+		blocks = append(blocks, neighborBlocks...)
+		if maxBlocksCount > 0 && startingBlockHeight+uint64(len(blocks)) > maxBlocksCount {
+			return nil, newNeighborMisbehavior(application.InvalidBlocksMisbehavior, fmt.Errorf("neighbor's blockchain is longer than possible: %d blocks, maximum is %d", startingBlockHeight+uint64(len(blocks)), maxBlocksCount))
+		}
+		// A block can't be followed by another one if it is not in the past
+		if uint64(len(neighborBlocks)) < blocksCountLimit || len(neighborBlocks) == 0 || neighborBlocks[len(neighborBlocks)-1].Timestamp() >= timestamp {
+			return blocks, nil
+		}
+	}
+}
+
+func (blockchain *Blockchain) getHeaders(neighbor application.Sender, startingBlockHeight uint64) ([]*ledger.BlockHeader, error) {
+	headersBytes, err := blockchain.request(func() ([]byte, error) { return neighbor.GetHeaders(startingBlockHeight) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to get neighbor's headers: %w", err)
+	}
+	var headers []*ledger.BlockHeader
+	if err = json.Unmarshal(headersBytes, &headers); err != nil {
+		return nil, newNeighborMisbehavior(application.MalformedResponseMisbehavior, fmt.Errorf("failed to unmarshal neighbor's headers: %w", err))
+	}
+	return headers, nil
+}
+
+func (blockchain *Blockchain) request(send func() ([]byte, error)) ([]byte, error) {
+	type ChanResult struct {
+		Bytes []byte
+		Err   error
+	}
+	resultChannel := make(chan *ChanResult, 1)
+	go func() {
+		bytes, err := send()
+		resultChannel <- &ChanResult{bytes, err}
+	}()
+	select {
+	case chanResult := <-resultChannel:
+		return chanResult.Bytes, chanResult.Err
+	case <-time.After(blockchain.settings.ValidationTimeout()):
+		return nil, newNeighborMisbehavior(application.TimeoutMisbehavior, errors.New("neighbor's response timeout"))
 	}
 }
 
@@ -410,6 +983,12 @@ func (blockchain *Blockchain) verifyBlock(neighborBlock *ledger.Block, previousB
 		nowDate := time.Unix(0, timestamp)
 		return fmt.Errorf("neighbor block timestamp is in the future: block date is %v, now is %v", blockDate, nowDate)
 	}
+	if err := neighborBlock.VerifySignature(); err != nil {
+		return fmt.Errorf("neighbor block signature is invalid: %w", err)
+	}
+	if neighborBlock.MerkleRoot() != ledger.NewMerkleRoot(neighborBlock.Transactions()) {
+		return errors.New("neighbor block merkle root is invalid")
+	}
 	var reward uint64
 	var totalTransactionsFees uint64
 	addedRegisteredAddresses := neighborBlock.AddedRegisteredAddresses()
@@ -425,7 +1004,8 @@ func (blockchain *Blockchain) verifyBlock(neighborBlock *ledger.Block, previousB
 			if currentBlockTimestamp < transaction.Timestamp() {
 				return fmt.Errorf("a neighbor block transaction timestamp is too far in the future: transaction timestamp: %d, id: %s", transaction.Timestamp(), transaction.Id())
 			}
-			if transaction.Timestamp() < previousBlockTimestamp {
+			// A transaction can be included in the two blocks following its creation, so that the transactions abandoned by a replaced tip remain valid
+			if transaction.Timestamp() < previousBlockTimestamp-blockchain.settings.ValidationTimestamp() {
 				return fmt.Errorf("a neighbor block transaction timestamp is too old: transaction timestamp: %d, id: %s", transaction.Timestamp(), transaction.Id())
 			}
 			if err := transaction.VerifySignatures(); err != nil {
@@ -460,3 +1040,20 @@ func (blockchain *Blockchain) verifyBlock(neighborBlock *ledger.Block, previousB
 	}
 	return nil
 }
+
+func commonBlocksCount(oldBlocks []*ledger.Block, newBlocks []*ledger.Block) int {
+	var commonBlocksCount int
+	for commonBlocksCount < len(oldBlocks) && commonBlocksCount < len(newBlocks) {
+		oldBlock := oldBlocks[commonBlocksCount]
+		newBlock := newBlocks[commonBlocksCount]
+		if oldBlock != newBlock {
+			oldBlockHash, oldBlockHashError := oldBlock.Hash()
+			newBlockHash, newBlockHashError := newBlock.Hash()
+			if oldBlockHashError != nil || newBlockHashError != nil || oldBlockHash != newBlockHash {
+				break
+			}
+		}
+		commonBlocksCount++
+	}
+	return commonBlocksCount
+}
@@ -1,6 +1,7 @@
 package verification
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,33 +20,462 @@ const (
 	blockchainKeptMessage     = "verification done: blockchain kept"
 )
 
+func Test_AddressHistory_AddressIndexIsDisabled_ReturnsError(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	transaction1, _ := ledger.NewRewardTransaction(test.Address, false, 1, 0)
+	transaction2, _ := ledger.NewRewardTransaction(test.Address2, false, 2, 0)
+	transaction3, _ := ledger.NewRewardTransaction(test.Address, false, 3, 0)
+	_ = blockchain.AddBlock(1, []*ledger.Transaction{transaction1}, nil, privateKey)
+	_ = blockchain.AddBlock(2, []*ledger.Transaction{transaction2}, nil, privateKey)
+	_ = blockchain.AddBlock(3, []*ledger.Transaction{transaction3}, nil, privateKey)
+
+	// Act
+	_, err := blockchain.AddressHistory(test.Address, 0, 0)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_AddressHistory_AddressIndexIsEnabled_ReturnsAddressTransactionsFromTheMostRecent(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, true, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	transaction1, _ := ledger.NewRewardTransaction(test.Address, false, 1, 0)
+	transaction2, _ := ledger.NewRewardTransaction(test.Address2, false, 2, 0)
+	transaction3, _ := ledger.NewRewardTransaction(test.Address, false, 3, 0)
+	_ = blockchain.AddBlock(1, []*ledger.Transaction{transaction1}, nil, privateKey)
+	_ = blockchain.AddBlock(2, []*ledger.Transaction{transaction2}, nil, privateKey)
+	_ = blockchain.AddBlock(3, []*ledger.Transaction{transaction3}, nil, privateKey)
+
+	// Act
+	history, err := blockchain.AddressHistory(test.Address, 0, 0)
+
+	// Assert
+	test.Assert(t, err == nil, "Error is returned whereas it should not")
+	test.Assert(t, len(history) == 2, fmt.Sprintf("Wrong history length. Expected: 2 - Actual: %d", len(history)))
+	test.Assert(t, history[0].Transaction() == transaction3 && history[0].BlockHeight() == 2 && history[0].Timestamp() == 3, "Wrong first history entry")
+	test.Assert(t, history[1].Transaction() == transaction1 && history[1].BlockHeight() == 0 && history[1].Timestamp() == 1, "Wrong second history entry")
+}
+
+func Test_AddressHistory_OffsetAndLimitAreGiven_ReturnsRequestedPage(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, true, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	transaction1, _ := ledger.NewRewardTransaction(test.Address, false, 1, 0)
+	transaction2, _ := ledger.NewRewardTransaction(test.Address2, false, 2, 0)
+	transaction3, _ := ledger.NewRewardTransaction(test.Address, false, 3, 0)
+	_ = blockchain.AddBlock(1, []*ledger.Transaction{transaction1}, nil, privateKey)
+	_ = blockchain.AddBlock(2, []*ledger.Transaction{transaction2}, nil, privateKey)
+	_ = blockchain.AddBlock(3, []*ledger.Transaction{transaction3}, nil, privateKey)
+
+	// Act
+	history, err := blockchain.AddressHistory(test.Address, 1, 1)
+
+	// Assert
+	test.Assert(t, err == nil, "Error is returned whereas it should not")
+	test.Assert(t, len(history) == 1 && history[0].Transaction() == transaction1, "Wrong history page")
+}
+
 func Test_AddBlock_ValidParameters_NoErrorReturned(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.FilterFunc = func([]string) []string { return nil }
 	registryMock.RemovedAddressesFunc = func() []string { return nil }
 	logger := log.NewLoggerMock()
 	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
 	utxosManagerMock := new(application.UtxosManagerMock)
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
 
 	// Act
-	err := blockchain.AddBlock(0, nil, nil)
+	err := blockchain.AddBlock(0, nil, nil, privateKey)
 
 	// Assert
 	test.Assert(t, err == nil, "error is returned whereas it should not")
 }
 
+func Test_AddBlock_ValidParameters_TipAnnounced(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	blocksRelayerMock := newBlocksRelayerMock()
+	blockchain := NewBlockchain(blocksStorageMock, new(application.SnapshotStorageMock), 0, false, registryMock, new(application.ProtocolSettingsProviderMock), new(application.SendersManagerMock), blocksRelayerMock, new(application.UtxosManagerMock), newMetricsRecorderMock(), log.NewLoggerMock())
+
+	// Act
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+
+	// Assert
+	announceBlockCalls := blocksRelayerMock.AnnounceBlockCalls()
+	test.Assert(t, len(announceBlockCalls) == 1, "Tip is not announced whereas it should be.")
+	actualHeight := announceBlockCalls[0].Header.Height()
+	test.Assert(t, actualHeight == 0, fmt.Sprintf("Wrong announced height. Expected: 0 - Actual: %d", actualHeight))
+}
+
+func Test_AddBlock_ValidParameters_ProducedBlockCounted(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	metricsRecorderMock := newMetricsRecorderMock()
+	blockchain := NewBlockchain(blocksStorageMock, new(application.SnapshotStorageMock), 0, false, registryMock, new(application.ProtocolSettingsProviderMock), new(application.SendersManagerMock), newBlocksRelayerMock(), new(application.UtxosManagerMock), metricsRecorderMock, log.NewLoggerMock())
+
+	// Act
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+
+	// Assert
+	isProducedBlockCounted := len(metricsRecorderMock.IncrementProducedBlocksCalls()) == 1
+	test.Assert(t, isProducedBlockCounted, "Produced block is not counted whereas it should be.")
+	isRejectedBlockCounted := len(metricsRecorderMock.IncrementRejectedBlocksCalls()) != 0
+	test.Assert(t, !isRejectedBlockCounted, "Rejected block is counted whereas it should not.")
+}
+
+func Test_AddBlock_StorageFails_ErrorReturned(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return errors.New("") }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 1 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+
+	// Act
+	err := blockchain.AddBlock(0, nil, nil, privateKey)
+
+	// Assert
+	test.Assert(t, err != nil, "error is not returned whereas it should be")
+	test.Assert(t, len(blockchain.Blocks(0)) == 0, "block is added whereas it should not")
+}
+
+func Test_AddBlock_SnapshotIntervalReached_SnapshotSaved(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.MarshalJSONFunc = func() ([]byte, error) { return []byte("{}"), nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	snapshotStorageMock.SaveSnapshotFunc = func([]byte) error { return nil }
+	settings := new(application.ProtocolSettingsProviderMock)
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.MarshalJSONFunc = func() ([]byte, error) { return []byte("{}"), nil }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 2, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+
+	// Act
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+	_ = blockchain.AddBlock(1, nil, nil, privateKey)
+	_ = blockchain.AddBlock(2, nil, nil, privateKey)
+	_ = blockchain.AddBlock(3, nil, nil, privateKey)
+
+	// Assert
+	saveSnapshotCalls := snapshotStorageMock.SaveSnapshotCalls()
+	test.Assert(t, len(saveSnapshotCalls) == 1, fmt.Sprintf("snapshot is saved %d times whereas it should be saved once", len(saveSnapshotCalls)))
+	var snapshot *snapshotDto
+	_ = json.Unmarshal(saveSnapshotCalls[0].Snapshot, &snapshot)
+	test.Assert(t, snapshot.BlockHeight == 1, fmt.Sprintf("snapshot block height is %d whereas it should be %d", snapshot.BlockHeight, 1))
+}
+
+func Test_Block_BlockHeightIsInBlockchain_ReturnsBlock(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+	_ = blockchain.AddBlock(1, nil, nil, privateKey)
+	_ = blockchain.AddBlock(2, nil, nil, privateKey)
+
+	// Act
+	block, err := blockchain.Block(1)
+
+	// Assert
+	test.Assert(t, err == nil, "Error is returned whereas it should not")
+	test.Assert(t, block.Timestamp() == 1, fmt.Sprintf("Wrong block timestamp. Expected: 1 - Actual: %d", block.Timestamp()))
+}
+
+func Test_Block_BlockHeightIsGreaterThanBlockchainHeight_ReturnsError(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+	_ = blockchain.AddBlock(1, nil, nil, privateKey)
+	_ = blockchain.AddBlock(2, nil, nil, privateKey)
+
+	// Act
+	_, err := blockchain.Block(3)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_BlockByHash_HashIsInBlockchain_ReturnsBlock(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+	_ = blockchain.AddBlock(1, nil, nil, privateKey)
+	_ = blockchain.AddBlock(2, nil, nil, privateKey)
+	blocks := blockchain.Blocks(0)
+	for _, expectedBlock := range blocks {
+		hash, _ := expectedBlock.Hash()
+
+		// Act
+		block, err := blockchain.BlockByHash(hash)
+
+		// Assert
+		test.Assert(t, err == nil, "Error is returned whereas it should not")
+		test.Assert(t, block == expectedBlock, fmt.Sprintf("Wrong block. Expected timestamp: %d - Actual timestamp: %d", expectedBlock.Timestamp(), block.Timestamp()))
+	}
+}
+
+func Test_BlockByHash_HashIsNotInBlockchain_ReturnsError(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+	_ = blockchain.AddBlock(1, nil, nil, privateKey)
+	_ = blockchain.AddBlock(2, nil, nil, privateKey)
+
+	// Act
+	_, err := blockchain.BlockByHash([32]byte{1})
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_BlocksRange_RangeIsInBlockchain_ReturnsBlocks(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+	_ = blockchain.AddBlock(1, nil, nil, privateKey)
+	_ = blockchain.AddBlock(2, nil, nil, privateKey)
+
+	// Act
+	blocks, err := blockchain.BlocksRange(1, 2)
+
+	// Assert
+	test.Assert(t, err == nil, "Error is returned whereas it should not")
+	test.Assert(t, len(blocks) == 2, fmt.Sprintf("Wrong blocks count. Expected: 2 - Actual: %d", len(blocks)))
+	test.Assert(t, blocks[0].Timestamp() == 1, "Wrong first block")
+}
+
+func Test_BlocksRange_RangeExceedsBlocksCountLimit_ReturnsError(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 1 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+	_ = blockchain.AddBlock(1, nil, nil, privateKey)
+	_ = blockchain.AddBlock(2, nil, nil, privateKey)
+
+	// Act
+	_, err := blockchain.BlocksRange(1, 2)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_BlocksRange_StartingBlockHeightIsGreaterThanEndingBlockHeight_ReturnsError(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+	_ = blockchain.AddBlock(1, nil, nil, privateKey)
+	_ = blockchain.AddBlock(2, nil, nil, privateKey)
+
+	// Act
+	_, err := blockchain.BlocksRange(2, 1)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
 func Test_Blocks_BlocksCountLimitSetToZero_ReturnsEmptyArray(t *testing.T) {
 	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
 	registryMock := new(application.AddressesManagerMock)
 	logger := log.NewLoggerMock()
 	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.BlocksCountLimitFunc = func() uint64 { return 0 }
 	utxosManagerMock := new(application.UtxosManagerMock)
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
 
 	// Act
 	blocks := blockchain.Blocks(0)
@@ -56,22 +486,28 @@ func Test_Blocks_BlocksCountLimitSetToZero_ReturnsEmptyArray(t *testing.T) {
 
 func Test_Blocks_BlocksCountLimitSetToOne_ReturnsOneBlock(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.FilterFunc = func([]string) []string { return nil }
 	registryMock.RemovedAddressesFunc = func() []string { return nil }
 	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
 	logger := log.NewLoggerMock()
 	sendersManagerMock := new(application.SendersManagerMock)
 	var expectedBlocksCount uint64 = 1
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.BlocksCountLimitFunc = func() uint64 { return expectedBlocksCount }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
 	var validationInterval int64 = 1
 	var genesisTimestamp int64 = 0
-	_ = blockchain.AddBlock(genesisTimestamp, nil, nil)
-	_ = blockchain.AddBlock(genesisTimestamp+validationInterval, nil, nil)
+	_ = blockchain.AddBlock(genesisTimestamp, nil, nil, privateKey)
+	_ = blockchain.AddBlock(genesisTimestamp+validationInterval, nil, nil, privateKey)
 
 	// Act
 	blocks := blockchain.Blocks(0)
@@ -83,22 +519,28 @@ func Test_Blocks_BlocksCountLimitSetToOne_ReturnsOneBlock(t *testing.T) {
 
 func Test_Blocks_BlocksCountLimitSetToTwo_ReturnsTwoBlocks(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.FilterFunc = func([]string) []string { return nil }
 	registryMock.RemovedAddressesFunc = func() []string { return nil }
 	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
 	logger := log.NewLoggerMock()
 	sendersManagerMock := new(application.SendersManagerMock)
 	var expectedBlocksCount uint64 = 2
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.BlocksCountLimitFunc = func() uint64 { return expectedBlocksCount }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
 	var validationInterval int64 = 1
 	var genesisTimestamp int64 = 0
-	_ = blockchain.AddBlock(genesisTimestamp, nil, nil)
-	_ = blockchain.AddBlock(genesisTimestamp+validationInterval, nil, nil)
+	_ = blockchain.AddBlock(genesisTimestamp, nil, nil, privateKey)
+	_ = blockchain.AddBlock(genesisTimestamp+validationInterval, nil, nil, privateKey)
 
 	// Act
 	blocks := blockchain.Blocks(0)
@@ -110,19 +552,24 @@ func Test_Blocks_BlocksCountLimitSetToTwo_ReturnsTwoBlocks(t *testing.T) {
 
 func Test_Blocks_StartingBlockHeightGreaterThanBlocksLength_ReturnsEmptyArray(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.FilterFunc = func([]string) []string { return nil }
 	registryMock.RemovedAddressesFunc = func() []string { return nil }
 	logger := log.NewLoggerMock()
 	sendersManagerMock := new(application.SendersManagerMock)
 	var blocksCount uint64 = 1
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.BlocksCountLimitFunc = func() uint64 { return blocksCount }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
 	var genesisTimestamp int64 = 0
-	_ = blockchain.AddBlock(genesisTimestamp, nil, nil)
+	_ = blockchain.AddBlock(genesisTimestamp, nil, nil, privateKey)
 
 	// Act
 	blocks := blockchain.Blocks(1)
@@ -133,14 +580,136 @@ func Test_Blocks_StartingBlockHeightGreaterThanBlocksLength_ReturnsEmptyArray(t
 	test.Assert(t, actualBlocksCount == expectedBlocksCount, fmt.Sprintf("blocks count is %d whereas it should be %d", actualBlocksCount, expectedBlocksCount))
 }
 
-func Test_FirstBlockTimestamp_BlockchainIsEmpty_Returns0(t *testing.T) {
+func Test_Flush_BlocksAppliedSinceLastSnapshot_SnapshotSavedAtTip(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.MarshalJSONFunc = func() ([]byte, error) { return []byte("{}"), nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
 	logger := log.NewLoggerMock()
 	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	snapshotStorageMock.SaveSnapshotFunc = func([]byte) error { return nil }
 	settings := new(application.ProtocolSettingsProviderMock)
 	utxosManagerMock := new(application.UtxosManagerMock)
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
+	utxosManagerMock.MarshalJSONFunc = func() ([]byte, error) { return []byte("{}"), nil }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 10, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+	_ = blockchain.AddBlock(1, nil, nil, privateKey)
+	_ = blockchain.AddBlock(2, nil, nil, privateKey)
+
+	// Act
+	err := blockchain.Flush()
+	_ = blockchain.Flush()
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	saveSnapshotCalls := snapshotStorageMock.SaveSnapshotCalls()
+	test.Assert(t, len(saveSnapshotCalls) == 1, fmt.Sprintf("snapshot is saved %d times whereas it should be saved once", len(saveSnapshotCalls)))
+	var snapshot *snapshotDto
+	_ = json.Unmarshal(saveSnapshotCalls[0].Snapshot, &snapshot)
+	test.Assert(t, snapshot.BlockHeight == 1, fmt.Sprintf("snapshot block height is %d whereas it should be %d", snapshot.BlockHeight, 1))
+}
+
+func Test_Flush_StorageFailedDuringUpdate_StoredBlocksRewritten(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	var isStorageFailing bool
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error {
+		if isStorageFailing {
+			return errors.New("")
+		}
+		return nil
+	}
+	blocksStorageMock.TruncateFunc = func(uint64) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.ClearFunc = func() {}
+	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	logger := log.NewLoggerMock()
+	senderMock := new(application.SenderMock)
+	senderMock.TargetFunc = func() string {
+		return "neighbor"
+	}
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
+	sendersManagerMock.SendersFunc = func() []application.Sender {
+		return []application.Sender{senderMock}
+	}
+	var validationTimestamp int64 = 11
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
+	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
+	now := 5 * validationTimestamp
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	utxosManagerMock.ClearFunc = func() {}
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(now-5*validationTimestamp, nil, nil, privateKey)
+	_ = blockchain.AddBlock(now-4*validationTimestamp, nil, nil, privateKey)
+	blocks := blockchain.Blocks(0)
+	genesisBlockHash := blocks[1].PreviousHash()
+	block1 := ledger.NewRewardedBlock(genesisBlockHash, now-4*validationTimestamp, privateKey)
+	hash1, _ := block1.Hash()
+	block2 := ledger.NewRewardedBlock(hash1, now-3*validationTimestamp, privateKey)
+	hash2, _ := block2.Hash()
+	block3 := ledger.NewRewardedBlock(hash2, now-2*validationTimestamp, privateKey)
+	hash3, _ := block3.Hash()
+	block4 := ledger.NewRewardedBlock(hash3, now-validationTimestamp, privateKey)
+	neighborBlocks := []*ledger.Block{blocks[0], block1, block2, block3, block4}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		endingBlockHeight := startingBlockHeight + 2
+		if endingBlockHeight > uint64(len(neighborBlocks)) {
+			endingBlockHeight = uint64(len(neighborBlocks))
+		}
+		return json.Marshal(neighborBlocks[startingBlockHeight:endingBlockHeight])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
+	}
+
+	isStorageFailing = true
+	_ = blockchain.Update(context.Background(), now)
+	isStorageFailing = false
+	storedBlocksCount := len(blocksStorageMock.AddBlockCalls())
+	truncateCallsCount := len(blocksStorageMock.TruncateCalls())
+
+	// Act
+	err := blockchain.Flush()
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	truncateCalls := blocksStorageMock.TruncateCalls()[truncateCallsCount:]
+	test.Assert(t, len(truncateCalls) == 1 && truncateCalls[0].BlocksCount == 0, "stored blocks are not all removed whereas they should be")
+	test.Assert(t, len(blocksStorageMock.AddBlockCalls())-storedBlocksCount == len(neighborBlocks), "stored blocks are not all rewritten whereas they should be")
+}
+
+func Test_FirstBlockTimestamp_BlockchainIsEmpty_Returns0(t *testing.T) {
+	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
+	registryMock := new(application.AddressesManagerMock)
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	utxosManagerMock := new(application.UtxosManagerMock)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
 
 	// Act
 	actualTimestamp := blockchain.FirstBlockTimestamp()
@@ -152,17 +721,22 @@ func Test_FirstBlockTimestamp_BlockchainIsEmpty_Returns0(t *testing.T) {
 
 func Test_FirstBlockTimestamp_BlockchainIsNotEmpty_ReturnsFirstBlockTimestamp(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.FilterFunc = func([]string) []string { return nil }
 	registryMock.RemovedAddressesFunc = func() []string { return nil }
 	logger := log.NewLoggerMock()
 	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
 	var genesisTimestamp int64 = 0
-	_ = blockchain.AddBlock(genesisTimestamp, nil, nil)
+	_ = blockchain.AddBlock(genesisTimestamp, nil, nil, privateKey)
 
 	// Act
 	actualTimestamp := blockchain.FirstBlockTimestamp()
@@ -174,91 +748,694 @@ func Test_FirstBlockTimestamp_BlockchainIsNotEmpty_ReturnsFirstBlockTimestamp(t
 
 func Test_LastBlockTimestamp_BlockchainIsEmpty_Returns0(t *testing.T) {
 	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
 	registryMock := new(application.AddressesManagerMock)
 	logger := log.NewLoggerMock()
 	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
 	utxosManagerMock := new(application.UtxosManagerMock)
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+
+	// Act
+	actualTimestamp := blockchain.LastBlockTimestamp()
+
+	// Assert
+	var expectedTimestamp int64 = 0
+	test.Assert(t, actualTimestamp == expectedTimestamp, fmt.Sprintf("timestamp is %d whereas it should be %d", actualTimestamp, expectedTimestamp))
+}
+
+func Test_LastBlockTimestamp_BlockchainIsNotEmpty_ReturnsLastBlockTimestamp(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	var genesisTimestamp int64 = 0
+	var expectedTimestamp int64 = 1
+	_ = blockchain.AddBlock(genesisTimestamp, nil, nil, privateKey)
+	_ = blockchain.AddBlock(expectedTimestamp, nil, nil, privateKey)
+
+	// Act
+	actualTimestamp := blockchain.LastBlockTimestamp()
+
+	// Assert
+	test.Assert(t, actualTimestamp == expectedTimestamp, fmt.Sprintf("timestamp is %d whereas it should be %d", actualTimestamp, expectedTimestamp))
+}
+
+func Test_LastBlockTransactions_BlockchainIsEmpty_ReturnsEmptyArray(t *testing.T) {
+	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
+	registryMock := new(application.AddressesManagerMock)
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	utxosManagerMock := new(application.UtxosManagerMock)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+
+	// Act
+	actualTransactions := blockchain.LastBlockTransactions()
+
+	// Assert
+	expectedTransactionsLength := 0
+	test.Assert(t, len(actualTransactions) == expectedTransactionsLength, fmt.Sprintf("transactions length is %d whereas it should be %d", len(actualTransactions), expectedTransactionsLength))
+}
+
+func Test_LastBlockTransactions_BlockchainIsNotEmpty_ReturnsLastBlockTimestamp(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	var genesisTimestamp int64 = 0
+	var timestamp int64 = 1
+	_ = blockchain.AddBlock(genesisTimestamp, nil, nil, privateKey)
+	transaction, _ := ledger.NewRewardTransaction("", false, timestamp, 0)
+	expectedTransactionId := transaction.Id()
+	_ = blockchain.AddBlock(timestamp, []*ledger.Transaction{transaction}, nil, privateKey)
+
+	// Act
+	actualTransactions := blockchain.LastBlockTransactions()
+
+	// Assert
+	actualTransactionId := actualTransactions[0].Id()
+	test.Assert(t, actualTransactionId == expectedTransactionId, fmt.Sprintf("transactions ID is %s whereas it should be %s", actualTransactionId, expectedTransactionId))
+}
+
+func Test_Tip_BlockchainIsEmpty_ReturnsError(t *testing.T) {
+	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
+	registryMock := new(application.AddressesManagerMock)
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	utxosManagerMock := new(application.UtxosManagerMock)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+
+	// Act
+	_, err := blockchain.Tip()
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_Tip_BlockchainIsNotEmpty_ReturnsLastBlockHeader(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+	_ = blockchain.AddBlock(1, nil, nil, privateKey)
+	_ = blockchain.AddBlock(2, nil, nil, privateKey)
+	lastBlock := blockchain.Blocks(2)[0]
+	expectedHash, _ := lastBlock.Hash()
+
+	// Act
+	tip, err := blockchain.Tip()
+
+	// Assert
+	test.Assert(t, err == nil, "Error is returned whereas it should not")
+	test.Assert(t, tip.Height() == 2, fmt.Sprintf("Wrong tip height. Expected: 2 - Actual: %d", tip.Height()))
+	test.Assert(t, tip.Hash() == expectedHash, "Wrong tip hash")
+	test.Assert(t, tip.Timestamp() == 2, fmt.Sprintf("Wrong tip timestamp. Expected: 2 - Actual: %d", tip.Timestamp()))
+}
+
+func Test_TransactionById_TransactionIsInBlockchain_ReturnsIndexedTransaction(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	transaction1, _ := ledger.NewRewardTransaction(test.Address, false, 1, 0)
+	transaction2, _ := ledger.NewRewardTransaction(test.Address, false, 1, 1)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+	_ = blockchain.AddBlock(1, []*ledger.Transaction{transaction1, transaction2}, nil, privateKey)
+	_ = blockchain.AddBlock(2, nil, nil, privateKey)
+
+	// Act
+	indexedTransaction, err := blockchain.TransactionById(transaction2.Id())
+
+	// Assert
+	test.Assert(t, err == nil, "Error is returned whereas it should not")
+	test.Assert(t, indexedTransaction.Transaction() == transaction2, "Wrong transaction")
+	test.Assert(t, indexedTransaction.BlockHeight() == 1, fmt.Sprintf("Wrong block height. Expected: 1 - Actual: %d", indexedTransaction.BlockHeight()))
+	test.Assert(t, indexedTransaction.ConfirmationsCount() == 2, fmt.Sprintf("Wrong confirmations count. Expected: 2 - Actual: %d", indexedTransaction.ConfirmationsCount()))
+}
+
+func Test_TransactionById_TransactionIsNotInBlockchain_ReturnsError(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	transaction1, _ := ledger.NewRewardTransaction(test.Address, false, 1, 0)
+	transaction2, _ := ledger.NewRewardTransaction(test.Address, false, 1, 1)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
+	_ = blockchain.AddBlock(1, []*ledger.Transaction{transaction1, transaction2}, nil, privateKey)
+	_ = blockchain.AddBlock(2, nil, nil, privateKey)
+
+	// Act
+	_, err := blockchain.TransactionById("unknown")
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_TransactionProof_TransactionIsInBlockchain_ReturnsValidProof(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 1 }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	var genesisTimestamp int64 = 0
+	var timestamp int64 = 1
+	_ = blockchain.AddBlock(genesisTimestamp, nil, nil, privateKey)
+	transaction1, _ := ledger.NewRewardTransaction(test.Address, false, timestamp, 0)
+	transaction2, _ := ledger.NewRewardTransaction(test.Address, false, timestamp, 1)
+	_ = blockchain.AddBlock(timestamp, []*ledger.Transaction{transaction1, transaction2}, nil, privateKey)
+
+	// Act
+	proof, err := blockchain.TransactionProof(transaction1.Id())
+
+	// Assert
+	test.Assert(t, err == nil, "Error is returned whereas it should not")
+	test.Assert(t, proof.BlockHeight() == 1, fmt.Sprintf("Wrong block height. Expected: 1 - Actual: %d", proof.BlockHeight()))
+	test.Assert(t, proof.MerkleRoot() == blockchain.Blocks(1)[0].MerkleRoot(), "Merkle root does not match the block one")
+	header, _ := ledger.NewBlockHeader(blockchain.Blocks(1)[0], 1)
+	test.Assert(t, proof.Verify(header) == nil, "Proof is invalid whereas it should be valid")
+}
+
+func Test_TransactionProof_TransactionIsNotInBlockchain_ReturnsError(t *testing.T) {
+	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
+	registryMock := new(application.AddressesManagerMock)
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	utxosManagerMock := new(application.UtxosManagerMock)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+
+	// Act
+	_, err := blockchain.TransactionProof("unknown")
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_Load_StoredBlocksAreValid_BlocksLoaded(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	var validationTimestamp int64 = 11
+	now := 3 * validationTimestamp
+	genesisBlock := ledger.NewGenesisBlock(test.Address, 0)
+	genesisBlockHash, _ := genesisBlock.Hash()
+	block1 := ledger.NewRewardedBlock(genesisBlockHash, validationTimestamp, privateKey)
+	hash1, _ := block1.Hash()
+	block2 := ledger.NewRewardedBlock(hash1, 2*validationTimestamp, privateKey)
+	storedBlocks := []*ledger.Block{genesisBlock, block1, block2}
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.BlocksFunc = func() ([]*ledger.Block, error) { return storedBlocks, nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.ClearFunc = func() {}
+	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	snapshotStorageMock.SnapshotFunc = func() ([]byte, error) { return nil, nil }
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 3 }
+	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.ClearFunc = func() {}
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+
+	// Act
+	err := blockchain.Load(now)
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	blocks := blockchain.Blocks(0)
+	test.Assert(t, len(blocks) == len(storedBlocks), fmt.Sprintf("blocks count is %d whereas it should be %d", len(blocks), len(storedBlocks)))
+	test.Assert(t, len(blocksStorageMock.TruncateCalls()) == 0, "stored blocks are truncated whereas they should not")
+}
+
+func Test_Load_StoredBlocksAreInvalid_StoredBlocksDiscarded(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	var validationTimestamp int64 = 11
+	now := 3 * validationTimestamp
+	genesisBlock := ledger.NewGenesisBlock(test.Address, 0)
+	block1 := ledger.NewRewardedBlock([32]byte{}, validationTimestamp, privateKey)
+	storedBlocks := []*ledger.Block{genesisBlock, block1}
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.BlocksFunc = func() ([]*ledger.Block, error) { return storedBlocks, nil }
+	blocksStorageMock.TruncateFunc = func(uint64) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.ClearFunc = func() {}
+	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	snapshotStorageMock.SnapshotFunc = func() ([]byte, error) { return nil, nil }
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 3 }
+	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.ClearFunc = func() {}
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+
+	// Act
+	err := blockchain.Load(now)
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	blocks := blockchain.Blocks(0)
+	test.Assert(t, len(blocks) == 0, "blocks are loaded whereas they should not")
+	truncateCalls := blocksStorageMock.TruncateCalls()
+	test.Assert(t, len(truncateCalls) == 1 && truncateCalls[0].BlocksCount == 0, "stored blocks are not discarded whereas they should be")
+	test.AssertThatMessageIsLogged(t, logger.WarnCalls(), "stored blocks discarded")
+}
+
+func Test_Load_SnapshotMatchesStoredBlocks_OnlyFollowingBlocksReplayed(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	var validationTimestamp int64 = 11
+	now := 3 * validationTimestamp
+	genesisBlock := ledger.NewGenesisBlock(test.Address, 0)
+	genesisBlockHash, _ := genesisBlock.Hash()
+	block1 := ledger.NewRewardedBlock(genesisBlockHash, validationTimestamp, privateKey)
+	hash1, _ := block1.Hash()
+	block2 := ledger.NewRewardedBlock(hash1, 2*validationTimestamp, privateKey)
+	storedBlocks := []*ledger.Block{genesisBlock, block1, block2}
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.BlocksFunc = func() ([]*ledger.Block, error) { return storedBlocks, nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
+	registryMock.UnmarshalJSONFunc = func([]byte) error { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	logger := log.NewLoggerMock()
+	sendersManagerMock := new(application.SendersManagerMock)
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	snapshotStorageMock.SnapshotFunc = func() ([]byte, error) {
+		return json.Marshal(snapshotDto{
+			BlockHash:   genesisBlockHash,
+			BlockHeight: 0,
+			Registry:    []byte("{}"),
+			Utxos:       []byte("{}"),
+		})
+	}
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 3 }
+	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UnmarshalJSONFunc = func([]byte) error { return nil }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+
+	// Act
+	err := blockchain.Load(now)
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	blocks := blockchain.Blocks(0)
+	test.Assert(t, len(blocks) == len(storedBlocks), fmt.Sprintf("blocks count is %d whereas it should be %d", len(blocks), len(storedBlocks)))
+	test.Assert(t, len(registryMock.UnmarshalJSONCalls()) == 1, "registry is not restored whereas it should be")
+	test.Assert(t, len(utxosManagerMock.UnmarshalJSONCalls()) == 1, "UTXOs are not restored whereas they should be")
+	updateUtxosCalls := utxosManagerMock.UpdateUtxosCalls()
+	// Two calls for the verification of the blocks following the snapshot and one call for the replay
+	test.Assert(t, len(updateUtxosCalls) == 3, fmt.Sprintf("UTXOs are updated %d times whereas they should be updated %d times", len(updateUtxosCalls), 3))
+	test.Assert(t, updateUtxosCalls[2].Timestamp == block1.Timestamp(), "wrong block replayed")
+}
+
+func Test_Update_NeighborBlockchainIsBetter_IsReplaced(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	blocksStorageMock.TruncateFunc = func(uint64) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.ClearFunc = func() {}
+	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	logger := log.NewLoggerMock()
+	senderMock := new(application.SenderMock)
+	senderMock.TargetFunc = func() string {
+		return "neighbor"
+	}
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
+	sendersManagerMock.SendersFunc = func() []application.Sender {
+		return []application.Sender{senderMock}
+	}
+	var validationTimestamp int64 = 11
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
+	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
+	now := 5 * validationTimestamp
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	utxosManagerMock.ClearFunc = func() {}
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(now-5*validationTimestamp, nil, nil, privateKey)
+	_ = blockchain.AddBlock(now-4*validationTimestamp, nil, nil, privateKey)
+	blocks := blockchain.Blocks(0)
+	genesisBlockHash := blocks[1].PreviousHash()
+	block1 := ledger.NewRewardedBlock(genesisBlockHash, now-4*validationTimestamp, privateKey)
+	hash1, _ := block1.Hash()
+	block2 := ledger.NewRewardedBlock(hash1, now-3*validationTimestamp, privateKey)
+	hash2, _ := block2.Hash()
+	block3 := ledger.NewRewardedBlock(hash2, now-2*validationTimestamp, privateKey)
+	hash3, _ := block3.Hash()
+	block4 := ledger.NewRewardedBlock(hash3, now-validationTimestamp, privateKey)
+	neighborBlocks := []*ledger.Block{blocks[0], block1, block2, block3, block4}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		endingBlockHeight := startingBlockHeight + 2
+		if endingBlockHeight > uint64(len(neighborBlocks)) {
+			endingBlockHeight = uint64(len(neighborBlocks))
+		}
+		return json.Marshal(neighborBlocks[startingBlockHeight:endingBlockHeight])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
+	}
 
 	// Act
-	actualTimestamp := blockchain.LastBlockTimestamp()
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
-	var expectedTimestamp int64 = 0
-	test.Assert(t, actualTimestamp == expectedTimestamp, fmt.Sprintf("timestamp is %d whereas it should be %d", actualTimestamp, expectedTimestamp))
+	expectedMessages := []string{
+		blockchainReplacedMessage,
+	}
+	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), expectedMessages...)
 }
 
-func Test_LastBlockTimestamp_BlockchainIsNotEmpty_ReturnsLastBlockTimestamp(t *testing.T) {
+func Test_Update_NeighborReturnsEndlessOldBlocks_BlocksRequestsBounded(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
+	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
 	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
 	registryMock.RemovedAddressesFunc = func() []string { return nil }
 	registryMock.UpdateFunc = func([]string, []string) {}
-	logger := log.NewLoggerMock()
+	senderMock := new(application.SenderMock)
+	senderMock.TargetFunc = func() string {
+		return "neighbor"
+	}
+	var penalizedMisbehavior application.Misbehavior
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(_ string, misbehavior application.Misbehavior) { penalizedMisbehavior = misbehavior }
+	sendersManagerMock.SendersFunc = func() []application.Sender {
+		return []application.Sender{senderMock}
+	}
+	var validationTimestamp int64 = 11
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
+	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
+	now := 5 * validationTimestamp
 	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
-	var genesisTimestamp int64 = 0
-	var expectedTimestamp int64 = 1
-	_ = blockchain.AddBlock(genesisTimestamp, nil, nil)
-	_ = blockchain.AddBlock(expectedTimestamp, nil, nil)
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), log.NewLoggerMock())
+	_ = blockchain.AddBlock(now-5*validationTimestamp, nil, nil, privateKey)
+	_ = blockchain.AddBlock(now-4*validationTimestamp, nil, nil, privateKey)
+	blocks := blockchain.Blocks(0)
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		// Full pages of blocks far in the past, the last block timestamp never reaches the current timestamp
+		timestamp := int64(startingBlockHeight)
+		return json.Marshal([]*ledger.Block{
+			ledger.NewBlock([32]byte{}, nil, nil, timestamp, nil),
+			ledger.NewBlock([32]byte{}, nil, nil, timestamp+1, nil),
+		})
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(blocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
+	}
 
 	// Act
-	actualTimestamp := blockchain.LastBlockTimestamp()
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
-	test.Assert(t, actualTimestamp == expectedTimestamp, fmt.Sprintf("timestamp is %d whereas it should be %d", actualTimestamp, expectedTimestamp))
+	maxRequestsCount := 4
+	actualRequestsCount := len(senderMock.GetBlocksCalls())
+	test.Assert(t, actualRequestsCount <= maxRequestsCount, fmt.Sprintf("Too many blocks requests. Expected at most: %d - Actual: %d", maxRequestsCount, actualRequestsCount))
+	test.Assert(t, penalizedMisbehavior == application.InvalidBlocksMisbehavior, "Neighbor is not penalized for invalid blocks whereas it should be.")
 }
 
-func Test_LastBlockTransactions_BlockchainIsEmpty_ReturnsEmptyArray(t *testing.T) {
+func Test_Update_NeighborBlockchainSharesHostBlocks_OnlyMissingBlocksRequested(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	blocksStorageMock.TruncateFunc = func(uint64) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
+	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
 	logger := log.NewLoggerMock()
+	senderMock := new(application.SenderMock)
+	senderMock.TargetFunc = func() string {
+		return "neighbor"
+	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
+	sendersManagerMock.SendersFunc = func() []application.Sender {
+		return []application.Sender{senderMock}
+	}
+	var validationTimestamp int64 = 11
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 3 }
+	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
+	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
+	now := 4 * validationTimestamp
 	utxosManagerMock := new(application.UtxosManagerMock)
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	for i := int64(4); i > 1; i-- {
+		rewardTransaction, _ := ledger.NewRewardTransaction(test.Address, false, now-i*validationTimestamp, 0)
+		_ = blockchain.AddBlock(now-i*validationTimestamp, []*ledger.Transaction{rewardTransaction}, nil, privateKey)
+	}
+	blocks := blockchain.Blocks(0)
+	hash2, _ := blocks[2].Hash()
+	block3 := ledger.NewRewardedBlock(hash2, now-validationTimestamp, privateKey)
+	neighborBlocks := []*ledger.Block{blocks[0], blocks[1], blocks[2], block3}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
+	}
 
 	// Act
-	actualTransactions := blockchain.LastBlockTransactions()
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
-	expectedTransactionsLength := 0
-	test.Assert(t, len(actualTransactions) == expectedTransactionsLength, fmt.Sprintf("transactions length is %d whereas it should be %d", len(actualTransactions), expectedTransactionsLength))
+	getBlocksCalls := senderMock.GetBlocksCalls()
+	test.Assert(t, len(getBlocksCalls) == 1, fmt.Sprintf("Wrong blocks requests count. Expected: 1 - Actual: %d", len(getBlocksCalls)))
+	var expectedStartingBlockHeight uint64 = 2
+	actualStartingBlockHeight := getBlocksCalls[0].StartingBlockHeight
+	test.Assert(t, actualStartingBlockHeight == expectedStartingBlockHeight, fmt.Sprintf("Wrong starting block height. Expected: %d - Actual: %d", expectedStartingBlockHeight, actualStartingBlockHeight))
+	expectedMessages := []string{
+		blockchainReplacedMessage,
+	}
+	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), expectedMessages...)
 }
 
-func Test_LastBlockTransactions_BlockchainIsNotEmpty_ReturnsLastBlockTimestamp(t *testing.T) {
+func Test_Update_NeighborBlockchainForksFromHost_AbandonedBlocksReverted(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	blocksStorageMock.TruncateFunc = func(uint64) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
+	registryMock.ClearFunc = func() {}
+	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
 	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
 	registryMock.RemovedAddressesFunc = func() []string { return nil }
 	registryMock.UpdateFunc = func([]string, []string) {}
 	logger := log.NewLoggerMock()
+	senderMock := new(application.SenderMock)
+	senderMock.TargetFunc = func() string {
+		return "neighbor"
+	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
+	sendersManagerMock.SendersFunc = func() []application.Sender {
+		return []application.Sender{senderMock}
+	}
+	var validationTimestamp int64 = 11
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
+	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
+	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
+	now := 5 * validationTimestamp
 	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.ClearFunc = func() {}
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.RevertUtxosFunc = func([]*ledger.Transaction, []*ledger.Utxo) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
-	var genesisTimestamp int64 = 0
-	var timestamp int64 = 1
-	_ = blockchain.AddBlock(genesisTimestamp, nil, nil)
-	transaction, _ := ledger.NewRewardTransaction("", false, timestamp, 0)
-	expectedTransactionId := transaction.Id()
-	_ = blockchain.AddBlock(timestamp, []*ledger.Transaction{transaction}, nil)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	abandonedTransaction := ledger.NewSignedTransaction(1, 0, 0, "A", privateKey, publicKey, now-4*validationTimestamp, "0", 1, false)
+	_ = blockchain.AddBlock(now-5*validationTimestamp, nil, nil, privateKey)
+	_ = blockchain.AddBlock(now-4*validationTimestamp, []*ledger.Transaction{abandonedTransaction}, nil, privateKey)
+	_ = blockchain.AddBlock(now-3*validationTimestamp, nil, nil, privateKey)
+	blocks := blockchain.Blocks(0)
+	genesisBlockHash := blocks[1].PreviousHash()
+	block1 := ledger.NewRewardedBlock(genesisBlockHash, now-4*validationTimestamp, privateKey)
+	hash1, _ := block1.Hash()
+	block2 := ledger.NewRewardedBlock(hash1, now-3*validationTimestamp, privateKey)
+	hash2, _ := block2.Hash()
+	block3 := ledger.NewRewardedBlock(hash2, now-2*validationTimestamp, privateKey)
+	hash3, _ := block3.Hash()
+	block4 := ledger.NewRewardedBlock(hash3, now-validationTimestamp, privateKey)
+	neighborBlocks := []*ledger.Block{blocks[0], block1, block2, block3, block4}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
+	}
 
 	// Act
-	actualTransactions := blockchain.LastBlockTransactions()
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
-	actualTransactionId := actualTransactions[0].Id()
-	test.Assert(t, actualTransactionId == expectedTransactionId, fmt.Sprintf("transactions ID is %s whereas it should be %s", actualTransactionId, expectedTransactionId))
+	expectedMessages := []string{
+		blockchainReplacedMessage,
+	}
+	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), expectedMessages...)
+	test.Assert(t, len(utxosManagerMock.ClearCalls()) == 0, "UTXOs are cleared whereas they should be reverted")
+	test.Assert(t, len(utxosManagerMock.RevertUtxosCalls()) > 0, "UTXOs are not reverted whereas they should be")
+	abandonedTransactions := blockchain.AbandonedTransactions()
+	test.Assert(t, len(abandonedTransactions) == 1 && abandonedTransactions[0] == abandonedTransaction, "abandoned transaction is not returned")
+	test.Assert(t, len(blockchain.AbandonedTransactions()) == 0, "abandoned transactions are returned twice")
+	_, err := blockchain.TransactionById(abandonedTransaction.Id())
+	test.Assert(t, err != nil, "abandoned transaction is still indexed")
+	indexedTransaction, err := blockchain.TransactionById(block4.Transactions()[0].Id())
+	test.Assert(t, err == nil && indexedTransaction.BlockHeight() == 4, "new branch transaction is not indexed")
 }
 
-func Test_Update_NeighborBlockchainIsBetter_IsReplaced(t *testing.T) {
+func Test_Update_NeighborBlockchainFailsToApplyMidway_HostStateRestored(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	blocksStorageMock.TruncateFunc = func(uint64) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.ClearFunc = func() {}
 	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
@@ -272,73 +1449,111 @@ func Test_Update_NeighborBlockchainIsBetter_IsReplaced(t *testing.T) {
 		return "neighbor"
 	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
 	sendersManagerMock.SendersFunc = func() []application.Sender {
 		return []application.Sender{senderMock}
 	}
 	var validationTimestamp int64 = 11
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
-	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
 	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
 	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
 	now := 5 * validationTimestamp
+	verificationUtxosManagerMock := new(application.UtxosManagerMock)
+	verificationUtxosManagerMock.ClearFunc = func() {}
+	verificationUtxosManagerMock.CopyFunc = func() application.UtxosManager { return verificationUtxosManagerMock }
+	verificationUtxosManagerMock.RevertUtxosFunc = func([]*ledger.Transaction, []*ledger.Utxo) error { return nil }
+	verificationUtxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	verificationUtxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	failingBlockTimestamp := now - 3*validationTimestamp
 	utxosManagerMock := new(application.UtxosManagerMock)
-	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
-	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
 	utxosManagerMock.ClearFunc = func() {}
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
-	_ = blockchain.AddBlock(now-5*validationTimestamp, nil, nil)
-	_ = blockchain.AddBlock(now-4*validationTimestamp, nil, nil)
-	blocks := blockchain.Blocks(0)
-	genesisBlockHash := blocks[1].PreviousHash()
-	block1 := ledger.NewRewardedBlock(genesisBlockHash, now-4*validationTimestamp)
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return verificationUtxosManagerMock }
+	utxosManagerMock.RevertUtxosFunc = func([]*ledger.Transaction, []*ledger.Utxo) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	utxosManagerMock.UpdateUtxosFunc = func(_ []*ledger.Transaction, timestamp int64) error {
+		// The neighbor block is valid on the verification copy but fails to be applied to the host state
+		if timestamp == failingBlockTimestamp {
+			return errors.New("failed to apply block")
+		}
+		return nil
+	}
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	revertedTransaction := ledger.NewSignedTransaction(1, 0, 0, "A", privateKey, publicKey, now-4*validationTimestamp, "0", 1, false)
+	_ = blockchain.AddBlock(now-5*validationTimestamp, nil, nil, privateKey)
+	_ = blockchain.AddBlock(now-4*validationTimestamp, []*ledger.Transaction{revertedTransaction}, nil, privateKey)
+	_ = blockchain.AddBlock(now-3*validationTimestamp, nil, nil, privateKey)
+	hostBlocks := blockchain.Blocks(0)
+	genesisBlockHash := hostBlocks[1].PreviousHash()
+	block1 := ledger.NewRewardedBlock(genesisBlockHash, now-4*validationTimestamp, privateKey)
 	hash1, _ := block1.Hash()
-	block2 := ledger.NewRewardedBlock(hash1, now-3*validationTimestamp)
+	block2 := ledger.NewRewardedBlock(hash1, now-3*validationTimestamp, privateKey)
 	hash2, _ := block2.Hash()
-	block3 := ledger.NewRewardedBlock(hash2, now-2*validationTimestamp)
+	block3 := ledger.NewRewardedBlock(hash2, now-2*validationTimestamp, privateKey)
 	hash3, _ := block3.Hash()
-	block4 := ledger.NewRewardedBlock(hash3, now-validationTimestamp)
-	neighborBlocks := []*ledger.Block{blocks[0], block1, block2, block3, block4}
-	neighborBlocksBytes, _ := json.Marshal(neighborBlocks)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) {
-		return neighborBlocksBytes, nil
+	block4 := ledger.NewRewardedBlock(hash3, now-validationTimestamp, privateKey)
+	neighborBlocks := []*ledger.Block{hostBlocks[0], block1, block2, block3, block4}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
 	}
 
 	// Act
-	blockchain.Update(now)
+	err := blockchain.Update(context.Background(), now)
 
 	// Assert
-	expectedMessages := []string{
-		blockchainReplacedMessage,
-	}
-	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), expectedMessages...)
+	test.Assert(t, err != nil, "Error is nil whereas it should not.")
+	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), blockchainKeptMessage)
+	blocks := blockchain.Blocks(0)
+	test.Assert(t, len(blocks) == len(hostBlocks) && blocks[len(blocks)-1] == hostBlocks[len(hostBlocks)-1], "Host blocks are replaced whereas they should not.")
+	revertUtxosCalls := utxosManagerMock.RevertUtxosCalls()
+	test.Assert(t, len(revertUtxosCalls) == 2, fmt.Sprintf("Wrong reverted blocks count. Expected: 2 - Actual: %d", len(revertUtxosCalls)))
+	updateUtxosCalls := utxosManagerMock.UpdateUtxosCalls()
+	lastUpdateUtxosCall := updateUtxosCalls[len(updateUtxosCalls)-1]
+	isRevertedBlockApplied := len(lastUpdateUtxosCall.Transactions) == 1 && lastUpdateUtxosCall.Transactions[0] == revertedTransaction
+	test.Assert(t, isRevertedBlockApplied, "Reverted host block is not applied again.")
+	expectedUndoRecordsCount := len(hostBlocks) - 1
+	test.Assert(t, len(blockchain.undoRecords) == expectedUndoRecordsCount, fmt.Sprintf("Wrong undo records count. Expected: %d - Actual: %d", expectedUndoRecordsCount, len(blockchain.undoRecords)))
 }
 
 func Test_Update_NeighborNewBlockTimestampIsInvalid_IsNotReplaced(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.ClearFunc = func() {}
 	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
 	registryMock.FilterFunc = func([]string) []string { return nil }
 	registryMock.IsRegisteredFunc = func(string) bool { return true }
 	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
 	logger := log.NewLoggerMock()
 	senderMock := new(application.SenderMock)
 	senderMock.TargetFunc = func() string {
 		return "neighbor"
 	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
 	sendersManagerMock.SendersFunc = func() []application.Sender {
 		return []application.Sender{senderMock}
 	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
 	settings.ValidationTimestampFunc = func() int64 { return 1 }
 	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.ClearFunc = func() {}
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
-	_ = blockchain.AddBlock(0, nil, nil)
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
 
 	type args struct {
 		firstBlockTimestamp  int64
@@ -380,17 +1595,20 @@ func Test_Update_NeighborNewBlockTimestampIsInvalid_IsNotReplaced(t *testing.T)
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			senderMock.GetBlocksFunc = func(uint64) ([]byte, error) {
-				block1 := ledger.NewRewardedBlock([32]byte{}, tt.args.firstBlockTimestamp)
-				hash, _ := block1.Hash()
-				block2 := ledger.NewRewardedBlock(hash, tt.args.secondBlockTimestamp)
-				blocks := []*ledger.Block{block1, block2}
-				blockBytes, _ := json.Marshal(blocks)
-				return blockBytes, nil
+			block1 := ledger.NewRewardedBlock([32]byte{}, tt.args.firstBlockTimestamp, privateKey)
+			hash, _ := block1.Hash()
+			block2 := ledger.NewRewardedBlock(hash, tt.args.secondBlockTimestamp, privateKey)
+			neighborBlocks := []*ledger.Block{block1, block2}
+			senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+				return json.Marshal(neighborBlocks[startingBlockHeight:])
+			}
+			senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+				headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+				return json.Marshal(headers)
 			}
 
 			// Act
-			blockchain.Update(1)
+			_ = blockchain.Update(context.Background(), 1)
 
 			// Assert
 			expectedMessages := []string{
@@ -404,43 +1622,54 @@ func Test_Update_NeighborNewBlockTimestampIsInvalid_IsNotReplaced(t *testing.T)
 
 func Test_Update_NeighborNewBlockTimestampIsInTheFuture_IsNotReplaced(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.ClearFunc = func() {}
 	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
 	registryMock.FilterFunc = func([]string) []string { return nil }
 	registryMock.IsRegisteredFunc = func(string) bool { return true }
 	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
 	logger := log.NewLoggerMock()
 	senderMock := new(application.SenderMock)
 	var validationTimestamp int64 = 1
 	now := validationTimestamp
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) {
-		block1 := ledger.NewRewardedBlock([32]byte{}, now)
-		hash, _ := block1.Hash()
-		block2 := ledger.NewRewardedBlock(hash, now+validationTimestamp)
-		blocks := []*ledger.Block{block1, block2}
-		blockBytes, _ := json.Marshal(blocks)
-		return blockBytes, nil
+	block1 := ledger.NewRewardedBlock([32]byte{}, now, privateKey)
+	hash, _ := block1.Hash()
+	block2 := ledger.NewRewardedBlock(hash, now+validationTimestamp, privateKey)
+	neighborBlocks := []*ledger.Block{block1, block2}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
 	}
 	senderMock.TargetFunc = func() string {
 		return "neighbor"
 	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
 	sendersManagerMock.SendersFunc = func() []application.Sender {
 		return []application.Sender{senderMock}
 	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
 	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
 	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.ClearFunc = func() {}
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
-	_ = blockchain.AddBlock(0, nil, nil)
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
 
 	// Act
-	blockchain.Update(now)
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
 	expectedMessages := []string{
@@ -448,10 +1677,15 @@ func Test_Update_NeighborNewBlockTimestampIsInTheFuture_IsNotReplaced(t *testing
 		blockchainKeptMessage,
 	}
 	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), expectedMessages...)
+	penalizeCalls := sendersManagerMock.PenalizeCalls()
+	isPenalized := len(penalizeCalls) == 1 && penalizeCalls[0].Misbehavior == application.InvalidBlocksMisbehavior
+	test.Assert(t, isPenalized, "Neighbor is not penalized for invalid blocks whereas it should be.")
 }
 
 func Test_Update_NeighborNewBlockTransactionFeeCalculationFails_IsNotReplaced(t *testing.T) {
 	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.ClearFunc = func() {}
 	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
@@ -471,7 +1705,7 @@ func Test_Update_NeighborNewBlockTransactionFeeCalculationFails_IsNotReplaced(t
 	genesisAmount := 2 * incomeLimit
 	block1 := ledger.NewGenesisBlock(address, genesisAmount)
 	hash1, _ := block1.Hash()
-	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp)
+	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp, privateKey)
 	hash2, _ := block2.Hash()
 	genesisTransaction := block1.Transactions()[0]
 	var genesisOutputIndex uint16 = 0
@@ -481,26 +1715,33 @@ func Test_Update_NeighborNewBlockTransactionFeeCalculationFails_IsNotReplaced(t
 		invalidTransaction,
 		rewardTransaction,
 	}
-	block3 := ledger.NewBlock(hash2, []string{address}, nil, now, transactions)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) {
-		blocks := []*ledger.Block{block1, block2, block3}
-		blocksBytes, _ := json.Marshal(blocks)
-		return blocksBytes, nil
+	block3, _ := ledger.NewSignedBlock(hash2, []string{address}, nil, now, transactions, privateKey)
+	neighborBlocks := []*ledger.Block{block1, block2, block3}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
 	}
 	senderMock.TargetFunc = func() string {
 		return "neighbor"
 	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
 	sendersManagerMock.SendersFunc = func() []application.Sender {
 		return []application.Sender{senderMock}
 	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
 	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
 	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.ClearFunc = func() {}
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
 	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, timestamp int64) (uint64, error) {
 		if transaction.Id() == invalidTransaction.Id() {
 			return 0, errors.New("")
@@ -508,11 +1749,11 @@ func Test_Update_NeighborNewBlockTransactionFeeCalculationFails_IsNotReplaced(t
 			return 0, nil
 		}
 	}
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
-	_ = blockchain.AddBlock(0, nil, nil)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
 
 	// Act
-	blockchain.Update(now)
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
 	expectedMessages := []string{
@@ -524,6 +1765,8 @@ func Test_Update_NeighborNewBlockTransactionFeeCalculationFails_IsNotReplaced(t
 
 func Test_Update_NeighborNewBlockTransactionTimestampIsTooFarInTheFuture_IsNotReplaced(t *testing.T) {
 	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.ClearFunc = func() {}
 	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
@@ -545,39 +1788,46 @@ func Test_Update_NeighborNewBlockTransactionTimestampIsTooFarInTheFuture_IsNotRe
 	genesisTransaction := block1.Transactions()[0]
 	invalidTransaction := ledger.NewSignedTransaction(genesisAmount, transactionFee, genesisOutputIndex, "A", privateKey, publicKey, now+validationTimestamp, genesisTransaction.Id(), genesisAmount, false)
 	hash1, _ := block1.Hash()
-	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp)
+	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp, privateKey)
 	hash2, _ := block2.Hash()
 	rewardTransaction, _ := ledger.NewRewardTransaction(address, false, now, 0)
 	transactions := []*ledger.Transaction{
 		invalidTransaction,
 		rewardTransaction,
 	}
-	block3 := ledger.NewBlock(hash2, []string{address}, nil, now, transactions)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) {
-		blocks := []*ledger.Block{block1, block2, block3}
-		blocksBytes, _ := json.Marshal(blocks)
-		return blocksBytes, nil
+	block3, _ := ledger.NewSignedBlock(hash2, []string{address}, nil, now, transactions, privateKey)
+	neighborBlocks := []*ledger.Block{block1, block2, block3}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
 	}
 	senderMock.TargetFunc = func() string {
 		return "neighbor"
 	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
 	sendersManagerMock.SendersFunc = func() []application.Sender {
 		return []application.Sender{senderMock}
 	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
 	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
 	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.ClearFunc = func() {}
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
 	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, timestamp int64) (uint64, error) { return 0, nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
-	_ = blockchain.AddBlock(0, nil, nil)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
 
 	// Act
-	blockchain.Update(now)
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
 	expectedMessages := []string{
@@ -589,6 +1839,8 @@ func Test_Update_NeighborNewBlockTransactionTimestampIsTooFarInTheFuture_IsNotRe
 
 func Test_Update_NeighborNewBlockTransactionTimestampIsTooOld_IsNotReplaced(t *testing.T) {
 	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.ClearFunc = func() {}
 	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
@@ -608,41 +1860,48 @@ func Test_Update_NeighborNewBlockTransactionTimestampIsTooOld_IsNotReplaced(t *t
 	block1 := ledger.NewGenesisBlock(address, genesisAmount)
 	var genesisOutputIndex uint16 = 0
 	genesisTransaction := block1.Transactions()[0]
-	invalidTransaction := ledger.NewSignedTransaction(genesisAmount, transactionFee, genesisOutputIndex, "A", privateKey, publicKey, now-validationTimestamp-1, genesisTransaction.Id(), genesisAmount, false)
+	invalidTransaction := ledger.NewSignedTransaction(genesisAmount, transactionFee, genesisOutputIndex, "A", privateKey, publicKey, now-2*validationTimestamp-1, genesisTransaction.Id(), genesisAmount, false)
 	hash1, _ := block1.Hash()
-	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp)
+	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp, privateKey)
 	hash2, _ := block2.Hash()
 	rewardTransaction, _ := ledger.NewRewardTransaction(address, false, now, 0)
 	transactions := []*ledger.Transaction{
 		invalidTransaction,
 		rewardTransaction,
 	}
-	block3 := ledger.NewBlock(hash2, []string{address}, nil, now, transactions)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) {
-		blocks := []*ledger.Block{block1, block2, block3}
-		blocksBytes, _ := json.Marshal(blocks)
-		return blocksBytes, nil
+	block3, _ := ledger.NewSignedBlock(hash2, []string{address}, nil, now, transactions, privateKey)
+	neighborBlocks := []*ledger.Block{block1, block2, block3}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
 	}
 	senderMock.TargetFunc = func() string {
 		return "neighbor"
 	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
 	sendersManagerMock.SendersFunc = func() []application.Sender {
 		return []application.Sender{senderMock}
 	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
 	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
 	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.ClearFunc = func() {}
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
 	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, timestamp int64) (uint64, error) { return 0, nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
-	_ = blockchain.AddBlock(0, nil, nil)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
 
 	// Act
-	blockchain.Update(now)
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
 	expectedMessages := []string{
@@ -654,6 +1913,8 @@ func Test_Update_NeighborNewBlockTransactionTimestampIsTooOld_IsNotReplaced(t *t
 
 func Test_Update_NeighborNewBlockTransactionInputSignatureIsInvalid_IsNotReplaced(t *testing.T) {
 	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.ClearFunc = func() {}
 	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
@@ -676,39 +1937,46 @@ func Test_Update_NeighborNewBlockTransactionInputSignatureIsInvalid_IsNotReplace
 	genesisTransaction := block1.Transactions()[0]
 	invalidTransaction := ledger.NewSignedTransaction(genesisAmount, transactionFee, genesisOutputIndex, "A", privateKey2, publicKey, now-validationTimestamp, genesisTransaction.Id(), genesisAmount, false)
 	hash1, _ := block1.Hash()
-	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp)
+	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp, privateKey)
 	hash2, _ := block2.Hash()
 	rewardTransaction, _ := ledger.NewRewardTransaction(address, false, now, 0)
 	transactions := []*ledger.Transaction{
 		invalidTransaction,
 		rewardTransaction,
 	}
-	block3 := ledger.NewBlock(hash2, []string{address}, nil, now, transactions)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) {
-		blocks := []*ledger.Block{block1, block2, block3}
-		blocksBytes, _ := json.Marshal(blocks)
-		return blocksBytes, nil
+	block3, _ := ledger.NewSignedBlock(hash2, []string{address}, nil, now, transactions, privateKey)
+	neighborBlocks := []*ledger.Block{block1, block2, block3}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
 	}
 	senderMock.TargetFunc = func() string {
 		return "neighbor"
 	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
 	sendersManagerMock.SendersFunc = func() []application.Sender {
 		return []application.Sender{senderMock}
 	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
 	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
 	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.ClearFunc = func() {}
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
 	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, timestamp int64) (uint64, error) { return 0, nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
-	_ = blockchain.AddBlock(0, nil, nil)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
 
 	// Act
-	blockchain.Update(now)
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
 	expectedMessages := []string{
@@ -720,6 +1988,9 @@ func Test_Update_NeighborNewBlockTransactionInputSignatureIsInvalid_IsNotReplace
 
 func Test_Update_NeighborBlockYieldingOutputAddressIsRegistered_IsReplaced(t *testing.T) {
 	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	blocksStorageMock.TruncateFunc = func(uint64) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.ClearFunc = func() {}
 	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
@@ -741,39 +2012,46 @@ func Test_Update_NeighborBlockYieldingOutputAddressIsRegistered_IsReplaced(t *te
 	genesisTransaction := block1.Transactions()[0]
 	invalidTransaction := ledger.NewSignedTransaction(genesisAmount, transactionFee, genesisOutputIndex, address, privateKey, publicKey, now-validationTimestamp, genesisTransaction.Id(), genesisAmount, true)
 	hash1, _ := block1.Hash()
-	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp)
+	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp, privateKey)
 	hash2, _ := block2.Hash()
 	rewardTransaction, _ := ledger.NewRewardTransaction(address, false, now, 0)
 	transactions := []*ledger.Transaction{
 		invalidTransaction,
 		rewardTransaction,
 	}
-	block3 := ledger.NewBlock(hash2, nil, nil, now, transactions)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) {
-		blocks := []*ledger.Block{block1, block2, block3}
-		blocksBytes, _ := json.Marshal(blocks)
-		return blocksBytes, nil
+	block3, _ := ledger.NewSignedBlock(hash2, nil, nil, now, transactions, privateKey)
+	neighborBlocks := []*ledger.Block{block1, block2, block3}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
 	}
 	senderMock.TargetFunc = func() string {
 		return "neighbor"
 	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
 	sendersManagerMock.SendersFunc = func() []application.Sender {
 		return []application.Sender{senderMock}
 	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
 	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
 	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.ClearFunc = func() {}
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
 	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, timestamp int64) (uint64, error) { return 0, nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
-	_ = blockchain.AddBlock(0, nil, nil)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
 
 	// Act
-	blockchain.Update(now)
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
 	expectedMessages := []string{
@@ -784,6 +2062,9 @@ func Test_Update_NeighborBlockYieldingOutputAddressIsRegistered_IsReplaced(t *te
 
 func Test_Update_NeighborBlockYieldingOutputAddressHasBeenRecentlyAdded_IsReplaced(t *testing.T) {
 	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	blocksStorageMock.TruncateFunc = func(uint64) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.ClearFunc = func() {}
 	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
@@ -806,39 +2087,46 @@ func Test_Update_NeighborBlockYieldingOutputAddressHasBeenRecentlyAdded_IsReplac
 	genesisTransaction := block1.Transactions()[0]
 	invalidTransaction := ledger.NewSignedTransaction(genesisAmount, transactionFee, genesisOutputIndex, addedAddress, privateKey, publicKey, now-validationTimestamp, genesisTransaction.Id(), genesisAmount, true)
 	hash1, _ := block1.Hash()
-	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp)
+	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp, privateKey)
 	hash2, _ := block2.Hash()
 	rewardTransaction, _ := ledger.NewRewardTransaction(address, false, now, 0)
 	transactions := []*ledger.Transaction{
 		invalidTransaction,
 		rewardTransaction,
 	}
-	block3 := ledger.NewBlock(hash2, []string{addedAddress}, nil, now, transactions)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) {
-		blocks := []*ledger.Block{block1, block2, block3}
-		blocksBytes, _ := json.Marshal(blocks)
-		return blocksBytes, nil
+	block3, _ := ledger.NewSignedBlock(hash2, []string{addedAddress}, nil, now, transactions, privateKey)
+	neighborBlocks := []*ledger.Block{block1, block2, block3}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
 	}
 	senderMock.TargetFunc = func() string {
 		return "neighbor"
 	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
 	sendersManagerMock.SendersFunc = func() []application.Sender {
 		return []application.Sender{senderMock}
 	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
 	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
 	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.ClearFunc = func() {}
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
 	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, timestamp int64) (uint64, error) { return 0, nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
-	_ = blockchain.AddBlock(0, nil, nil)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
 
 	// Act
-	blockchain.Update(now)
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
 	expectedMessages := []string{
@@ -849,6 +2137,8 @@ func Test_Update_NeighborBlockYieldingOutputAddressHasBeenRecentlyAdded_IsReplac
 
 func Test_Update_NeighborBlockYieldingOutputIsNotRegistered_IsNotReplaced(t *testing.T) {
 	// Arrange
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.ClearFunc = func() {}
 	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
@@ -871,39 +2161,46 @@ func Test_Update_NeighborBlockYieldingOutputIsNotRegistered_IsNotReplaced(t *tes
 	genesisTransaction := block1.Transactions()[0]
 	invalidTransaction := ledger.NewSignedTransaction(genesisAmount, transactionFee, genesisOutputIndex, removedAddress, privateKey, publicKey, now-validationTimestamp, genesisTransaction.Id(), genesisAmount, true)
 	hash1, _ := block1.Hash()
-	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp)
+	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp, privateKey)
 	hash2, _ := block2.Hash()
 	rewardTransaction, _ := ledger.NewRewardTransaction(address, false, now, 0)
 	transactions := []*ledger.Transaction{
 		invalidTransaction,
 		rewardTransaction,
 	}
-	block3 := ledger.NewBlock(hash2, nil, []string{removedAddress}, now, transactions)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) {
-		blocks := []*ledger.Block{block1, block2, block3}
-		blocksBytes, _ := json.Marshal(blocks)
-		return blocksBytes, nil
+	block3, _ := ledger.NewSignedBlock(hash2, nil, []string{removedAddress}, now, transactions, privateKey)
+	neighborBlocks := []*ledger.Block{block1, block2, block3}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
 	}
 	senderMock.TargetFunc = func() string {
 		return "neighbor"
 	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
 	sendersManagerMock.SendersFunc = func() []application.Sender {
 		return []application.Sender{senderMock}
 	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 10 }
 	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
 	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
 	utxosManagerMock := new(application.UtxosManagerMock)
 	utxosManagerMock.ClearFunc = func() {}
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
 	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, timestamp int64) (uint64, error) { return 0, nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
-	_ = blockchain.AddBlock(0, nil, nil)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	_ = blockchain.AddBlock(0, nil, nil, privateKey)
 
 	// Act
-	blockchain.Update(now)
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
 	expectedMessages := []string{
@@ -915,6 +2212,10 @@ func Test_Update_NeighborBlockYieldingOutputIsNotRegistered_IsNotReplaced(t *tes
 
 func Test_Update_NeighborValidatorIsNotTheOldest_IsNotReplaced(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	otherPrivateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey2)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
 	registryMock.FilterFunc = func([]string) []string { return nil }
@@ -929,37 +2230,44 @@ func Test_Update_NeighborValidatorIsNotTheOldest_IsNotReplaced(t *testing.T) {
 		return "neighbor"
 	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
 	sendersManagerMock.SendersFunc = func() []application.Sender {
 		return []application.Sender{senderMock}
 	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.BlocksCountLimitFunc = func() uint64 { return 1 }
 	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
 	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
 	utxosManagerMock := new(application.UtxosManagerMock)
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
 	rewardTransaction1, _ := ledger.NewRewardTransaction(test.Address, false, now-2*validationTimestamp, 0)
+	utxosManagerMock.RevertUtxosFunc = func([]*ledger.Transaction, []*ledger.Utxo) error { return nil }
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
 	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, timestamp int64) (uint64, error) { return 0, nil }
-	_ = blockchain.AddBlock(now-2*validationTimestamp, []*ledger.Transaction{rewardTransaction1}, nil)
+	_ = blockchain.AddBlock(now-2*validationTimestamp, []*ledger.Transaction{rewardTransaction1}, nil, privateKey)
 	blocks := blockchain.Blocks(0)
-	rewardTransaction2, _ := ledger.NewRewardTransaction(test.Address, false, now-validationTimestamp, 0)
-	_ = blockchain.AddBlock(now-validationTimestamp, []*ledger.Transaction{rewardTransaction2}, nil)
+	rewardTransaction2, _ := ledger.NewRewardTransaction(test.Address2, false, now-validationTimestamp, 0)
+	_ = blockchain.AddBlock(now-validationTimestamp, []*ledger.Transaction{rewardTransaction2}, nil, otherPrivateKey)
 	rewardTransaction3, _ := ledger.NewRewardTransaction(test.Address, false, now, 0)
-	_ = blockchain.AddBlock(now, []*ledger.Transaction{rewardTransaction3}, nil)
+	_ = blockchain.AddBlock(now, []*ledger.Transaction{rewardTransaction3}, nil, privateKey)
 	hash1, _ := blocks[0].Hash()
-	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp)
+	block2 := ledger.NewRewardedBlock(hash1, now-validationTimestamp, privateKey)
 	hash2, _ := block2.Hash()
-	block3 := ledger.NewRewardedBlock(hash2, now)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) {
-		neighborBlocks := []*ledger.Block{block3}
-		neighborBlocksBytes, _ := json.Marshal(neighborBlocks)
-		return neighborBlocksBytes, nil
+	block3 := ledger.NewRewardedBlock(hash2, now, privateKey)
+	neighborBlocks := []*ledger.Block{blocks[0], block2, block3}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
 	}
 
 	// Act
-	blockchain.Update(now)
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
 	expectedMessages := []string{
@@ -970,6 +2278,11 @@ func Test_Update_NeighborValidatorIsNotTheOldest_IsNotReplaced(t *testing.T) {
 
 func Test_Update_NeighborValidatorIsTheOldest_IsReplaced(t *testing.T) {
 	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	neighborPrivateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey2)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	blocksStorageMock.TruncateFunc = func(uint64) error { return nil }
 	registryMock := new(application.AddressesManagerMock)
 	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
 	registryMock.FilterFunc = func([]string) []string { return nil }
@@ -984,39 +2297,242 @@ func Test_Update_NeighborValidatorIsTheOldest_IsReplaced(t *testing.T) {
 		return "neighbor"
 	}
 	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
 	sendersManagerMock.SendersFunc = func() []application.Sender {
 		return []application.Sender{senderMock}
 	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
 	settings := new(application.ProtocolSettingsProviderMock)
 	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
 	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
 	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
 	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.RevertUtxosFunc = func([]*ledger.Transaction, []*ledger.Utxo) error { return nil }
 	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
 	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
-	blockchain := NewBlockchain(registryMock, settings, sendersManagerMock, utxosManagerMock, logger)
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
 	rewardTransaction1, _ := ledger.NewRewardTransaction(test.Address, false, now-2*validationTimestamp, 0)
 	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, timestamp int64) (uint64, error) { return 0, nil }
-	_ = blockchain.AddBlock(now-2*validationTimestamp, []*ledger.Transaction{rewardTransaction1}, nil)
+	_ = blockchain.AddBlock(now-2*validationTimestamp, []*ledger.Transaction{rewardTransaction1}, nil, privateKey)
 	rewardTransaction2, _ := ledger.NewRewardTransaction(test.Address, false, now-validationTimestamp, 0)
-	_ = blockchain.AddBlock(now-validationTimestamp, []*ledger.Transaction{rewardTransaction2}, nil)
+	_ = blockchain.AddBlock(now-validationTimestamp, []*ledger.Transaction{rewardTransaction2}, nil, privateKey)
 	blocks := blockchain.Blocks(0)
 	rewardTransaction3, _ := ledger.NewRewardTransaction(test.Address, false, now, 0)
-	_ = blockchain.AddBlock(now, []*ledger.Transaction{rewardTransaction3}, nil)
+	_ = blockchain.AddBlock(now, []*ledger.Transaction{rewardTransaction3}, nil, privateKey)
 	hash2, _ := blocks[1].Hash()
-	block3 := ledger.NewRewardedBlock(hash2, now)
-	senderMock.GetBlocksFunc = func(uint64) ([]byte, error) {
-		neighborBlocks := []*ledger.Block{block3}
-		neighborBlocksBytes, _ := json.Marshal(neighborBlocks)
-		return neighborBlocksBytes, nil
+	block3 := ledger.NewRewardedBlock(hash2, now, neighborPrivateKey)
+	neighborBlocks := []*ledger.Block{blocks[0], blocks[1], block3}
+	senderMock.GetBlocksFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		return json.Marshal(neighborBlocks[startingBlockHeight:])
+	}
+	senderMock.GetHeadersFunc = func(startingBlockHeight uint64) ([]byte, error) {
+		headers, _ := ledger.NewBlockHeaders(neighborBlocks[startingBlockHeight:], startingBlockHeight)
+		return json.Marshal(headers)
+	}
+
+	// Act
+	_ = blockchain.Update(context.Background(), now)
+
+	// Assert
+	expectedMessages := []string{
+		blockchainReplacedMessage,
+	}
+	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), expectedMessages...)
+}
+
+func Test_Update_NeighborAnnouncedBlock_IsReplacedWithoutPolling(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	neighborPrivateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey2)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	blocksStorageMock.TruncateFunc = func(uint64) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	logger := log.NewLoggerMock()
+	senderMock := new(application.SenderMock)
+	var validationTimestamp int64 = 1
+	now := 2 * validationTimestamp
+	senderMock.TargetFunc = func() string {
+		return "neighbor"
+	}
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
+	sendersManagerMock.SendersFunc = func() []application.Sender {
+		return []application.Sender{senderMock}
 	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
+	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.RevertUtxosFunc = func([]*ledger.Transaction, []*ledger.Utxo) error { return nil }
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	rewardTransaction1, _ := ledger.NewRewardTransaction(test.Address, false, now-2*validationTimestamp, 0)
+	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, timestamp int64) (uint64, error) { return 0, nil }
+	_ = blockchain.AddBlock(now-2*validationTimestamp, []*ledger.Transaction{rewardTransaction1}, nil, privateKey)
+	rewardTransaction2, _ := ledger.NewRewardTransaction(test.Address, false, now-validationTimestamp, 0)
+	_ = blockchain.AddBlock(now-validationTimestamp, []*ledger.Transaction{rewardTransaction2}, nil, privateKey)
+	blocks := blockchain.Blocks(0)
+	rewardTransaction3, _ := ledger.NewRewardTransaction(test.Address, false, now, 0)
+	_ = blockchain.AddBlock(now, []*ledger.Transaction{rewardTransaction3}, nil, privateKey)
+	hash2, _ := blocks[1].Hash()
+	block3 := ledger.NewRewardedBlock(hash2, now, neighborPrivateKey)
+	blockchain.AddAnnouncedBlock(block3, 2, "neighbor")
 
 	// Act
-	blockchain.Update(now)
+	_ = blockchain.Update(context.Background(), now)
 
 	// Assert
 	expectedMessages := []string{
 		blockchainReplacedMessage,
 	}
 	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), expectedMessages...)
+	isPolled := len(senderMock.GetHeadersCalls()) != 0 || len(senderMock.GetBlocksCalls()) != 0
+	test.Assert(t, !isPolled, "Neighbor is polled whereas its announced block is already verified.")
+}
+
+func Test_Update_NoNeighbor_UpdateObservedAsKept(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.SendersFunc = func() []application.Sender { return nil }
+	metricsRecorderMock := newMetricsRecorderMock()
+	blockchain := NewBlockchain(new(application.BlocksStorageMock), new(application.SnapshotStorageMock), 0, false, new(application.AddressesManagerMock), new(application.ProtocolSettingsProviderMock), sendersManagerMock, newBlocksRelayerMock(), new(application.UtxosManagerMock), metricsRecorderMock, log.NewLoggerMock())
+
+	// Act
+	_ = blockchain.Update(context.Background(), 0)
+
+	// Assert
+	observeBlockchainUpdateCalls := metricsRecorderMock.ObserveBlockchainUpdateCalls()
+	test.Assert(t, len(observeBlockchainUpdateCalls) == 1, "Update is not observed whereas it should be.")
+	test.Assert(t, !observeBlockchainUpdateCalls[0].IsReplaced, "Update is observed as a replacement whereas it should not.")
+}
+
+func Test_AddAnnouncedBlock_BlockTimestampIsInvalid_NeighborPenalized(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	neighborPrivateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey2)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	blocksStorageMock.TruncateFunc = func(uint64) error { return nil }
+	registryMock := new(application.AddressesManagerMock)
+	registryMock.CopyFunc = func() application.AddressesManager { return registryMock }
+	registryMock.FilterFunc = func([]string) []string { return nil }
+	registryMock.IsRegisteredFunc = func(string) bool { return true }
+	registryMock.RemovedAddressesFunc = func() []string { return nil }
+	registryMock.UpdateFunc = func([]string, []string) {}
+	logger := log.NewLoggerMock()
+	senderMock := new(application.SenderMock)
+	var validationTimestamp int64 = 1
+	now := 2 * validationTimestamp
+	senderMock.TargetFunc = func() string {
+		return "neighbor"
+	}
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
+	sendersManagerMock.SendersFunc = func() []application.Sender {
+		return []application.Sender{senderMock}
+	}
+	snapshotStorageMock := new(application.SnapshotStorageMock)
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	settings.ValidationTimestampFunc = func() int64 { return validationTimestamp }
+	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
+	utxosManagerMock := new(application.UtxosManagerMock)
+	utxosManagerMock.RevertUtxosFunc = func([]*ledger.Transaction, []*ledger.Utxo) error { return nil }
+	utxosManagerMock.CopyFunc = func() application.UtxosManager { return utxosManagerMock }
+	utxosManagerMock.UpdateUtxosFunc = func([]*ledger.Transaction, int64) error { return nil }
+	utxosManagerMock.SpentUtxosFunc = func([]*ledger.Transaction) []*ledger.Utxo { return nil }
+	blockchain := NewBlockchain(blocksStorageMock, snapshotStorageMock, 0, false, registryMock, settings, sendersManagerMock, newBlocksRelayerMock(), utxosManagerMock, newMetricsRecorderMock(), logger)
+	rewardTransaction1, _ := ledger.NewRewardTransaction(test.Address, false, now-2*validationTimestamp, 0)
+	utxosManagerMock.CalculateFeeFunc = func(transaction *ledger.Transaction, timestamp int64) (uint64, error) { return 0, nil }
+	_ = blockchain.AddBlock(now-2*validationTimestamp, []*ledger.Transaction{rewardTransaction1}, nil, privateKey)
+	rewardTransaction2, _ := ledger.NewRewardTransaction(test.Address, false, now-validationTimestamp, 0)
+	_ = blockchain.AddBlock(now-validationTimestamp, []*ledger.Transaction{rewardTransaction2}, nil, privateKey)
+	blocks := blockchain.Blocks(0)
+	rewardTransaction3, _ := ledger.NewRewardTransaction(test.Address, false, now, 0)
+	_ = blockchain.AddBlock(now, []*ledger.Transaction{rewardTransaction3}, nil, privateKey)
+	hash2, _ := blocks[1].Hash()
+	block3 := ledger.NewRewardedBlock(hash2, now+validationTimestamp, neighborPrivateKey)
+
+	// Act
+	blockchain.AddAnnouncedBlock(block3, 2, "neighbor")
+
+	// Assert
+	penalizeCalls := sendersManagerMock.PenalizeCalls()
+	test.Assert(t, len(penalizeCalls) == 1, "Neighbor is not penalized whereas it should be.")
+	test.Assert(t, penalizeCalls[0].Misbehavior == application.InvalidBlocksMisbehavior, "Neighbor is penalized for a wrong misbehavior.")
+}
+
+func Test_AddAnnouncedBlock_BlockSpendsTipOutput_BlockAccepted(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	neighborPrivateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey2)
+	blocksStorageMock := new(application.BlocksStorageMock)
+	blocksStorageMock.AddBlockFunc = func(*ledger.Block) error { return nil }
+	blocksStorageMock.TruncateFunc = func(uint64) error { return nil }
+	logger := log.NewLoggerMock()
+	metricsRecorderMock := newMetricsRecorderMock()
+	registry := NewAddressesRegistry(new(HumansManagerMock), metricsRecorderMock, logger)
+	senderMock := new(application.SenderMock)
+	senderMock.TargetFunc = func() string {
+		return "neighbor"
+	}
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.PenalizeFunc = func(string, application.Misbehavior) {}
+	sendersManagerMock.SendersFunc = func() []application.Sender {
+		return []application.Sender{senderMock}
+	}
+	settings := new(application.ProtocolSettingsProviderMock)
+	settings.BlocksCountLimitFunc = func() uint64 { return 2 }
+	settings.HalfLifeInNanosecondsFunc = func() float64 { return 1e12 }
+	settings.IncomeBaseFunc = func() uint64 { return 0 }
+	settings.IncomeLimitFunc = func() uint64 { return 0 }
+	settings.MinimalTransactionFeeFunc = func() uint64 { return 0 }
+	settings.ValidationTimestampFunc = func() int64 { return 1 }
+	settings.ValidationTimeoutFunc = func() time.Duration { return time.Second }
+	utxosRegistry := NewUtxosRegistry(settings)
+	blockchain := NewBlockchain(blocksStorageMock, new(application.SnapshotStorageMock), 0, false, registry, settings, sendersManagerMock, newBlocksRelayerMock(), utxosRegistry, metricsRecorderMock, logger)
+	var genesisValue uint64 = 100
+	genesisTransaction, _ := ledger.NewRewardTransaction(test.Address, false, 0, genesisValue)
+	_ = blockchain.AddBlock(0, []*ledger.Transaction{genesisTransaction}, nil, privateKey)
+	tip := blockchain.Blocks(0)[0]
+	tipHash, _ := tip.Hash()
+	transaction := ledger.NewSignedTransaction(genesisValue, 1, 0, test.Address2, privateKey, publicKey, 1, genesisTransaction.Id(), 1, false)
+	rewardTransaction, _ := ledger.NewRewardTransaction(test.Address2, false, 1, 0)
+	block, _ := ledger.NewSignedBlock(tipHash, nil, nil, 1, []*ledger.Transaction{transaction, rewardTransaction}, neighborPrivateKey)
+
+	// Act
+	blockchain.AddAnnouncedBlock(block, 1, "neighbor")
+
+	// Assert
+	test.Assert(t, len(sendersManagerMock.PenalizeCalls()) == 0, "Neighbor is penalized whereas its block spends a tip output.")
+	_ = blockchain.Update(context.Background(), 1)
+	test.AssertThatMessageIsLogged(t, logger.DebugCalls(), blockchainReplacedMessage)
+	isPolled := len(senderMock.GetHeadersCalls()) != 0 || len(senderMock.GetBlocksCalls()) != 0
+	test.Assert(t, !isPolled, "Neighbor is polled whereas its announced block is already verified.")
+}
+
+func newBlocksRelayerMock() *application.BlocksRelayerMock {
+	blocksRelayerMock := new(application.BlocksRelayerMock)
+	blocksRelayerMock.AnnounceBlockFunc = func(*ledger.BlockHeader) {}
+	return blocksRelayerMock
+}
+
+func newMetricsRecorderMock() *application.MetricsRecorderMock {
+	metricsRecorderMock := new(application.MetricsRecorderMock)
+	metricsRecorderMock.IncrementProducedBlocksFunc = func() {}
+	metricsRecorderMock.IncrementRejectedBlocksFunc = func() {}
+	metricsRecorderMock.ObserveBlockchainUpdateFunc = func(time.Duration, bool, bool) {}
+	return metricsRecorderMock
 }
@@ -0,0 +1,22 @@
+package verification
+
+import (
+	"github.com/my-cloud/ruthenium/validatornode/application"
+)
+
+type neighborMisbehavior struct {
+	misbehavior application.Misbehavior
+	err         error
+}
+
+func newNeighborMisbehavior(misbehavior application.Misbehavior, err error) *neighborMisbehavior {
+	return &neighborMisbehavior{misbehavior, err}
+}
+
+func (misbehavior *neighborMisbehavior) Error() string {
+	return misbehavior.err.Error()
+}
+
+func (misbehavior *neighborMisbehavior) Unwrap() error {
+	return misbehavior.err
+}
@@ -0,0 +1,41 @@
+package verification
+
+import (
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+)
+
+type transactionLocation struct {
+	blockHeight uint64
+	position    int
+}
+
+type transactionsIndex struct {
+	locationsById map[string]*transactionLocation
+}
+
+func newTransactionsIndex(blocks []*ledger.Block) *transactionsIndex {
+	index := &transactionsIndex{make(map[string]*transactionLocation)}
+	index.addBlocks(blocks, 0)
+	return index
+}
+
+func (index *transactionsIndex) addBlocks(blocks []*ledger.Block, startingBlockHeight uint64) {
+	for i, block := range blocks {
+		for position, transaction := range block.Transactions() {
+			index.locationsById[transaction.Id()] = &transactionLocation{startingBlockHeight + uint64(i), position}
+		}
+	}
+}
+
+func (index *transactionsIndex) location(transactionId string) (*transactionLocation, bool) {
+	location, ok := index.locationsById[transactionId]
+	return location, ok
+}
+
+func (index *transactionsIndex) removeBlocks(blocks []*ledger.Block) {
+	for _, block := range blocks {
+		for _, transaction := range block.Transactions() {
+			delete(index.locationsById, transaction.Id())
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package verification
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+)
+
+type undoRecord struct {
+	addedAddresses   []string
+	removedAddresses []string
+	spentUtxos       []*ledger.Utxo
+}
+
+func applyBlock(block *ledger.Block, utxosManager application.UtxosManager, registry application.AddressesManager) (*undoRecord, error) {
+	spentUtxos := utxosManager.SpentUtxos(block.Transactions())
+	if err := utxosManager.UpdateUtxos(block.Transactions(), block.Timestamp()); err != nil {
+		return nil, fmt.Errorf("failed to add UTXO: %w", err)
+	}
+	// Only record the registry changes that are actually applied, so that reverting them restores the previous state
+	addedAddresses := registry.Filter(block.AddedRegisteredAddresses())
+	var removedAddresses []string
+	for _, address := range block.RemovedRegisteredAddresses() {
+		if registry.IsRegistered(address) {
+			removedAddresses = append(removedAddresses, address)
+		}
+	}
+	registry.Update(block.AddedRegisteredAddresses(), block.RemovedRegisteredAddresses())
+	return &undoRecord{addedAddresses, removedAddresses, spentUtxos}, nil
+}
+
+func revertBlocks(blocks []*ledger.Block, undoRecords []*undoRecord, utxosManager application.UtxosManager, registry application.AddressesManager) error {
+	if len(blocks) != len(undoRecords) {
+		return errors.New("undo records are missing")
+	}
+	for _, record := range undoRecords {
+		if record == nil {
+			return errors.New("undo records are missing")
+		}
+	}
+	for i := len(blocks) - 1; i >= 0; i-- {
+		block := blocks[i]
+		record := undoRecords[i]
+		if err := utxosManager.RevertUtxos(block.Transactions(), record.spentUtxos); err != nil {
+			return fmt.Errorf("failed to revert UTXOs: %w", err)
+		}
+		registry.Update(record.removedAddresses, record.addedAddresses)
+	}
+	return nil
+}
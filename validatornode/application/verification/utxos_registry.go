@@ -1,6 +1,7 @@
 package verification
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/my-cloud/ruthenium/validatornode/application"
@@ -15,6 +16,11 @@ type utxosRegistrationInfo struct {
 	utxos         []*ledger.Utxo
 }
 
+type utxosRegistryDto struct {
+	UtxosByAddress map[string][]*ledger.Utxo `json:"utxos_by_address"`
+	UtxosById      map[string][]*ledger.Utxo `json:"utxos_by_id"`
+}
+
 type UtxosRegistry struct {
 	mutex          sync.RWMutex
 	settings       application.ProtocolSettingsProvider
@@ -88,6 +94,92 @@ func (registry *UtxosRegistry) Copy() application.UtxosManager {
 	return registryCopy
 }
 
+func (registry *UtxosRegistry) MarshalJSON() ([]byte, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	return json.Marshal(utxosRegistryDto{
+		UtxosByAddress: registry.utxosByAddress,
+		UtxosById:      registry.utxosById,
+	})
+}
+
+func (registry *UtxosRegistry) RevertUtxos(transactions []*ledger.Transaction, spentUtxos []*ledger.Utxo) error {
+	spentUtxosByInputInfo := make(map[ledger.InputInfo]*ledger.Utxo, len(spentUtxos))
+	for _, utxo := range spentUtxos {
+		spentUtxosByInputInfo[*ledger.NewInputInfo(utxo.OutputIndex(), utxo.TransactionId())] = utxo
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	utxosByAddress := copyUtxosMap(registry.utxosByAddress)
+	utxosById := copyUtxosMap(registry.utxosById)
+	for i := len(transactions) - 1; i >= 0; i-- {
+		transaction := transactions[i]
+		for j, output := range transaction.Outputs() {
+			utxosForOutputAddress := removeUtxo(utxosByAddress[output.Address()], transaction.Id(), uint16(j))
+			if len(utxosForOutputAddress) == 0 {
+				delete(utxosByAddress, output.Address())
+			} else {
+				utxosByAddress[output.Address()] = utxosForOutputAddress
+			}
+		}
+		delete(utxosById, transaction.Id())
+		for _, input := range transaction.Inputs() {
+			utxo, ok := spentUtxosByInputInfo[*ledger.NewInputInfo(input.OutputIndex(), input.TransactionId())]
+			if !ok {
+				// The spent output has been created in the same block, it is already reverted
+				continue
+			}
+			utxosForInputTransactionId := utxosById[input.TransactionId()]
+			for len(utxosForInputTransactionId) <= int(input.OutputIndex()) {
+				utxosForInputTransactionId = append(utxosForInputTransactionId, nil)
+			}
+			if utxosForInputTransactionId[input.OutputIndex()] != nil {
+				return fmt.Errorf("failed to restore an unspent output, input: %v", input)
+			}
+			utxosForInputTransactionId[input.OutputIndex()] = utxo
+			utxosById[input.TransactionId()] = utxosForInputTransactionId
+			utxosByAddress[utxo.Address()] = append(utxosByAddress[utxo.Address()], utxo)
+		}
+	}
+	registry.utxosById = utxosById
+	registry.utxosByAddress = utxosByAddress
+	return nil
+}
+
+func (registry *UtxosRegistry) SpentUtxos(transactions []*ledger.Transaction) []*ledger.Utxo {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	var spentUtxos []*ledger.Utxo
+	for _, transaction := range transactions {
+		for _, input := range transaction.Inputs() {
+			utxos := registry.utxosById[input.TransactionId()]
+			if int(input.OutputIndex()) < len(utxos) && utxos[input.OutputIndex()] != nil {
+				spentUtxos = append(spentUtxos, utxos[input.OutputIndex()])
+			}
+		}
+	}
+	return spentUtxos
+}
+
+func (registry *UtxosRegistry) UnmarshalJSON(data []byte) error {
+	var dto *utxosRegistryDto
+	err := json.Unmarshal(data, &dto)
+	if err != nil {
+		return err
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.utxosByAddress = make(map[string][]*ledger.Utxo)
+	for address, utxos := range dto.UtxosByAddress {
+		registry.utxosByAddress[address] = utxos
+	}
+	registry.utxosById = make(map[string][]*ledger.Utxo)
+	for transactionId, utxos := range dto.UtxosById {
+		registry.utxosById[transactionId] = utxos
+	}
+	return nil
+}
+
 func (registry *UtxosRegistry) UpdateUtxos(transactions []*ledger.Transaction, timestamp int64) error {
 	utxosByAddress := copyUtxosMap(registry.utxosByAddress)
 	utxosById := copyUtxosMap(registry.utxosById)
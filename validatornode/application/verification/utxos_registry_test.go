@@ -197,6 +197,61 @@ func Test_UpdateUtxos_ValidTransactions_ReturnsNil(t *testing.T) {
 	test.Assert(t, err == nil, fmt.Errorf("error should be nil but was: %w", err).Error())
 }
 
+func Test_RevertUtxos_UpdatedTransactions_UtxosRestored(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	publicKey := encryption.NewPublicKey(privateKey)
+	address := publicKey.Address()
+	transactionId := ""
+	initialUtxo := ledger.NewUtxo(ledger.NewInputInfo(0, transactionId), ledger.NewOutput(address, false, 1), 0)
+	initialUtxos := utxosRegistrationInfo{
+		address,
+		transactionId,
+		[]*ledger.Utxo{initialUtxo},
+	}
+	registry := NewUtxosRegistry(new(application.ProtocolSettingsProviderMock), initialUtxos)
+	transaction := ledger.NewSignedTransaction(1, 1, 0, address, privateKey, publicKey, 0, transactionId, 0, false)
+	transactions := []*ledger.Transaction{transaction}
+	spentUtxos := registry.SpentUtxos(transactions)
+	_ = registry.UpdateUtxos(transactions, 0)
+
+	// Act
+	err := registry.RevertUtxos(transactions, spentUtxos)
+
+	// Assert
+	test.Assert(t, err == nil, fmt.Sprintf("error is returned whereas it should not: %v", err))
+	actualUtxos := registry.Utxos(address)
+	test.Assert(t, len(actualUtxos) == 1 && actualUtxos[0] == initialUtxo, "utxos by address are not correctly restored")
+	utxosById := registry.utxosById[transactionId]
+	test.Assert(t, len(utxosById) == 1 && utxosById[0] == initialUtxo, "utxos by ID are not correctly restored")
+	_, isTransactionRegistered := registry.utxosById[transaction.Id()]
+	test.Assert(t, !isTransactionRegistered, "reverted transaction outputs are still registered")
+}
+
+func Test_UnmarshalJSON_MarshaledRegistry_UtxosRestored(t *testing.T) {
+	// Arrange
+	address := "address"
+	transactionId := "transaction"
+	initialUtxos := utxosRegistrationInfo{
+		address,
+		transactionId,
+		[]*ledger.Utxo{nil, ledger.NewUtxo(ledger.NewInputInfo(1, transactionId), ledger.NewOutput(address, false, 1), 0)},
+	}
+	registry := NewUtxosRegistry(new(application.ProtocolSettingsProviderMock), initialUtxos)
+	registryBytes, _ := registry.MarshalJSON()
+	restoredRegistry := NewUtxosRegistry(new(application.ProtocolSettingsProviderMock))
+
+	// Act
+	err := restoredRegistry.UnmarshalJSON(registryBytes)
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	actualUtxos := restoredRegistry.Utxos(address)
+	test.Assert(t, len(actualUtxos) == 2, fmt.Sprintf("utxos length is %d whereas it should be %d", len(actualUtxos), 2))
+	utxosById := restoredRegistry.utxosById[transactionId]
+	test.Assert(t, len(utxosById) == 2 && utxosById[0] == nil && utxosById[1].InitialValue() == 1, "utxos by ID are not correctly restored")
+}
+
 func Test_Utxos_UnknownAddress_ReturnsEmptyArray(t *testing.T) {
 	// Arrange
 	registry := NewUtxosRegistry(new(application.ProtocolSettingsProviderMock))
@@ -1,83 +1,142 @@
 package clock
 
 import (
-	"github.com/my-cloud/ruthenium/validatornode/application"
+	"context"
+	"fmt"
+	"sync"
 	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 )
 
-type Engine struct {
-	function func(timestamp int64)
+type Task func(ctx context.Context, timestamp int64) error
 
+type Engine struct {
+	name               string
+	task               Task
 	watch              application.TimeProvider
 	timer              time.Duration
 	subTimer           time.Duration
-	ticker             *time.Ticker
 	occurrences        int64
-	skippedOccurrences int
-	started            bool
-	requested          bool
+	skippedOccurrences int64
+	metricsRecorder    application.MetricsRecorder
+	logger             log.Logger
+
+	mutex     sync.Mutex
+	cancel    context.CancelFunc
+	isRunning bool
+	isStopped bool
 }
 
-func NewEngine(function func(timestamp int64), watch application.TimeProvider, timer time.Duration, occurrences int64, skippedOccurrences int) *Engine {
-	var subTimer time.Duration
-	if occurrences > 0 {
-		subTimer = time.Duration(timer.Nanoseconds() / occurrences)
-	} else {
-		subTimer = timer
+func NewEngine(name string, task Task, watch application.TimeProvider, timer time.Duration, occurrences int64, skippedOccurrences int, metricsRecorder application.MetricsRecorder, logger log.Logger) *Engine {
+	if occurrences < 1 {
+		occurrences = 1
+	}
+	subTimer := time.Duration(timer.Nanoseconds() / occurrences)
+	return &Engine{
+		name:               name,
+		task:               task,
+		watch:              watch,
+		timer:              timer,
+		subTimer:           subTimer,
+		occurrences:        occurrences,
+		skippedOccurrences: int64(skippedOccurrences),
+		metricsRecorder:    metricsRecorder,
+		logger:             logger.With(log.TaskKey, name),
 	}
-	ticker := time.NewTicker(timer)
-	return &Engine{function, watch, timer, subTimer, ticker, occurrences, skippedOccurrences, false, false}
 }
 
-func (engine *Engine) Pulse() {
-	if engine.started || engine.requested {
+func (engine *Engine) Pulse(ctx context.Context) {
+	ctx, isBegun := engine.begin(ctx)
+	if !isBegun {
 		return
 	}
+	defer engine.end()
 	now := engine.watch.Now()
 	startTime := now.Truncate(engine.timer).Add(engine.timer)
-	deadline := startTime.Sub(now)
-	engine.ticker.Reset(deadline)
-	engine.requested = true
-	<-engine.ticker.C
-	engine.function(startTime.UnixNano())
-	engine.requested = false
-	if engine.started {
-		newParsedStartDate := startTime.Add(engine.timer)
-		newDeadline := newParsedStartDate.Sub(startTime)
-		engine.ticker.Reset(newDeadline)
-	} else {
-		engine.ticker.Stop()
+	if sleep(ctx, startTime.Sub(now)) {
+		engine.run(ctx, startTime)
 	}
 }
 
-func (engine *Engine) Start() {
-	if engine.started {
+func (engine *Engine) Start(ctx context.Context) {
+	ctx, isBegun := engine.begin(ctx)
+	if !isBegun {
 		return
 	}
-	engine.started = true
-	initialTime := engine.watch.Now()
-	startTime := initialTime.Truncate(engine.timer).Add(engine.timer)
-	deadline := startTime.Sub(initialTime)
-	engine.ticker.Reset(deadline)
-	<-engine.ticker.C
-	engine.ticker.Reset(engine.subTimer)
-	occurrences := int(engine.occurrences)
-	for {
-		for i := 0; i < occurrences; i++ {
-			if i >= engine.skippedOccurrences {
-				if !engine.started {
-					engine.ticker.Stop()
-					return
-				}
-				now := engine.watch.Now().Round(engine.subTimer)
-				engine.function(now.UnixNano())
-			}
-			<-engine.ticker.C
+	defer engine.end()
+	startTime := engine.watch.Now().Truncate(engine.timer).Add(engine.timer)
+	for occurrence := int64(0); ; occurrence++ {
+		scheduledTime := startTime.Add(time.Duration(occurrence) * engine.subTimer)
+		if !sleep(ctx, scheduledTime.Sub(engine.watch.Now())) {
+			return
+		}
+		if occurrence%engine.occurrences >= engine.skippedOccurrences {
+			engine.run(ctx, scheduledTime)
+		}
+		// The occurrences missed because of an overrun are skipped rather than run in a burst
+		if missedOccurrences := int64(engine.watch.Now().Sub(scheduledTime) / engine.subTimer); missedOccurrences > 0 {
+			occurrence += missedOccurrences
 		}
 	}
 }
 
+// Stop cancels the running task, a stopped engine cannot be started again.
 func (engine *Engine) Stop() {
-	engine.started = false
-	engine.ticker.Reset(time.Nanosecond)
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	engine.isStopped = true
+	if engine.cancel != nil {
+		engine.cancel()
+	}
+}
+
+func (engine *Engine) begin(ctx context.Context) (context.Context, bool) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	if engine.isRunning || engine.isStopped {
+		return nil, false
+	}
+	ctx, engine.cancel = context.WithCancel(ctx)
+	engine.isRunning = true
+	return ctx, true
+}
+
+func (engine *Engine) end() {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+	engine.cancel()
+	engine.cancel = nil
+	engine.isRunning = false
+}
+
+func (engine *Engine) run(ctx context.Context, scheduledTime time.Time) {
+	taskCtx, cancel := context.WithTimeout(ctx, engine.subTimer)
+	defer cancel()
+	startTime := time.Now()
+	err := engine.task(taskCtx, scheduledTime.UnixNano())
+	duration := time.Since(startTime)
+	isOverrun := duration > engine.subTimer
+	engine.metricsRecorder.ObserveTask(engine.name, duration, err == nil, isOverrun)
+	if isOverrun {
+		engine.logger.Warn(fmt.Sprintf("task %s overran its interval: took %s whereas the interval is %s", engine.name, duration, engine.subTimer))
+	}
+	if err != nil && ctx.Err() == nil {
+		engine.logger.Error(err.Error())
+	}
+}
+
+func sleep(ctx context.Context, duration time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
 }
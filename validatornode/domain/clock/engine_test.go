@@ -1,8 +1,11 @@
 package clock
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 	"testing"
 	"time"
 
@@ -14,10 +17,10 @@ func Test_Do_NoError_FunctionCalled(t *testing.T) {
 	watchMock := new(application.TimeProviderMock)
 	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
 	var calls int
-	engine := NewEngine(func(int64) { calls++ }, watchMock, 1, 0, 0)
+	engine := NewEngine("test", func(context.Context, int64) error { calls++; return nil }, watchMock, 1, 0, 0, newMetricsRecorderMock(), log.NewLoggerMock())
 
 	// Act
-	engine.Pulse()
+	engine.Pulse(context.Background())
 
 	// Assert
 	test.Assert(t, calls == 1, fmt.Sprintf("The function is called %d times whereas it should be called once.", calls))
@@ -29,11 +32,103 @@ func Test_Start_NotStarted_Started(t *testing.T) {
 	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
 	var engine = &Engine{}
 	var calls int
-	engine = NewEngine(func(int64) { calls++; engine.Stop() }, watchMock, 1, 1, 0)
+	engine = NewEngine("test", func(context.Context, int64) error { calls++; engine.Stop(); return nil }, watchMock, 1, 1, 0, newMetricsRecorderMock(), log.NewLoggerMock())
 
 	// Act
-	engine.Start()
+	engine.Start(context.Background())
 
 	// Assert
 	test.Assert(t, calls == 1, fmt.Sprintf("The function is called %d times whereas it should be called once.", calls))
 }
+
+func Test_Start_Stopped_NotStarted(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	var calls int
+	engine := NewEngine("test", func(context.Context, int64) error { calls++; return nil }, watchMock, 1, 1, 0, newMetricsRecorderMock(), log.NewLoggerMock())
+	engine.Stop()
+
+	// Act
+	engine.Start(context.Background())
+
+	// Assert
+	test.Assert(t, calls == 0, fmt.Sprintf("The function is called %d times whereas it should not be called.", calls))
+}
+
+func Test_Start_ContextCancelled_TaskCancelled(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	ctx, cancel := context.WithCancel(context.Background())
+	var taskError error
+	engine := NewEngine("test", func(ctx context.Context, _ int64) error {
+		cancel()
+		<-ctx.Done()
+		taskError = ctx.Err()
+		return taskError
+	}, watchMock, time.Hour, 1, 0, newMetricsRecorderMock(), log.NewLoggerMock())
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0).Add(time.Hour - time.Millisecond) }
+
+	// Act
+	engine.Start(ctx)
+
+	// Assert
+	test.Assert(t, errors.Is(taskError, context.Canceled), "The task is not cancelled whereas it should be.")
+}
+
+func Test_Start_TaskFails_ErrorReported(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	logger := log.NewLoggerMock()
+	metricsRecorderMock := newMetricsRecorderMock()
+	var engine = &Engine{}
+	var calls int
+	engine = NewEngine("test", func(context.Context, int64) error {
+		calls++
+		if calls == 1 {
+			return errors.New("task failed")
+		}
+		engine.Stop()
+		return nil
+	}, watchMock, 1, 1, 0, metricsRecorderMock, logger)
+
+	// Act
+	engine.Start(context.Background())
+
+	// Assert
+	test.AssertThatMessageIsLogged(t, logger.ErrorCalls(), "task failed")
+	observeTaskCalls := metricsRecorderMock.ObserveTaskCalls()
+	test.Assert(t, len(observeTaskCalls) == 2 && !observeTaskCalls[0].IsSuccessful, "The task failure is not observed whereas it should be.")
+}
+
+func Test_Start_TaskOverruns_OverrunReported(t *testing.T) {
+	// Arrange
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	logger := log.NewLoggerMock()
+	metricsRecorderMock := newMetricsRecorderMock()
+	var engine = &Engine{}
+	var taskError error
+	engine = NewEngine("test", func(ctx context.Context, _ int64) error {
+		<-ctx.Done()
+		engine.Stop()
+		taskError = ctx.Err()
+		return nil
+	}, watchMock, time.Millisecond, 1, 0, metricsRecorderMock, logger)
+
+	// Act
+	engine.Start(context.Background())
+
+	// Assert
+	test.Assert(t, errors.Is(taskError, context.DeadlineExceeded), "The task deadline is not exceeded whereas it should be.")
+	test.AssertThatMessageIsLogged(t, logger.WarnCalls(), "task test overran its interval")
+	calls := metricsRecorderMock.ObserveTaskCalls()
+	test.Assert(t, len(calls) == 1 && calls[0].IsOverrun, "The task overrun is not observed whereas it should be.")
+}
+
+func newMetricsRecorderMock() *application.MetricsRecorderMock {
+	metricsRecorderMock := new(application.MetricsRecorderMock)
+	metricsRecorderMock.ObserveTaskFunc = func(string, time.Duration, bool, bool) {}
+	return metricsRecorderMock
+}
@@ -0,0 +1,60 @@
+package ledger
+
+import (
+	"encoding/json"
+)
+
+type addressBookEntryDto struct {
+	FailuresCount     int    `json:"failures_count"`
+	LastSeenTimestamp int64  `json:"last_seen_timestamp"`
+	Score             int    `json:"score"`
+	Target            string `json:"target"`
+}
+
+type AddressBookEntry struct {
+	failuresCount     int
+	lastSeenTimestamp int64
+	score             int
+	target            string
+}
+
+func NewAddressBookEntry(failuresCount int, lastSeenTimestamp int64, score int, target string) *AddressBookEntry {
+	return &AddressBookEntry{failuresCount, lastSeenTimestamp, score, target}
+}
+
+func (entry *AddressBookEntry) UnmarshalJSON(data []byte) error {
+	var dto *addressBookEntryDto
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	entry.failuresCount = dto.FailuresCount
+	entry.lastSeenTimestamp = dto.LastSeenTimestamp
+	entry.score = dto.Score
+	entry.target = dto.Target
+	return nil
+}
+
+func (entry *AddressBookEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(addressBookEntryDto{
+		FailuresCount:     entry.failuresCount,
+		LastSeenTimestamp: entry.lastSeenTimestamp,
+		Score:             entry.score,
+		Target:            entry.target,
+	})
+}
+
+func (entry *AddressBookEntry) FailuresCount() int {
+	return entry.failuresCount
+}
+
+func (entry *AddressBookEntry) LastSeenTimestamp() int64 {
+	return entry.lastSeenTimestamp
+}
+
+func (entry *AddressBookEntry) Score() int {
+	return entry.score
+}
+
+func (entry *AddressBookEntry) Target() string {
+	return entry.target
+}
@@ -0,0 +1,52 @@
+package ledger
+
+import (
+	"encoding/json"
+)
+
+type addressHistoryEntryDto struct {
+	BlockHeight uint64       `json:"block_height"`
+	Timestamp   int64        `json:"timestamp"`
+	Transaction *Transaction `json:"transaction"`
+}
+
+type AddressHistoryEntry struct {
+	blockHeight uint64
+	timestamp   int64
+	transaction *Transaction
+}
+
+func NewAddressHistoryEntry(transaction *Transaction, blockHeight uint64, timestamp int64) *AddressHistoryEntry {
+	return &AddressHistoryEntry{blockHeight, timestamp, transaction}
+}
+
+func (entry *AddressHistoryEntry) UnmarshalJSON(data []byte) error {
+	var dto *addressHistoryEntryDto
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	entry.blockHeight = dto.BlockHeight
+	entry.timestamp = dto.Timestamp
+	entry.transaction = dto.Transaction
+	return nil
+}
+
+func (entry *AddressHistoryEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(addressHistoryEntryDto{
+		BlockHeight: entry.blockHeight,
+		Timestamp:   entry.timestamp,
+		Transaction: entry.transaction,
+	})
+}
+
+func (entry *AddressHistoryEntry) BlockHeight() uint64 {
+	return entry.blockHeight
+}
+
+func (entry *AddressHistoryEntry) Timestamp() int64 {
+	return entry.timestamp
+}
+
+func (entry *AddressHistoryEntry) Transaction() *Transaction {
+	return entry.transaction
+}
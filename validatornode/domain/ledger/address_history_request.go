@@ -0,0 +1,52 @@
+package ledger
+
+import (
+	"encoding/json"
+)
+
+type addressHistoryRequestDto struct {
+	Address string `json:"address"`
+	Limit   uint64 `json:"limit"`
+	Offset  uint64 `json:"offset"`
+}
+
+type AddressHistoryRequest struct {
+	address string
+	limit   uint64
+	offset  uint64
+}
+
+func NewAddressHistoryRequest(address string, offset uint64, limit uint64) *AddressHistoryRequest {
+	return &AddressHistoryRequest{address, limit, offset}
+}
+
+func (request *AddressHistoryRequest) UnmarshalJSON(data []byte) error {
+	var dto *addressHistoryRequestDto
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	request.address = dto.Address
+	request.limit = dto.Limit
+	request.offset = dto.Offset
+	return nil
+}
+
+func (request *AddressHistoryRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(addressHistoryRequestDto{
+		Address: request.address,
+		Limit:   request.limit,
+		Offset:  request.offset,
+	})
+}
+
+func (request *AddressHistoryRequest) Address() string {
+	return request.address
+}
+
+func (request *AddressHistoryRequest) Limit() uint64 {
+	return request.limit
+}
+
+func (request *AddressHistoryRequest) Offset() uint64 {
+	return request.offset
+}
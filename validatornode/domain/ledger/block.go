@@ -1,9 +1,11 @@
 package ledger
 
 import (
-	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/encryption"
 )
 
 type blockDto struct {
@@ -12,6 +14,9 @@ type blockDto struct {
 	RemovedRegisteredAddresses []string       `json:"removed_registered_addresses"`
 	Timestamp                  int64          `json:"timestamp"`
 	Transactions               []*Transaction `json:"transactions"`
+	MerkleRoot                 [32]byte       `json:"merkle_root"`
+	PublicKey                  string         `json:"public_key"`
+	Signature                  string         `json:"signature"`
 }
 
 type Block struct {
@@ -20,10 +25,29 @@ type Block struct {
 	removedRegisteredAddresses []string
 	timestamp                  int64
 	transactions               []*Transaction
+	merkleRoot                 [32]byte
+	publicKey                  *encryption.PublicKey
+	signature                  *encryption.Signature
 }
 
 func NewBlock(previousHash [32]byte, addedRegisteredAddresses []string, removedRegisteredAddresses []string, timestamp int64, transactions []*Transaction) *Block {
-	return &Block{previousHash, addedRegisteredAddresses, removedRegisteredAddresses, timestamp, transactions}
+	merkleRoot := NewMerkleRoot(transactions)
+	return &Block{previousHash, addedRegisteredAddresses, removedRegisteredAddresses, timestamp, transactions, merkleRoot, nil, nil}
+}
+
+func NewSignedBlock(previousHash [32]byte, addedRegisteredAddresses []string, removedRegisteredAddresses []string, timestamp int64, transactions []*Transaction, privateKey *encryption.PrivateKey) (*Block, error) {
+	block := NewBlock(previousHash, addedRegisteredAddresses, removedRegisteredAddresses, timestamp, transactions)
+	block.publicKey = encryption.NewPublicKey(privateKey)
+	marshaledBlock, err := block.marshalUnsigned()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := encryption.NewSignature(marshaledBlock, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign block: %w", err)
+	}
+	block.signature = signature
+	return block, nil
 }
 
 func (block *Block) UnmarshalJSON(data []byte) error {
@@ -37,27 +61,52 @@ func (block *Block) UnmarshalJSON(data []byte) error {
 	block.removedRegisteredAddresses = dto.RemovedRegisteredAddresses
 	block.timestamp = dto.Timestamp
 	block.transactions = dto.Transactions
+	block.merkleRoot = dto.MerkleRoot
+	block.publicKey = nil
+	if dto.PublicKey != "" {
+		block.publicKey, err = encryption.NewPublicKeyFromHex(dto.PublicKey)
+		if err != nil {
+			return fmt.Errorf("failed to decode public key: %w", err)
+		}
+	}
+	block.signature = nil
+	if dto.Signature != "" {
+		block.signature, err = encryption.DecodeSignature(dto.Signature)
+		if err != nil {
+			return fmt.Errorf("failed to decode signature: %w", err)
+		}
+	}
 	return nil
 }
 
 func (block *Block) MarshalJSON() ([]byte, error) {
-	return json.Marshal(blockDto{
-		PreviousHash:               block.previousHash,
-		AddedRegisteredAddresses:   block.addedRegisteredAddresses,
-		RemovedRegisteredAddresses: block.removedRegisteredAddresses,
-		Timestamp:                  block.timestamp,
-		Transactions:               block.transactions,
-	})
+	var encodedSignature string
+	if block.signature != nil {
+		encodedSignature = block.signature.String()
+	}
+	return block.marshal(encodedSignature)
 }
 
-func (block *Block) Hash() (hash [32]byte, err error) {
-	marshaledBlock, err := block.MarshalJSON()
+// Hash only covers the block header, the transactions are committed by the merkle root so that a transaction inclusion can be proven without the whole block
+func (block *Block) Hash() ([32]byte, error) {
+	return headerHash(block.previousHash, block.addedRegisteredAddresses, block.removedRegisteredAddresses, block.timestamp, block.merkleRoot, block.encodedPublicKey())
+}
+
+func (block *Block) VerifySignature() error {
+	if block.publicKey == nil || block.signature == nil {
+		return errors.New("block is not signed")
+	}
+	marshaledBlock, err := block.marshalUnsigned()
 	if err != nil {
-		err = fmt.Errorf("failed to marshal block: %w", err)
-		return
+		return err
+	}
+	if !block.signature.Verify(marshaledBlock, block.publicKey) {
+		return errors.New("signature is invalid")
+	}
+	if block.publicKey.Address() != block.ValidatorAddress() {
+		return errors.New("signer is not the reward recipient")
 	}
-	hash = sha256.Sum256(marshaledBlock)
-	return
+	return nil
 }
 
 func (block *Block) ValidatorAddress() string {
@@ -71,6 +120,10 @@ func (block *Block) ValidatorAddress() string {
 	return validatorAddress
 }
 
+func (block *Block) MerkleRoot() [32]byte {
+	return block.merkleRoot
+}
+
 func (block *Block) PreviousHash() [32]byte {
 	return block.previousHash
 }
@@ -90,3 +143,31 @@ func (block *Block) Timestamp() int64 {
 func (block *Block) Transactions() []*Transaction {
 	return block.transactions
 }
+
+func (block *Block) encodedPublicKey() string {
+	if block.publicKey == nil {
+		return ""
+	}
+	return block.publicKey.String()
+}
+
+func (block *Block) marshal(encodedSignature string) ([]byte, error) {
+	return json.Marshal(blockDto{
+		PreviousHash:               block.previousHash,
+		AddedRegisteredAddresses:   block.addedRegisteredAddresses,
+		RemovedRegisteredAddresses: block.removedRegisteredAddresses,
+		Timestamp:                  block.timestamp,
+		Transactions:               block.transactions,
+		MerkleRoot:                 block.merkleRoot,
+		PublicKey:                  block.encodedPublicKey(),
+		Signature:                  encodedSignature,
+	})
+}
+
+func (block *Block) marshalUnsigned() ([]byte, error) {
+	marshaledBlock, err := block.marshal("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal block: %w", err)
+	}
+	return marshaledBlock, nil
+}
@@ -0,0 +1,44 @@
+package ledger
+
+import (
+	"encoding/json"
+)
+
+type blockAnnouncementDto struct {
+	BlockBroadcasterTarget string       `json:"block_broadcaster_target"`
+	BlockHeader            *BlockHeader `json:"block_header"`
+}
+
+type BlockAnnouncement struct {
+	blockBroadcasterTarget string
+	blockHeader            *BlockHeader
+}
+
+func NewBlockAnnouncement(blockHeader *BlockHeader, blockBroadcasterTarget string) *BlockAnnouncement {
+	return &BlockAnnouncement{blockBroadcasterTarget, blockHeader}
+}
+
+func (announcement *BlockAnnouncement) UnmarshalJSON(data []byte) error {
+	var dto *blockAnnouncementDto
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	announcement.blockBroadcasterTarget = dto.BlockBroadcasterTarget
+	announcement.blockHeader = dto.BlockHeader
+	return nil
+}
+
+func (announcement *BlockAnnouncement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(blockAnnouncementDto{
+		BlockBroadcasterTarget: announcement.blockBroadcasterTarget,
+		BlockHeader:            announcement.blockHeader,
+	})
+}
+
+func (announcement *BlockAnnouncement) BlockBroadcasterTarget() string {
+	return announcement.blockBroadcasterTarget
+}
+
+func (announcement *BlockAnnouncement) BlockHeader() *BlockHeader {
+	return announcement.blockHeader
+}
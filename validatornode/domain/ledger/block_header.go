@@ -0,0 +1,137 @@
+package ledger
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type blockHeaderDto struct {
+	AddedRegisteredAddresses   []string `json:"added_registered_addresses"`
+	Hash                       [32]byte `json:"hash"`
+	Height                     uint64   `json:"height"`
+	MerkleRoot                 [32]byte `json:"merkle_root"`
+	PreviousHash               [32]byte `json:"previous_hash"`
+	PublicKey                  string   `json:"public_key"`
+	RemovedRegisteredAddresses []string `json:"removed_registered_addresses"`
+	Timestamp                  int64    `json:"timestamp"`
+}
+
+type hashedHeaderDto struct {
+	PreviousHash               [32]byte `json:"previous_hash"`
+	AddedRegisteredAddresses   []string `json:"added_registered_addresses"`
+	RemovedRegisteredAddresses []string `json:"removed_registered_addresses"`
+	Timestamp                  int64    `json:"timestamp"`
+	MerkleRoot                 [32]byte `json:"merkle_root"`
+	PublicKey                  string   `json:"public_key"`
+}
+
+type BlockHeader struct {
+	addedRegisteredAddresses   []string
+	hash                       [32]byte
+	height                     uint64
+	merkleRoot                 [32]byte
+	previousHash               [32]byte
+	publicKey                  string
+	removedRegisteredAddresses []string
+	timestamp                  int64
+}
+
+func NewBlockHeader(block *Block, height uint64) (*BlockHeader, error) {
+	hash, err := block.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate block hash: %w", err)
+	}
+	return &BlockHeader{block.AddedRegisteredAddresses(), hash, height, block.MerkleRoot(), block.PreviousHash(), block.encodedPublicKey(), block.RemovedRegisteredAddresses(), block.Timestamp()}, nil
+}
+
+func NewBlockHeaders(blocks []*Block, startingBlockHeight uint64) ([]*BlockHeader, error) {
+	headers := make([]*BlockHeader, len(blocks))
+	for i, block := range blocks {
+		header, err := NewBlockHeader(block, startingBlockHeight+uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		headers[i] = header
+	}
+	return headers, nil
+}
+
+func (header *BlockHeader) UnmarshalJSON(data []byte) error {
+	var dto *blockHeaderDto
+	err := json.Unmarshal(data, &dto)
+	if err != nil {
+		return err
+	}
+	header.addedRegisteredAddresses = dto.AddedRegisteredAddresses
+	header.hash = dto.Hash
+	header.height = dto.Height
+	header.merkleRoot = dto.MerkleRoot
+	header.previousHash = dto.PreviousHash
+	header.publicKey = dto.PublicKey
+	header.removedRegisteredAddresses = dto.RemovedRegisteredAddresses
+	header.timestamp = dto.Timestamp
+	return nil
+}
+
+func (header *BlockHeader) MarshalJSON() ([]byte, error) {
+	return json.Marshal(blockHeaderDto{
+		AddedRegisteredAddresses:   header.addedRegisteredAddresses,
+		Hash:                       header.hash,
+		Height:                     header.height,
+		MerkleRoot:                 header.merkleRoot,
+		PreviousHash:               header.previousHash,
+		PublicKey:                  header.publicKey,
+		RemovedRegisteredAddresses: header.removedRegisteredAddresses,
+		Timestamp:                  header.timestamp,
+	})
+}
+
+func (header *BlockHeader) VerifyHash() error {
+	hash, err := headerHash(header.previousHash, header.addedRegisteredAddresses, header.removedRegisteredAddresses, header.timestamp, header.merkleRoot, header.publicKey)
+	if err != nil {
+		return err
+	}
+	if hash != header.hash {
+		return errors.New("block header hash is invalid")
+	}
+	return nil
+}
+
+func (header *BlockHeader) Hash() [32]byte {
+	return header.hash
+}
+
+func (header *BlockHeader) Height() uint64 {
+	return header.height
+}
+
+func (header *BlockHeader) MerkleRoot() [32]byte {
+	return header.merkleRoot
+}
+
+func (header *BlockHeader) PreviousHash() [32]byte {
+	return header.previousHash
+}
+
+func (header *BlockHeader) Timestamp() int64 {
+	return header.timestamp
+}
+
+func headerHash(previousHash [32]byte, addedRegisteredAddresses []string, removedRegisteredAddresses []string, timestamp int64, merkleRoot [32]byte, publicKey string) (hash [32]byte, err error) {
+	marshaledHeader, err := json.Marshal(hashedHeaderDto{
+		PreviousHash:               previousHash,
+		AddedRegisteredAddresses:   addedRegisteredAddresses,
+		RemovedRegisteredAddresses: removedRegisteredAddresses,
+		Timestamp:                  timestamp,
+		MerkleRoot:                 merkleRoot,
+		PublicKey:                  publicKey,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to marshal block header: %w", err)
+		return
+	}
+	hash = sha256.Sum256(marshaledHeader)
+	return
+}
@@ -0,0 +1,85 @@
+package ledger
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/encryption"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_VerifySignature_BlockIsSignedByRewardRecipient_ReturnsNil(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	block := NewRewardedBlock([32]byte{}, 1, privateKey)
+	blockBytes, _ := json.Marshal(block)
+	var unmarshalledBlock *Block
+	_ = json.Unmarshal(blockBytes, &unmarshalledBlock)
+
+	// Act
+	err := unmarshalledBlock.VerifySignature()
+
+	// Assert
+	test.Assert(t, err == nil, "Error is returned whereas it should not")
+}
+
+func Test_VerifySignature_BlockIsNotSigned_ReturnsError(t *testing.T) {
+	// Arrange
+	rewardTransaction, _ := NewRewardTransaction(test.Address, false, 0, 0)
+	block := NewBlock([32]byte{}, nil, nil, 1, []*Transaction{rewardTransaction})
+
+	// Act
+	err := block.VerifySignature()
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_VerifySignature_BlockIsTampered_ReturnsError(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	block := NewRewardedBlock([32]byte{}, 1, privateKey)
+	blockBytes, _ := json.Marshal(block)
+	var dto map[string]interface{}
+	_ = json.Unmarshal(blockBytes, &dto)
+	dto["timestamp"] = 2
+	tamperedBlockBytes, _ := json.Marshal(dto)
+	var tamperedBlock *Block
+	_ = json.Unmarshal(tamperedBlockBytes, &tamperedBlock)
+
+	// Act
+	err := tamperedBlock.VerifySignature()
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_VerifySignature_SignerIsNotRewardRecipient_ReturnsError(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	rewardTransaction, _ := NewRewardTransaction(test.Address2, false, 0, 0)
+	block, _ := NewSignedBlock([32]byte{}, nil, nil, 1, []*Transaction{rewardTransaction}, privateKey)
+
+	// Act
+	err := block.VerifySignature()
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_VerifyHash_HeaderIsUnmarshalled_ReturnsNil(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	rewardTransaction, _ := NewRewardTransaction(test.Address, false, 0, 0)
+	block, _ := NewSignedBlock([32]byte{1}, []string{test.Address}, []string{test.Address2}, 1, []*Transaction{rewardTransaction}, privateKey)
+	header, _ := NewBlockHeader(block, 1)
+	headerBytes, _ := json.Marshal(header)
+	var unmarshalledHeader *BlockHeader
+	_ = json.Unmarshal(headerBytes, &unmarshalledHeader)
+
+	// Act
+	err := unmarshalledHeader.VerifyHash()
+
+	// Assert
+	test.Assert(t, err == nil, "Error is returned whereas it should not")
+}
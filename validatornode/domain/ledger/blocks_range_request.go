@@ -0,0 +1,44 @@
+package ledger
+
+import (
+	"encoding/json"
+)
+
+type blocksRangeRequestDto struct {
+	EndingBlockHeight   uint64 `json:"ending_block_height"`
+	StartingBlockHeight uint64 `json:"starting_block_height"`
+}
+
+type BlocksRangeRequest struct {
+	endingBlockHeight   uint64
+	startingBlockHeight uint64
+}
+
+func NewBlocksRangeRequest(startingBlockHeight uint64, endingBlockHeight uint64) *BlocksRangeRequest {
+	return &BlocksRangeRequest{endingBlockHeight, startingBlockHeight}
+}
+
+func (request *BlocksRangeRequest) UnmarshalJSON(data []byte) error {
+	var dto *blocksRangeRequestDto
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	request.endingBlockHeight = dto.EndingBlockHeight
+	request.startingBlockHeight = dto.StartingBlockHeight
+	return nil
+}
+
+func (request *BlocksRangeRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(blocksRangeRequestDto{
+		EndingBlockHeight:   request.endingBlockHeight,
+		StartingBlockHeight: request.startingBlockHeight,
+	})
+}
+
+func (request *BlocksRangeRequest) EndingBlockHeight() uint64 {
+	return request.endingBlockHeight
+}
+
+func (request *BlocksRangeRequest) StartingBlockHeight() uint64 {
+	return request.startingBlockHeight
+}
@@ -0,0 +1,92 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type handshakeDto struct {
+	GenesisBlockHash [32]byte     `json:"genesis_block_hash"`
+	NetworkId        string       `json:"network_id"`
+	ProtocolVersion  uint32       `json:"protocol_version"`
+	SettingsHash     [32]byte     `json:"settings_hash"`
+	Tip              *BlockHeader `json:"tip"`
+}
+
+type Handshake struct {
+	genesisBlockHash [32]byte
+	networkId        string
+	protocolVersion  uint32
+	settingsHash     [32]byte
+	tip              *BlockHeader
+}
+
+func NewHandshake(genesisBlockHash [32]byte, networkId string, protocolVersion uint32, settingsHash [32]byte, tip *BlockHeader) *Handshake {
+	return &Handshake{genesisBlockHash, networkId, protocolVersion, settingsHash, tip}
+}
+
+func (handshake *Handshake) UnmarshalJSON(data []byte) error {
+	var dto *handshakeDto
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	handshake.genesisBlockHash = dto.GenesisBlockHash
+	handshake.networkId = dto.NetworkId
+	handshake.protocolVersion = dto.ProtocolVersion
+	handshake.settingsHash = dto.SettingsHash
+	handshake.tip = dto.Tip
+	return nil
+}
+
+func (handshake *Handshake) MarshalJSON() ([]byte, error) {
+	return json.Marshal(handshakeDto{
+		GenesisBlockHash: handshake.genesisBlockHash,
+		NetworkId:        handshake.networkId,
+		ProtocolVersion:  handshake.protocolVersion,
+		SettingsHash:     handshake.settingsHash,
+		Tip:              handshake.tip,
+	})
+}
+
+func (handshake *Handshake) VerifyCompatibility(other *Handshake) error {
+	if other == nil {
+		return errors.New("handshake is missing")
+	}
+	if other.protocolVersion != handshake.protocolVersion {
+		return fmt.Errorf("protocol version mismatch: expected: %d, actual: %d", handshake.protocolVersion, other.protocolVersion)
+	}
+	if other.networkId != handshake.networkId {
+		return fmt.Errorf("network id mismatch: expected: %s, actual: %s", handshake.networkId, other.networkId)
+	}
+	if other.settingsHash != handshake.settingsHash {
+		return fmt.Errorf("protocol settings hash mismatch: expected: %x, actual: %x", handshake.settingsHash, other.settingsHash)
+	}
+	// The genesis block hash is unknown while the blockchain is empty
+	var emptyHash [32]byte
+	isGenesisBlockKnown := handshake.genesisBlockHash != emptyHash && other.genesisBlockHash != emptyHash
+	if isGenesisBlockKnown && other.genesisBlockHash != handshake.genesisBlockHash {
+		return fmt.Errorf("genesis block hash mismatch: expected: %x, actual: %x", handshake.genesisBlockHash, other.genesisBlockHash)
+	}
+	return nil
+}
+
+func (handshake *Handshake) GenesisBlockHash() [32]byte {
+	return handshake.genesisBlockHash
+}
+
+func (handshake *Handshake) NetworkId() string {
+	return handshake.networkId
+}
+
+func (handshake *Handshake) ProtocolVersion() uint32 {
+	return handshake.protocolVersion
+}
+
+func (handshake *Handshake) SettingsHash() [32]byte {
+	return handshake.settingsHash
+}
+
+func (handshake *Handshake) Tip() *BlockHeader {
+	return handshake.tip
+}
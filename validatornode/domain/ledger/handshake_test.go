@@ -0,0 +1,54 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_VerifyCompatibility_DifferentNetworkId_ReturnsError(t *testing.T) {
+	// Arrange
+	handshake := NewHandshake([32]byte{1}, "mainnet", 1, [32]byte{2}, nil)
+	other := NewHandshake([32]byte{1}, "testnet", 1, [32]byte{2}, nil)
+
+	// Act
+	err := handshake.VerifyCompatibility(other)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is nil whereas it should not.")
+}
+
+func Test_VerifyCompatibility_DifferentGenesisBlockHash_ReturnsError(t *testing.T) {
+	// Arrange
+	handshake := NewHandshake([32]byte{1}, "mainnet", 1, [32]byte{2}, nil)
+	other := NewHandshake([32]byte{3}, "mainnet", 1, [32]byte{2}, nil)
+
+	// Act
+	err := handshake.VerifyCompatibility(other)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is nil whereas it should not.")
+}
+
+func Test_VerifyCompatibility_GenesisBlockUnknown_ReturnsNil(t *testing.T) {
+	// Arrange
+	handshake := NewHandshake([32]byte{1}, "mainnet", 1, [32]byte{2}, nil)
+	other := NewHandshake([32]byte{}, "mainnet", 1, [32]byte{2}, nil)
+
+	// Act
+	err := handshake.VerifyCompatibility(other)
+
+	// Assert
+	test.Assert(t, err == nil, "Error is not nil whereas it should be.")
+}
+
+func Test_VerifyCompatibility_NullHandshake_ReturnsError(t *testing.T) {
+	// Arrange
+	handshake := NewHandshake([32]byte{1}, "mainnet", 1, [32]byte{2}, nil)
+
+	// Act
+	err := handshake.VerifyCompatibility(nil)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is nil whereas it should not.")
+}
@@ -0,0 +1,52 @@
+package ledger
+
+import (
+	"encoding/json"
+)
+
+type indexedTransactionDto struct {
+	BlockHeight        uint64       `json:"block_height"`
+	ConfirmationsCount uint64       `json:"confirmations_count"`
+	Transaction        *Transaction `json:"transaction"`
+}
+
+type IndexedTransaction struct {
+	blockHeight        uint64
+	confirmationsCount uint64
+	transaction        *Transaction
+}
+
+func NewIndexedTransaction(transaction *Transaction, blockHeight uint64, confirmationsCount uint64) *IndexedTransaction {
+	return &IndexedTransaction{blockHeight, confirmationsCount, transaction}
+}
+
+func (indexedTransaction *IndexedTransaction) UnmarshalJSON(data []byte) error {
+	var dto *indexedTransactionDto
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	indexedTransaction.blockHeight = dto.BlockHeight
+	indexedTransaction.confirmationsCount = dto.ConfirmationsCount
+	indexedTransaction.transaction = dto.Transaction
+	return nil
+}
+
+func (indexedTransaction *IndexedTransaction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(indexedTransactionDto{
+		BlockHeight:        indexedTransaction.blockHeight,
+		ConfirmationsCount: indexedTransaction.confirmationsCount,
+		Transaction:        indexedTransaction.transaction,
+	})
+}
+
+func (indexedTransaction *IndexedTransaction) BlockHeight() uint64 {
+	return indexedTransaction.blockHeight
+}
+
+func (indexedTransaction *IndexedTransaction) ConfirmationsCount() uint64 {
+	return indexedTransaction.confirmationsCount
+}
+
+func (indexedTransaction *IndexedTransaction) Transaction() *Transaction {
+	return indexedTransaction.transaction
+}
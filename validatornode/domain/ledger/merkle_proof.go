@@ -0,0 +1,167 @@
+package ledger
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	merkleLeafPrefix byte = 0
+	merkleNodePrefix byte = 1
+)
+
+type merkleProofStepDto struct {
+	Hash   [32]byte `json:"hash"`
+	IsLeft bool     `json:"is_left"`
+}
+
+type merkleProofDto struct {
+	BlockHeight   uint64                `json:"block_height"`
+	MerkleRoot    [32]byte              `json:"merkle_root"`
+	Path          []*merkleProofStepDto `json:"path"`
+	TransactionId string                `json:"transaction_id"`
+}
+
+type MerkleProof struct {
+	blockHeight   uint64
+	merkleRoot    [32]byte
+	path          []*merkleProofStepDto
+	transactionId string
+}
+
+func NewMerkleProof(transactions []*Transaction, transactionId string, blockHeight uint64) (*MerkleProof, error) {
+	leafIndex := -1
+	for i, transaction := range transactions {
+		if transaction.Id() == transactionId {
+			leafIndex = i
+			break
+		}
+	}
+	if leafIndex == -1 {
+		return nil, fmt.Errorf("transaction not found in block: transaction id: %s, block height: %d", transactionId, blockHeight)
+	}
+	var path []*merkleProofStepDto
+	level := merkleLeaves(transactions)
+	index := leafIndex
+	for len(level) > 1 {
+		if index%2 == 1 {
+			path = append(path, &merkleProofStepDto{level[index-1], true})
+		} else if index+1 < len(level) {
+			path = append(path, &merkleProofStepDto{level[index+1], false})
+		}
+		level = merkleParents(level)
+		index /= 2
+	}
+	return &MerkleProof{blockHeight, level[0], path, transactionId}, nil
+}
+
+func NewMerkleRoot(transactions []*Transaction) [32]byte {
+	if len(transactions) == 0 {
+		return [32]byte{}
+	}
+	level := merkleLeaves(transactions)
+	for len(level) > 1 {
+		level = merkleParents(level)
+	}
+	return level[0]
+}
+
+func (proof *MerkleProof) UnmarshalJSON(data []byte) error {
+	var dto *merkleProofDto
+	err := json.Unmarshal(data, &dto)
+	if err != nil {
+		return err
+	}
+	proof.blockHeight = dto.BlockHeight
+	proof.merkleRoot = dto.MerkleRoot
+	proof.path = dto.Path
+	proof.transactionId = dto.TransactionId
+	return nil
+}
+
+func (proof *MerkleProof) MarshalJSON() ([]byte, error) {
+	return json.Marshal(merkleProofDto{
+		BlockHeight:   proof.blockHeight,
+		MerkleRoot:    proof.merkleRoot,
+		Path:          proof.path,
+		TransactionId: proof.transactionId,
+	})
+}
+
+// Verify checks that the transaction is committed by the given block header, whose hash is recalculated
+func (proof *MerkleProof) Verify(header *BlockHeader) error {
+	if header == nil {
+		return errors.New("block header is missing")
+	}
+	if err := header.VerifyHash(); err != nil {
+		return fmt.Errorf("failed to verify block header: %w", err)
+	}
+	if header.Height() != proof.blockHeight {
+		return fmt.Errorf("merkle proof block height does not match the block header one: proof block height: %d, block header height: %d", proof.blockHeight, header.Height())
+	}
+	if header.MerkleRoot() != proof.merkleRoot {
+		return errors.New("merkle proof root does not match the block header one")
+	}
+	hash := merkleLeaf(proof.transactionId)
+	for _, step := range proof.path {
+		if step == nil {
+			return errors.New("merkle proof path contains an empty step")
+		}
+		if step.IsLeft {
+			hash = merkleNode(step.Hash, hash)
+		} else {
+			hash = merkleNode(hash, step.Hash)
+		}
+	}
+	if hash != proof.merkleRoot {
+		return errors.New("merkle proof does not match the merkle root")
+	}
+	return nil
+}
+
+func (proof *MerkleProof) BlockHeight() uint64 {
+	return proof.blockHeight
+}
+
+func (proof *MerkleProof) MerkleRoot() [32]byte {
+	return proof.merkleRoot
+}
+
+func (proof *MerkleProof) TransactionId() string {
+	return proof.transactionId
+}
+
+func merkleLeaf(transactionId string) [32]byte {
+	return sha256.Sum256(append([]byte{merkleLeafPrefix}, transactionId...))
+}
+
+func merkleLeaves(transactions []*Transaction) [][32]byte {
+	leaves := make([][32]byte, len(transactions))
+	for i, transaction := range transactions {
+		leaves[i] = merkleLeaf(transaction.Id())
+	}
+	return leaves
+}
+
+func merkleNode(left [32]byte, right [32]byte) [32]byte {
+	data := make([]byte, 0, 65)
+	data = append(data, merkleNodePrefix)
+	data = append(data, left[:]...)
+	data = append(data, right[:]...)
+	return sha256.Sum256(data)
+}
+
+func merkleParents(level [][32]byte) [][32]byte {
+	parents := make([][32]byte, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 < len(level) {
+			parents = append(parents, merkleNode(level[i], level[i+1]))
+		} else {
+			// An odd node is promoted as is rather than duplicated
+			parents = append(parents, level[i])
+		}
+	}
+	return parents
+}
@@ -0,0 +1,92 @@
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_Verify_EachTransactionOfBlocksOfDifferentSizes_ReturnsNil(t *testing.T) {
+	for transactionsCount := 1; transactionsCount <= 7; transactionsCount++ {
+		// Arrange
+		var transactions []*Transaction
+		for i := 0; i < transactionsCount; i++ {
+			transaction, _ := NewRewardTransaction(test.Address, false, int64(i), 0)
+			transactions = append(transactions, transaction)
+		}
+		merkleRoot := NewMerkleRoot(transactions)
+		header, _ := NewBlockHeader(NewBlock([32]byte{}, nil, nil, 0, transactions), 0)
+		for _, transaction := range transactions {
+			proof, _ := NewMerkleProof(transactions, transaction.Id(), 0)
+			proofBytes, _ := json.Marshal(proof)
+			var unmarshalledProof *MerkleProof
+			_ = json.Unmarshal(proofBytes, &unmarshalledProof)
+
+			// Act
+			err := unmarshalledProof.Verify(header)
+
+			// Assert
+			test.Assert(t, err == nil, fmt.Sprintf("Error is returned whereas it should not for %d transactions", transactionsCount))
+			test.Assert(t, unmarshalledProof.MerkleRoot() == merkleRoot, fmt.Sprintf("Wrong merkle root for %d transactions", transactionsCount))
+		}
+	}
+}
+
+func Test_Verify_TransactionIdIsTampered_ReturnsError(t *testing.T) {
+	// Arrange
+	transaction1, _ := NewRewardTransaction(test.Address, false, 1, 0)
+	transaction2, _ := NewRewardTransaction(test.Address, false, 2, 0)
+	transactions := []*Transaction{transaction1, transaction2}
+	header, _ := NewBlockHeader(NewBlock([32]byte{}, nil, nil, 0, transactions), 0)
+	proof, _ := NewMerkleProof(transactions, transaction1.Id(), 0)
+	proof.transactionId = transaction2.Id()
+
+	// Act
+	err := proof.Verify(header)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_Verify_MerkleRootIsNotTheHeaderOne_ReturnsError(t *testing.T) {
+	// Arrange
+	transaction1, _ := NewRewardTransaction(test.Address, false, 1, 0)
+	transaction2, _ := NewRewardTransaction(test.Address, false, 2, 0)
+	header, _ := NewBlockHeader(NewBlock([32]byte{}, nil, nil, 0, []*Transaction{transaction2}), 0)
+	proof, _ := NewMerkleProof([]*Transaction{transaction1}, transaction1.Id(), 0)
+
+	// Act
+	err := proof.Verify(header)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_Verify_HeaderIsTampered_ReturnsError(t *testing.T) {
+	// Arrange
+	transaction1, _ := NewRewardTransaction(test.Address, false, 1, 0)
+	transaction2, _ := NewRewardTransaction(test.Address, false, 2, 0)
+	header, _ := NewBlockHeader(NewBlock([32]byte{}, nil, nil, 0, []*Transaction{transaction2}), 0)
+	proof, _ := NewMerkleProof([]*Transaction{transaction1}, transaction1.Id(), 0)
+	header.merkleRoot = proof.MerkleRoot()
+
+	// Act
+	err := proof.Verify(header)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
+
+func Test_NewMerkleProof_TransactionIsNotInBlock_ReturnsError(t *testing.T) {
+	// Arrange
+	transaction, _ := NewRewardTransaction(test.Address, false, 1, 0)
+	transactions := []*Transaction{transaction}
+
+	// Act
+	_, err := NewMerkleProof(transactions, "unknown", 0)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should")
+}
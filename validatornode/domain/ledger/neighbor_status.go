@@ -0,0 +1,72 @@
+package ledger
+
+import (
+	"encoding/json"
+)
+
+type neighborStatusDto struct {
+	BanExpirationTimestamp int64  `json:"ban_expiration_timestamp"`
+	IsOutbound             bool   `json:"is_outbound"`
+	MisbehaviorScore       int    `json:"misbehavior_score"`
+	Score                  int    `json:"score"`
+	Target                 string `json:"target"`
+}
+
+type NeighborStatus struct {
+	banExpirationTimestamp int64
+	isOutbound             bool
+	misbehaviorScore       int
+	score                  int
+	target                 string
+}
+
+func NewNeighborStatus(banExpirationTimestamp int64, isOutbound bool, misbehaviorScore int, score int, target string) *NeighborStatus {
+	return &NeighborStatus{banExpirationTimestamp, isOutbound, misbehaviorScore, score, target}
+}
+
+func (status *NeighborStatus) UnmarshalJSON(data []byte) error {
+	var dto *neighborStatusDto
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	status.banExpirationTimestamp = dto.BanExpirationTimestamp
+	status.isOutbound = dto.IsOutbound
+	status.misbehaviorScore = dto.MisbehaviorScore
+	status.score = dto.Score
+	status.target = dto.Target
+	return nil
+}
+
+func (status *NeighborStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(neighborStatusDto{
+		BanExpirationTimestamp: status.banExpirationTimestamp,
+		IsOutbound:             status.isOutbound,
+		MisbehaviorScore:       status.misbehaviorScore,
+		Score:                  status.score,
+		Target:                 status.target,
+	})
+}
+
+func (status *NeighborStatus) BanExpirationTimestamp() int64 {
+	return status.banExpirationTimestamp
+}
+
+func (status *NeighborStatus) IsBanned() bool {
+	return status.banExpirationTimestamp != 0
+}
+
+func (status *NeighborStatus) IsOutbound() bool {
+	return status.isOutbound
+}
+
+func (status *NeighborStatus) MisbehaviorScore() int {
+	return status.misbehaviorScore
+}
+
+func (status *NeighborStatus) Score() int {
+	return status.score
+}
+
+func (status *NeighborStatus) Target() string {
+	return status.target
+}
@@ -0,0 +1,75 @@
+package ledger
+
+import (
+	"encoding/json"
+)
+
+const (
+	AcceptedTransactionStatus = "accepted"
+	RejectedTransactionStatus = "rejected"
+
+	AlreadyPendingReasonCode         = "already_pending"
+	BlockchainIsEmptyReasonCode      = "blockchain_is_empty"
+	DoubleSpendReasonCode            = "double_spend"
+	InternalErrorReasonCode          = "internal_error"
+	InvalidFeeReasonCode             = "invalid_fee"
+	InvalidSignatureReasonCode       = "invalid_signature"
+	PoolIsFullReasonCode             = "pool_is_full"
+	TimestampIsInTheFutureReasonCode = "timestamp_is_in_the_future"
+	TimestampIsTooOldReasonCode      = "timestamp_is_too_old"
+)
+
+type transactionResultDto struct {
+	Reason     string `json:"reason"`
+	ReasonCode string `json:"reason_code"`
+	Status     string `json:"status"`
+}
+
+type TransactionResult struct {
+	reason     string
+	reasonCode string
+	status     string
+}
+
+func NewAcceptedTransactionResult() *TransactionResult {
+	return &TransactionResult{"", "", AcceptedTransactionStatus}
+}
+
+func NewRejectedTransactionResult(reasonCode string, reason string) *TransactionResult {
+	return &TransactionResult{reason, reasonCode, RejectedTransactionStatus}
+}
+
+func (result *TransactionResult) UnmarshalJSON(data []byte) error {
+	var dto *transactionResultDto
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	result.reason = dto.Reason
+	result.reasonCode = dto.ReasonCode
+	result.status = dto.Status
+	return nil
+}
+
+func (result *TransactionResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(transactionResultDto{
+		Reason:     result.reason,
+		ReasonCode: result.reasonCode,
+		Status:     result.status,
+	})
+}
+
+func (result *TransactionResult) IsAccepted() bool {
+	return result.status == AcceptedTransactionStatus
+}
+
+func (result *TransactionResult) Reason() string {
+	return result.reason
+}
+
+func (result *TransactionResult) ReasonCode() string {
+	return result.reasonCode
+}
+
+func (result *TransactionResult) Status() string {
+	return result.status
+}
@@ -0,0 +1,44 @@
+package ledger
+
+import (
+	"encoding/json"
+)
+
+type transactionsAnnouncementDto struct {
+	TransactionIds               []string `json:"transaction_ids"`
+	TransactionBroadcasterTarget string   `json:"transaction_broadcaster_target"`
+}
+
+type TransactionsAnnouncement struct {
+	transactionIds               []string
+	transactionBroadcasterTarget string
+}
+
+func NewTransactionsAnnouncement(transactionIds []string, transactionBroadcasterTarget string) *TransactionsAnnouncement {
+	return &TransactionsAnnouncement{transactionIds, transactionBroadcasterTarget}
+}
+
+func (announcement *TransactionsAnnouncement) UnmarshalJSON(data []byte) error {
+	var dto *transactionsAnnouncementDto
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	announcement.transactionIds = dto.TransactionIds
+	announcement.transactionBroadcasterTarget = dto.TransactionBroadcasterTarget
+	return nil
+}
+
+func (announcement *TransactionsAnnouncement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(transactionsAnnouncementDto{
+		TransactionIds:               announcement.transactionIds,
+		TransactionBroadcasterTarget: announcement.transactionBroadcasterTarget,
+	})
+}
+
+func (announcement *TransactionsAnnouncement) TransactionIds() []string {
+	return announcement.transactionIds
+}
+
+func (announcement *TransactionsAnnouncement) TransactionBroadcasterTarget() string {
+	return announcement.transactionBroadcasterTarget
+}
@@ -6,13 +6,21 @@ import (
 )
 
 type hostSettingsDto struct {
-	Ip   string
-	Port int
+	Ip                          string
+	MaxInboundsCount            int
+	MaxRequestSizeInBytes       int
+	MaxRequestsPerSecond        int
+	MaxRequestsPerSecondByTopic map[string]int
+	Port                        int
 }
 
 type HostSettings struct {
-	ip   string
-	port string
+	ip                          string
+	maxInboundsCount            int
+	maxRequestSize              int
+	maxRequestsPerSecond        int
+	maxRequestsPerSecondByTopic map[string]int
+	port                        string
 }
 
 func (settings *HostSettings) UnmarshalJSON(data []byte) error {
@@ -22,6 +30,10 @@ func (settings *HostSettings) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	settings.ip = dto.Ip
+	settings.maxInboundsCount = dto.MaxInboundsCount
+	settings.maxRequestSize = dto.MaxRequestSizeInBytes
+	settings.maxRequestsPerSecond = dto.MaxRequestsPerSecond
+	settings.maxRequestsPerSecondByTopic = dto.MaxRequestsPerSecondByTopic
 	settings.port = strconv.Itoa(dto.Port)
 	return nil
 }
@@ -30,6 +42,22 @@ func (settings *HostSettings) Ip() string {
 	return settings.ip
 }
 
+func (settings *HostSettings) MaxInboundsCount() int {
+	return settings.maxInboundsCount
+}
+
+func (settings *HostSettings) MaxRequestSize() int {
+	return settings.maxRequestSize
+}
+
+func (settings *HostSettings) MaxRequestsPerSecond() int {
+	return settings.maxRequestsPerSecond
+}
+
+func (settings *HostSettings) MaxRequestsPerSecondByTopic() map[string]int {
+	return settings.maxRequestsPerSecondByTopic
+}
+
 func (settings *HostSettings) Port() string {
 	return settings.port
 }
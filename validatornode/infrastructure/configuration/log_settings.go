@@ -2,14 +2,23 @@ package configuration
 
 import (
 	"encoding/json"
+	"fmt"
+)
+
+const (
+	ConsoleLogFormat = "console"
+	JsonLogFormat    = "json"
+	LogfmtLogFormat  = "logfmt"
 )
 
 type logSettingsDto struct {
-	Level string
+	Format string
+	Level  string
 }
 
 type LogSettings struct {
-	level string
+	format string
+	level  string
 }
 
 func (settings *LogSettings) UnmarshalJSON(data []byte) error {
@@ -18,10 +27,22 @@ func (settings *LogSettings) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
+	switch dto.Format {
+	case "":
+		settings.format = ConsoleLogFormat
+	case ConsoleLogFormat, JsonLogFormat, LogfmtLogFormat:
+		settings.format = dto.Format
+	default:
+		return fmt.Errorf("unknown log format %s", dto.Format)
+	}
 	settings.level = dto.Level
 	return nil
 }
 
+func (settings *LogSettings) Format() string {
+	return settings.format
+}
+
 func (settings *LogSettings) Level() string {
 	return settings.level
 }
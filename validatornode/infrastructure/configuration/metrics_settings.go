@@ -0,0 +1,35 @@
+package configuration
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+type metricsSettingsDto struct {
+	IsEnabled bool
+	Port      int
+}
+
+type MetricsSettings struct {
+	isEnabled bool
+	port      string
+}
+
+func (settings *MetricsSettings) UnmarshalJSON(data []byte) error {
+	var dto *metricsSettingsDto
+	err := json.Unmarshal(data, &dto)
+	if err != nil {
+		return err
+	}
+	settings.isEnabled = dto.IsEnabled
+	settings.port = strconv.Itoa(dto.Port)
+	return nil
+}
+
+func (settings *MetricsSettings) IsEnabled() bool {
+	return settings.isEnabled
+}
+
+func (settings *MetricsSettings) Port() string {
+	return settings.port
+}
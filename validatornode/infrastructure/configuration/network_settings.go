@@ -2,21 +2,49 @@ package configuration
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
+const (
+	TcpTransport = "tcp"
+	TlsTransport = "tls"
+)
+
 type networkSettingsDto struct {
+	AddressBookEntryLifetimeInHours  int
+	AddressBookMaxFailuresCount      int
+	BanDurationInSeconds             int
+	BanScoreThreshold                int
 	ConnectionTimeoutInSeconds       int
 	MaxOutboundsCount                int
+	MaxRelayedTransactionsPerSecond  int
+	NetworkId                        string
 	Seeds                            []string
+	SeenBlocksTtlInSeconds           int
+	SeenTransactionsTtlInSeconds     int
 	SynchronizationIntervalInSeconds int
+	RelayIntervalInSeconds           int
+	Transport                        string
+	TrustedIdentityKeys              map[string]string
 }
 
 type NetworkSettings struct {
-	connectionTimeout    time.Duration
-	maxOutboundsCount    int
-	seeds                []string
-	synchronizationTimer time.Duration
+	addressBookEntryLifetime        time.Duration
+	addressBookMaxFailuresCount     int
+	banDuration                     time.Duration
+	banScoreThreshold               int
+	connectionTimeout               time.Duration
+	maxOutboundsCount               int
+	maxRelayedTransactionsPerSecond int
+	networkId                       string
+	seeds                           []string
+	seenBlocksTtl                   time.Duration
+	seenTransactionsTtl             time.Duration
+	synchronizationTimer            time.Duration
+	relayTimer                      time.Duration
+	transport                       string
+	trustedIdentityKeys             map[string]string
 }
 
 func (settings *NetworkSettings) UnmarshalJSON(data []byte) error {
@@ -25,13 +53,47 @@ func (settings *NetworkSettings) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
+	settings.addressBookEntryLifetime = time.Duration(dto.AddressBookEntryLifetimeInHours) * time.Hour
+	settings.addressBookMaxFailuresCount = dto.AddressBookMaxFailuresCount
+	settings.banDuration = time.Duration(dto.BanDurationInSeconds) * time.Second
+	settings.banScoreThreshold = dto.BanScoreThreshold
 	settings.connectionTimeout = time.Duration(dto.ConnectionTimeoutInSeconds) * time.Second
 	settings.maxOutboundsCount = dto.MaxOutboundsCount
+	settings.maxRelayedTransactionsPerSecond = dto.MaxRelayedTransactionsPerSecond
+	settings.networkId = dto.NetworkId
 	settings.seeds = dto.Seeds
+	settings.seenBlocksTtl = time.Duration(dto.SeenBlocksTtlInSeconds) * time.Second
+	settings.seenTransactionsTtl = time.Duration(dto.SeenTransactionsTtlInSeconds) * time.Second
 	settings.synchronizationTimer = time.Duration(dto.SynchronizationIntervalInSeconds) * time.Second
+	settings.relayTimer = time.Duration(dto.RelayIntervalInSeconds) * time.Second
+	settings.trustedIdentityKeys = dto.TrustedIdentityKeys
+	switch dto.Transport {
+	case "":
+		settings.transport = TcpTransport
+	case TcpTransport, TlsTransport:
+		settings.transport = dto.Transport
+	default:
+		return fmt.Errorf("unknown transport %s", dto.Transport)
+	}
 	return nil
 }
 
+func (settings *NetworkSettings) AddressBookEntryLifetime() time.Duration {
+	return settings.addressBookEntryLifetime
+}
+
+func (settings *NetworkSettings) AddressBookMaxFailuresCount() int {
+	return settings.addressBookMaxFailuresCount
+}
+
+func (settings *NetworkSettings) BanDuration() time.Duration {
+	return settings.banDuration
+}
+
+func (settings *NetworkSettings) BanScoreThreshold() int {
+	return settings.banScoreThreshold
+}
+
 func (settings *NetworkSettings) ConnectionTimeout() time.Duration {
 	return settings.connectionTimeout
 }
@@ -40,6 +102,22 @@ func (settings *NetworkSettings) MaxOutboundsCount() int {
 	return settings.maxOutboundsCount
 }
 
+func (settings *NetworkSettings) MaxRelayedTransactionsPerSecond() int {
+	return settings.maxRelayedTransactionsPerSecond
+}
+
+func (settings *NetworkSettings) NetworkId() string {
+	return settings.networkId
+}
+
+func (settings *NetworkSettings) SeenBlocksTtl() time.Duration {
+	return settings.seenBlocksTtl
+}
+
+func (settings *NetworkSettings) SeenTransactionsTtl() time.Duration {
+	return settings.seenTransactionsTtl
+}
+
 func (settings *NetworkSettings) SynchronizationTimer() time.Duration {
 	return settings.synchronizationTimer
 }
@@ -47,3 +125,15 @@ func (settings *NetworkSettings) SynchronizationTimer() time.Duration {
 func (settings *NetworkSettings) Seeds() []string {
 	return settings.seeds
 }
+
+func (settings *NetworkSettings) RelayTimer() time.Duration {
+	return settings.relayTimer
+}
+
+func (settings *NetworkSettings) Transport() string {
+	return settings.transport
+}
+
+func (settings *NetworkSettings) TrustedIdentityKeys() map[string]string {
+	return settings.trustedIdentityKeys
+}
@@ -0,0 +1,34 @@
+package configuration
+
+import (
+	"encoding/json"
+)
+
+type poolSettingsDto struct {
+	MaxTransactionsCount    int
+	MaxTransactionsPerBlock int
+}
+
+type PoolSettings struct {
+	maxTransactionsCount    int
+	maxTransactionsPerBlock int
+}
+
+func (settings *PoolSettings) UnmarshalJSON(data []byte) error {
+	var dto *poolSettingsDto
+	err := json.Unmarshal(data, &dto)
+	if err != nil {
+		return err
+	}
+	settings.maxTransactionsCount = dto.MaxTransactionsCount
+	settings.maxTransactionsPerBlock = dto.MaxTransactionsPerBlock
+	return nil
+}
+
+func (settings *PoolSettings) MaxTransactionsCount() int {
+	return settings.maxTransactionsCount
+}
+
+func (settings *PoolSettings) MaxTransactionsPerBlock() int {
+	return settings.maxTransactionsPerBlock
+}
@@ -9,18 +9,26 @@ import (
 
 type settingsDto struct {
 	Host      *HostSettings
+	Metrics   *MetricsSettings
 	Network   *NetworkSettings
+	Pool      *PoolSettings
 	Protocol  *ProtocolSettings
 	Registry  *RegistrySettings
+	Status    *StatusSettings
+	Storage   *StorageSettings
 	Validator *ValidatorSettings
 	Log       *LogSettings
 }
 
 type Settings struct {
 	host      *HostSettings
+	metrics   *MetricsSettings
 	network   *NetworkSettings
+	pool      *PoolSettings
 	protocol  *ProtocolSettings
 	registry  *RegistrySettings
+	status    *StatusSettings
+	storage   *StorageSettings
 	validator *ValidatorSettings
 	log       *LogSettings
 }
@@ -51,9 +59,13 @@ func (settings *Settings) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	settings.host = dto.Host
+	settings.metrics = dto.Metrics
 	settings.network = dto.Network
+	settings.pool = dto.Pool
 	settings.protocol = dto.Protocol
 	settings.registry = dto.Registry
+	settings.status = dto.Status
+	settings.storage = dto.Storage
 	settings.validator = dto.Validator
 	settings.log = dto.Log
 	return nil
@@ -63,10 +75,18 @@ func (settings *Settings) Host() *HostSettings {
 	return settings.host
 }
 
+func (settings *Settings) Metrics() *MetricsSettings {
+	return settings.metrics
+}
+
 func (settings *Settings) Network() *NetworkSettings {
 	return settings.network
 }
 
+func (settings *Settings) Pool() *PoolSettings {
+	return settings.pool
+}
+
 func (settings *Settings) Protocol() *ProtocolSettings {
 	return settings.protocol
 }
@@ -75,6 +95,14 @@ func (settings *Settings) Registry() *RegistrySettings {
 	return settings.registry
 }
 
+func (settings *Settings) Status() *StatusSettings {
+	return settings.status
+}
+
+func (settings *Settings) Storage() *StorageSettings {
+	return settings.storage
+}
+
 func (settings *Settings) Validator() *ValidatorSettings {
 	return settings.validator
 }
@@ -0,0 +1,42 @@
+package configuration
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+type statusSettingsDto struct {
+	IsEnabled       bool
+	MaxBlocksBehind uint64
+	Port            int
+}
+
+type StatusSettings struct {
+	isEnabled       bool
+	maxBlocksBehind uint64
+	port            string
+}
+
+func (settings *StatusSettings) UnmarshalJSON(data []byte) error {
+	var dto *statusSettingsDto
+	err := json.Unmarshal(data, &dto)
+	if err != nil {
+		return err
+	}
+	settings.isEnabled = dto.IsEnabled
+	settings.maxBlocksBehind = dto.MaxBlocksBehind
+	settings.port = strconv.Itoa(dto.Port)
+	return nil
+}
+
+func (settings *StatusSettings) IsEnabled() bool {
+	return settings.isEnabled
+}
+
+func (settings *StatusSettings) MaxBlocksBehind() uint64 {
+	return settings.maxBlocksBehind
+}
+
+func (settings *StatusSettings) Port() string {
+	return settings.port
+}
@@ -0,0 +1,41 @@
+package configuration
+
+import (
+	"encoding/json"
+)
+
+type storageSettingsDto struct {
+	Directory                string
+	IsAddressIndexEnabled    bool
+	SnapshotIntervalInBlocks uint64
+}
+
+type StorageSettings struct {
+	directory             string
+	isAddressIndexEnabled bool
+	snapshotInterval      uint64
+}
+
+func (settings *StorageSettings) UnmarshalJSON(data []byte) error {
+	var dto *storageSettingsDto
+	err := json.Unmarshal(data, &dto)
+	if err != nil {
+		return err
+	}
+	settings.directory = dto.Directory
+	settings.isAddressIndexEnabled = dto.IsAddressIndexEnabled
+	settings.snapshotInterval = dto.SnapshotIntervalInBlocks
+	return nil
+}
+
+func (settings *StorageSettings) Directory() string {
+	return settings.directory
+}
+
+func (settings *StorageSettings) IsAddressIndexEnabled() bool {
+	return settings.isAddressIndexEnabled
+}
+
+func (settings *StorageSettings) SnapshotInterval() uint64 {
+	return settings.snapshotInterval
+}
@@ -0,0 +1,41 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+)
+
+type AddressBookFile struct {
+	path string
+}
+
+func NewAddressBookFile(path string) *AddressBookFile {
+	return &AddressBookFile{path}
+}
+
+func (addressBookFile *AddressBookFile) AddressBook() ([]*ledger.AddressBookEntry, error) {
+	addressBookBytes, err := os.ReadFile(addressBookFile.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read file: %w", err)
+	}
+	var entries []*ledger.AddressBookEntry
+	if err = json.Unmarshal(addressBookBytes, &entries); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal address book: %w", err)
+	}
+	return entries, nil
+}
+
+func (addressBookFile *AddressBookFile) SaveAddressBook(entries []*ledger.AddressBookEntry) error {
+	addressBookBytes, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("unable to marshal address book: %w", err)
+	}
+	return writeAtomically(addressBookFile.path, addressBookBytes)
+}
@@ -0,0 +1,38 @@
+package file
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_AddressBook_FileDoesNotExist_ReturnsEmptyArray(t *testing.T) {
+	// Arrange
+	addressBookFile := NewAddressBookFile(filepath.Join(t.TempDir(), "address-book"))
+
+	// Act
+	entries, err := addressBookFile.AddressBook()
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	test.Assert(t, len(entries) == 0, "entries should be empty")
+}
+
+func Test_AddressBook_AddressBookSaved_ReturnsSavedEntries(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "address-book")
+	entry := ledger.NewAddressBookEntry(1, 2, 3, "0.0.0.0:1")
+	_ = NewAddressBookFile(path).SaveAddressBook([]*ledger.AddressBookEntry{entry})
+
+	// Act
+	entries, err := NewAddressBookFile(path).AddressBook()
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	test.Assert(t, len(entries) == 1, fmt.Sprintf("entries count is %d whereas it should be %d", len(entries), 1))
+	test.Assert(t, entries[0].Target() == entry.Target(), "wrong entry loaded")
+	test.Assert(t, entries[0].LastSeenTimestamp() == entry.LastSeenTimestamp(), "wrong last seen timestamp loaded")
+}
@@ -0,0 +1,48 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+)
+
+type BlocksFile struct {
+	file *linesFile
+}
+
+func NewBlocksFile(path string) *BlocksFile {
+	return &BlocksFile{newLinesFile(path)}
+}
+
+func (blocksFile *BlocksFile) AddBlock(block *ledger.Block) error {
+	blockBytes, err := json.Marshal(block)
+	if err != nil {
+		return fmt.Errorf("unable to marshal block: %w", err)
+	}
+	return blocksFile.file.append(blockBytes)
+}
+
+func (blocksFile *BlocksFile) Blocks() ([]*ledger.Block, error) {
+	lines, err := blocksFile.file.lines()
+	if err != nil {
+		return nil, err
+	}
+	var blocks []*ledger.Block
+	for i, line := range lines {
+		var block *ledger.Block
+		if err = json.Unmarshal(line, &block); err != nil {
+			// The blocks following a corrupted one cannot be chained anymore, they are discarded
+			if err = blocksFile.file.truncate(i); err != nil {
+				return nil, err
+			}
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+func (blocksFile *BlocksFile) Truncate(blocksCount uint64) error {
+	return blocksFile.file.truncate(int(blocksCount))
+}
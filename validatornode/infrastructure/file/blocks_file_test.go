@@ -0,0 +1,84 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/encryption"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_Blocks_FileDoesNotExist_ReturnsEmptyArray(t *testing.T) {
+	// Arrange
+	directory := t.TempDir()
+	blocksFile := NewBlocksFile(filepath.Join(directory, "blocks"))
+
+	// Act
+	blocks, err := blocksFile.Blocks()
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	test.Assert(t, len(blocks) == 0, "blocks should be empty")
+}
+
+func Test_Blocks_BlocksAdded_ReturnsAddedBlocks(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	path := filepath.Join(t.TempDir(), "blocks")
+	blocksFile := NewBlocksFile(path)
+	genesisBlock := ledger.NewGenesisBlock(test.Address, 0)
+	genesisBlockHash, _ := genesisBlock.Hash()
+	block := ledger.NewRewardedBlock(genesisBlockHash, 1, privateKey)
+	_ = blocksFile.AddBlock(genesisBlock)
+	_ = blocksFile.AddBlock(block)
+
+	// Act
+	blocks, err := NewBlocksFile(path).Blocks()
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	test.Assert(t, len(blocks) == 2, fmt.Sprintf("blocks count is %d whereas it should be %d", len(blocks), 2))
+	actualHash, _ := blocks[1].Hash()
+	expectedHash, _ := block.Hash()
+	test.Assert(t, actualHash == expectedHash, "wrong block loaded")
+}
+
+func Test_Blocks_LastBlockIsPartiallyWritten_ReturnsCompleteBlocks(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "blocks")
+	blocksFile := NewBlocksFile(path)
+	_ = blocksFile.AddBlock(ledger.NewGenesisBlock(test.Address, 0))
+	osFile, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	_, _ = osFile.Write([]byte(`{"previous_hash":`))
+	_ = osFile.Close()
+
+	// Act
+	blocks, err := NewBlocksFile(path).Blocks()
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	test.Assert(t, len(blocks) == 1, fmt.Sprintf("blocks count is %d whereas it should be %d", len(blocks), 1))
+}
+
+func Test_Truncate_BlocksAdded_RemovesFollowingBlocks(t *testing.T) {
+	// Arrange
+	privateKey, _ := encryption.NewPrivateKeyFromHex(test.PrivateKey)
+	path := filepath.Join(t.TempDir(), "blocks")
+	blocksFile := NewBlocksFile(path)
+	genesisBlock := ledger.NewGenesisBlock(test.Address, 0)
+	genesisBlockHash, _ := genesisBlock.Hash()
+	_ = blocksFile.AddBlock(genesisBlock)
+	_ = blocksFile.AddBlock(ledger.NewRewardedBlock(genesisBlockHash, 1, privateKey))
+	_ = blocksFile.AddBlock(ledger.NewRewardedBlock(genesisBlockHash, 2, privateKey))
+
+	// Act
+	err := blocksFile.Truncate(1)
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	blocks, _ := NewBlocksFile(path).Blocks()
+	test.Assert(t, len(blocks) == 1, fmt.Sprintf("blocks count is %d whereas it should be %d", len(blocks), 1))
+}
@@ -0,0 +1,135 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+type linesFile struct {
+	path     string
+	offsets  []int64
+	size     int64
+	isLoaded bool
+	mutex    sync.Mutex
+}
+
+func newLinesFile(path string) *linesFile {
+	return &linesFile{path: path}
+}
+
+func (file *linesFile) append(line []byte) error {
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+	if !file.isLoaded {
+		if _, err := file.load(); err != nil {
+			return err
+		}
+	}
+	osFile, err := os.OpenFile(file.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open file: %w", err)
+	}
+	record := append(line, '\n')
+	if _, err = osFile.Write(record); err != nil {
+		_ = osFile.Close()
+		return fmt.Errorf("unable to write file: %w", err)
+	}
+	if err = osFile.Sync(); err != nil {
+		_ = osFile.Close()
+		return fmt.Errorf("unable to sync file: %w", err)
+	}
+	if err = osFile.Close(); err != nil {
+		return fmt.Errorf("unable to close file: %w", err)
+	}
+	file.offsets = append(file.offsets, file.size)
+	file.size += int64(len(record))
+	return nil
+}
+
+func (file *linesFile) lines() ([][]byte, error) {
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+	return file.load()
+}
+
+func (file *linesFile) rewrite(lines [][]byte) error {
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+	var buffer bytes.Buffer
+	var offsets []int64
+	for _, line := range lines {
+		offsets = append(offsets, int64(buffer.Len()))
+		buffer.Write(line)
+		buffer.WriteByte('\n')
+	}
+	if err := writeAtomically(file.path, buffer.Bytes()); err != nil {
+		return err
+	}
+	file.offsets = offsets
+	file.size = int64(buffer.Len())
+	file.isLoaded = true
+	return nil
+}
+
+func (file *linesFile) truncate(linesCount int) error {
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+	if !file.isLoaded {
+		if _, err := file.load(); err != nil {
+			return err
+		}
+	}
+	if linesCount >= len(file.offsets) {
+		return nil
+	}
+	size := file.offsets[linesCount]
+	if err := os.Truncate(file.path, size); err != nil {
+		return fmt.Errorf("unable to truncate file: %w", err)
+	}
+	file.offsets = file.offsets[:linesCount]
+	file.size = size
+	return nil
+}
+
+func (file *linesFile) load() ([][]byte, error) {
+	if err := os.MkdirAll(filepath.Dir(file.path), 0755); err != nil {
+		return nil, fmt.Errorf("unable to create directory: %w", err)
+	}
+	osFile, err := os.OpenFile(file.path, os.O_CREATE|os.O_RDONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file: %w", err)
+	}
+	var lines [][]byte
+	var offsets []int64
+	var size int64
+	reader := bufio.NewReader(osFile)
+	for {
+		line, readError := reader.ReadBytes('\n')
+		if errors.Is(readError, io.EOF) {
+			// An unterminated last line is the trace of an interrupted write, it is discarded
+			break
+		} else if readError != nil {
+			_ = osFile.Close()
+			return nil, fmt.Errorf("unable to read file: %w", readError)
+		}
+		offsets = append(offsets, size)
+		size += int64(len(line))
+		lines = append(lines, line[:len(line)-1])
+	}
+	if err = osFile.Close(); err != nil {
+		return nil, fmt.Errorf("unable to close file: %w", err)
+	}
+	if err = os.Truncate(file.path, size); err != nil {
+		return nil, fmt.Errorf("unable to truncate file: %w", err)
+	}
+	file.offsets = offsets
+	file.size = size
+	file.isLoaded = true
+	return lines, nil
+}
@@ -0,0 +1,45 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type SnapshotFile struct {
+	path string
+}
+
+func NewSnapshotFile(path string) *SnapshotFile {
+	return &SnapshotFile{path}
+}
+
+func (snapshotFile *SnapshotFile) SaveSnapshot(snapshot []byte) error {
+	return writeAtomically(snapshotFile.path, snapshot)
+}
+
+func (snapshotFile *SnapshotFile) Snapshot() ([]byte, error) {
+	snapshot, err := os.ReadFile(snapshotFile.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read file: %w", err)
+	}
+	return snapshot, nil
+}
+
+func writeAtomically(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("unable to create directory: %w", err)
+	}
+	temporaryPath := path + ".tmp"
+	if err := os.WriteFile(temporaryPath, data, 0644); err != nil {
+		return fmt.Errorf("unable to write file: %w", err)
+	}
+	if err := os.Rename(temporaryPath, path); err != nil {
+		return fmt.Errorf("unable to rename file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+)
+
+type TransactionsFile struct {
+	file *linesFile
+}
+
+func NewTransactionsFile(path string) *TransactionsFile {
+	return &TransactionsFile{newLinesFile(path)}
+}
+
+func (transactionsFile *TransactionsFile) AddTransaction(transaction *ledger.Transaction) error {
+	transactionBytes, err := json.Marshal(transaction)
+	if err != nil {
+		return fmt.Errorf("unable to marshal transaction: %w", err)
+	}
+	return transactionsFile.file.append(transactionBytes)
+}
+
+func (transactionsFile *TransactionsFile) SaveTransactions(transactions []*ledger.Transaction) error {
+	lines := make([][]byte, len(transactions))
+	for i, transaction := range transactions {
+		transactionBytes, err := json.Marshal(transaction)
+		if err != nil {
+			return fmt.Errorf("unable to marshal transaction: %w", err)
+		}
+		lines[i] = transactionBytes
+	}
+	return transactionsFile.file.rewrite(lines)
+}
+
+func (transactionsFile *TransactionsFile) Transactions() ([]*ledger.Transaction, error) {
+	lines, err := transactionsFile.file.lines()
+	if err != nil {
+		return nil, err
+	}
+	var transactions []*ledger.Transaction
+	for _, line := range lines {
+		var transaction *ledger.Transaction
+		if err = json.Unmarshal(line, &transaction); err != nil {
+			// Unlike blocks, transactions are independent, a corrupted one does not invalidate the following ones
+			continue
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
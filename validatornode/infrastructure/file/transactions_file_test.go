@@ -0,0 +1,57 @@
+package file
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_Transactions_FileDoesNotExist_ReturnsEmptyArray(t *testing.T) {
+	// Arrange
+	transactionsFile := NewTransactionsFile(filepath.Join(t.TempDir(), "transactions"))
+
+	// Act
+	transactions, err := transactionsFile.Transactions()
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	test.Assert(t, len(transactions) == 0, "transactions should be empty")
+}
+
+func Test_Transactions_TransactionsAdded_ReturnsAddedTransactions(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "transactions")
+	transactionsFile := NewTransactionsFile(path)
+	transaction1, _ := ledger.NewRewardTransaction(test.Address, false, 1, 0)
+	transaction2, _ := ledger.NewRewardTransaction(test.Address, false, 2, 0)
+	_ = transactionsFile.AddTransaction(transaction1)
+	_ = transactionsFile.AddTransaction(transaction2)
+
+	// Act
+	transactions, err := NewTransactionsFile(path).Transactions()
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	test.Assert(t, len(transactions) == 2, fmt.Sprintf("transactions count is %d whereas it should be %d", len(transactions), 2))
+	test.Assert(t, transactions[1].Id() == transaction2.Id(), "wrong transaction loaded")
+}
+
+func Test_Transactions_TransactionsSaved_ReturnsOnlySavedTransactions(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "transactions")
+	transactionsFile := NewTransactionsFile(path)
+	transaction1, _ := ledger.NewRewardTransaction(test.Address, false, 1, 0)
+	transaction2, _ := ledger.NewRewardTransaction(test.Address, false, 2, 0)
+	_ = transactionsFile.AddTransaction(transaction1)
+	_ = transactionsFile.SaveTransactions([]*ledger.Transaction{transaction2})
+
+	// Act
+	transactions, err := NewTransactionsFile(path).Transactions()
+
+	// Assert
+	test.Assert(t, err == nil, "error is returned whereas it should not")
+	test.Assert(t, len(transactions) == 1 && transactions[0].Id() == transaction2.Id(), "saved transactions are not the loaded ones")
+}
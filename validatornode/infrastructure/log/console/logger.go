@@ -1,74 +1,55 @@
 package console
 
 import (
-	"log"
-	"strings"
-)
-
-type Level uint32
+	"fmt"
+	stdlog "log"
 
-const (
-	debug Level = iota
-	info
-	warn
-	err
-	fatal
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 )
 
-func parseLevel(level string) Level {
-	switch strings.ToLower(level) {
-	case "debug":
-		return debug
-	case "info":
-		return info
-	case "warn":
-		return warn
-	case "error":
-		return err
-	case "fatal":
-		return fatal
-	}
-	return info
-}
-
 type Logger struct {
-	level Level
+	fields string
+	level  log.Level
 }
 
 func NewLogger(level string) *Logger {
-	return &Logger{parseLevel(level)}
+	return &Logger{level: log.ParseLevel(level)}
 }
 
 func NewFatalLogger() *Logger {
-	return &Logger{fatal}
+	return &Logger{level: log.FatalLevel}
 }
 
 func (logger *Logger) Debug(msg string) {
-	if logger.level <= debug {
-		log.Println("DEBUG:", msg)
+	if logger.level <= log.DebugLevel {
+		stdlog.Println("DEBUG:", msg+logger.fields)
 	}
 }
 
 func (logger *Logger) Info(msg string) {
-	if logger.level <= info {
-		log.Println("INFO:", msg)
+	if logger.level <= log.InfoLevel {
+		stdlog.Println("INFO:", msg+logger.fields)
 	}
 }
 
 func (logger *Logger) Warn(msg string) {
-	if logger.level <= warn {
-		log.Println("WARN:", msg)
+	if logger.level <= log.WarnLevel {
+		stdlog.Println("WARN:", msg+logger.fields)
 	}
 }
 
 func (logger *Logger) Error(msg string) {
-	if logger.level <= err {
-		log.Println("ERROR:", msg)
+	if logger.level <= log.ErrorLevel {
+		stdlog.Println("ERROR:", msg+logger.fields)
 	}
 }
 
 func (logger *Logger) Fatal(msg string) {
-	if logger.level <= fatal {
-		log.Panicln("FATAL:", msg)
+	if logger.level <= log.FatalLevel {
+		stdlog.Println("FATAL:", msg+logger.fields)
 	}
 }
+
+func (logger *Logger) With(key string, value interface{}) log.Logger {
+	return &Logger{fmt.Sprintf("%s %s=%v", logger.fields, key, value), logger.level}
+}
@@ -1,8 +1,18 @@
 package log
 
+const (
+	BlockHeightKey   = "block_height"
+	ComponentKey     = "component"
+	ErrorKey         = "error"
+	TargetKey        = "target"
+	TaskKey          = "task"
+	TransactionIdKey = "transaction_id"
+)
+
 type Logger interface {
 	Debug(msg string)
 	Info(msg string)
 	Warn(msg string)
 	Error(msg string)
+	With(key string, value interface{}) Logger
 }
@@ -29,6 +29,9 @@ var _ Logger = &LoggerMock{}
 //			WarnFunc: func(msg string)  {
 //				panic("mock out the Warn method")
 //			},
+//			WithFunc: func(key string, value interface{}) Logger {
+//				panic("mock out the With method")
+//			},
 //		}
 //
 //		// use mockedLogger in code that requires Logger
@@ -48,6 +51,9 @@ type LoggerMock struct {
 	// WarnFunc mocks the Warn method.
 	WarnFunc func(msg string)
 
+	// WithFunc mocks the With method.
+	WithFunc func(key string, value interface{}) Logger
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Debug holds details about calls to the Debug method.
@@ -70,11 +76,19 @@ type LoggerMock struct {
 			// Msg is the msg argument value.
 			Msg string
 		}
+		// With holds details about calls to the With method.
+		With []struct {
+			// Key is the key argument value.
+			Key string
+			// Value is the value argument value.
+			Value interface{}
+		}
 	}
 	lockDebug sync.RWMutex
 	lockError sync.RWMutex
 	lockInfo  sync.RWMutex
 	lockWarn  sync.RWMutex
+	lockWith  sync.RWMutex
 }
 
 func NewLoggerMock() *LoggerMock {
@@ -83,6 +97,7 @@ func NewLoggerMock() *LoggerMock {
 	logger.InfoFunc = func(string) {}
 	logger.WarnFunc = func(string) {}
 	logger.ErrorFunc = func(string) {}
+	logger.WithFunc = func(string, interface{}) Logger { return logger }
 	return logger
 }
 
@@ -213,3 +228,39 @@ func (mock *LoggerMock) WarnCalls() []struct {
 	mock.lockWarn.RUnlock()
 	return calls
 }
+
+// With calls WithFunc.
+func (mock *LoggerMock) With(key string, value interface{}) Logger {
+	if mock.WithFunc == nil {
+		panic("LoggerMock.WithFunc: method is nil but Logger.With was just called")
+	}
+	callInfo := struct {
+		Key   string
+		Value interface{}
+	}{
+		Key:   key,
+		Value: value,
+	}
+	mock.lockWith.Lock()
+	mock.calls.With = append(mock.calls.With, callInfo)
+	mock.lockWith.Unlock()
+	return mock.WithFunc(key, value)
+}
+
+// WithCalls gets all the calls that were made to With.
+// Check the length with:
+//
+//	len(mockedLogger.WithCalls())
+func (mock *LoggerMock) WithCalls() []struct {
+	Key   string
+	Value interface{}
+} {
+	var calls []struct {
+		Key   string
+		Value interface{}
+	}
+	mock.lockWith.RLock()
+	calls = mock.calls.With
+	mock.lockWith.RUnlock()
+	return calls
+}
@@ -0,0 +1,137 @@
+package structured
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+)
+
+const (
+	JsonFormat   = "json"
+	LogfmtFormat = "logfmt"
+)
+
+type field struct {
+	key   string
+	value interface{}
+}
+
+type output struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+type Logger struct {
+	fields []field
+	format string
+	level  log.Level
+	output *output
+	watch  application.TimeProvider
+}
+
+func NewLogger(level string, format string, writer io.Writer, watch application.TimeProvider) *Logger {
+	return &Logger{nil, format, log.ParseLevel(level), &output{writer: writer}, watch}
+}
+
+func (logger *Logger) Debug(msg string) {
+	logger.write(log.DebugLevel, msg)
+}
+
+func (logger *Logger) Info(msg string) {
+	logger.write(log.InfoLevel, msg)
+}
+
+func (logger *Logger) Warn(msg string) {
+	logger.write(log.WarnLevel, msg)
+}
+
+func (logger *Logger) Error(msg string) {
+	logger.write(log.ErrorLevel, msg)
+}
+
+func (logger *Logger) Fatal(msg string) {
+	logger.write(log.FatalLevel, msg)
+}
+
+func (logger *Logger) With(key string, value interface{}) log.Logger {
+	fields := make([]field, len(logger.fields), len(logger.fields)+1)
+	copy(fields, logger.fields)
+	return &Logger{append(fields, field{key, value}), logger.format, logger.level, logger.output, logger.watch}
+}
+
+func (logger *Logger) write(level log.Level, msg string) {
+	if level < logger.level {
+		return
+	}
+	fields := []field{
+		{"time", logger.watch.Now().UTC().Format(time.RFC3339Nano)},
+		{"level", level.String()},
+		{"msg", msg},
+	}
+	fields = append(fields, logger.fields...)
+	var line []byte
+	if logger.format == LogfmtFormat {
+		line = encodeLogfmt(fields)
+	} else {
+		line = encodeJson(fields)
+	}
+	logger.output.mutex.Lock()
+	defer logger.output.mutex.Unlock()
+	_, _ = logger.output.writer.Write(line)
+}
+
+func encodeJson(fields []field) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, f := range fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.key)
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		value, err := json.Marshal(jsonValue(f.value))
+		if err != nil {
+			value, _ = json.Marshal(fmt.Sprint(f.value))
+		}
+		buffer.Write(value)
+	}
+	buffer.WriteString("}\n")
+	return buffer.Bytes()
+}
+
+func encodeLogfmt(fields []field) []byte {
+	var buffer bytes.Buffer
+	for i, f := range fields {
+		if i > 0 {
+			buffer.WriteByte(' ')
+		}
+		buffer.WriteString(f.key)
+		buffer.WriteByte('=')
+		value := fmt.Sprint(f.value)
+		if value == "" || strings.ContainsAny(value, " =\"\t\n") {
+			value = strconv.Quote(value)
+		}
+		buffer.WriteString(value)
+	}
+	buffer.WriteByte('\n')
+	return buffer.Bytes()
+}
+
+func jsonValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case error:
+		return typedValue.Error()
+	case fmt.Stringer:
+		return typedValue.String()
+	}
+	return value
+}
@@ -0,0 +1,76 @@
+package structured
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_Info_JsonFormatWithFields_JsonLineWritten(t *testing.T) {
+	// Arrange
+	var buffer bytes.Buffer
+	logger := NewLogger("info", JsonFormat, &buffer, newWatchMock())
+	contextualLogger := logger.With(log.ComponentKey, "blockchain").With(log.BlockHeightKey, 3).With(log.ErrorKey, errors.New("invalid block"))
+
+	// Act
+	contextualLogger.Info("block rejected")
+
+	// Assert
+	expectedLine := `{"time":"1970-01-01T00:00:01Z","level":"info","msg":"block rejected","component":"blockchain","block_height":3,"error":"invalid block"}` + "\n"
+	actualLine := buffer.String()
+	test.Assert(t, actualLine == expectedLine, fmt.Sprintf("Wrong line. Expected: %s - Actual: %s", expectedLine, actualLine))
+}
+
+func Test_Warn_LogfmtFormatWithFields_LogfmtLineWritten(t *testing.T) {
+	// Arrange
+	var buffer bytes.Buffer
+	logger := NewLogger("info", LogfmtFormat, &buffer, newWatchMock())
+	contextualLogger := logger.With(log.TargetKey, "0.0.0.0:0").With(log.TransactionIdKey, "")
+
+	// Act
+	contextualLogger.Warn("transaction dropped")
+
+	// Assert
+	expectedLine := `time=1970-01-01T00:00:01Z level=warn msg="transaction dropped" target=0.0.0.0:0 transaction_id=""` + "\n"
+	actualLine := buffer.String()
+	test.Assert(t, actualLine == expectedLine, fmt.Sprintf("Wrong line. Expected: %s - Actual: %s", expectedLine, actualLine))
+}
+
+func Test_Debug_InfoLevel_NothingWritten(t *testing.T) {
+	// Arrange
+	var buffer bytes.Buffer
+	logger := NewLogger("info", JsonFormat, &buffer, newWatchMock())
+
+	// Act
+	logger.Debug("ignored")
+
+	// Assert
+	test.Assert(t, buffer.Len() == 0, "Message is written whereas it should not.")
+}
+
+func Test_With_FieldAdded_ParentLoggerUnchanged(t *testing.T) {
+	// Arrange
+	var buffer bytes.Buffer
+	logger := NewLogger("info", LogfmtFormat, &buffer, newWatchMock())
+	_ = logger.With(log.ComponentKey, "pool")
+
+	// Act
+	logger.Info("started")
+
+	// Assert
+	expectedLine := "time=1970-01-01T00:00:01Z level=info msg=started\n"
+	actualLine := buffer.String()
+	test.Assert(t, actualLine == expectedLine, fmt.Sprintf("Wrong line. Expected: %s - Actual: %s", expectedLine, actualLine))
+}
+
+func newWatchMock() *application.TimeProviderMock {
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(1, 0) }
+	return watchMock
+}
@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "ruthenium"
+
+type Recorder struct {
+	blockchainForksCount        prometheus.Counter
+	blockchainReplacementsCount prometheus.Counter
+	blockchainUpdateDuration    prometheus.Histogram
+	producedBlocksCount         prometheus.Counter
+	registry                    *prometheus.Registry
+	registryChecksCount         *prometheus.CounterVec
+	rejectedBlocksCount         prometheus.Counter
+	rejectedRequestsCount       *prometheus.CounterVec
+	requestDuration             *prometheus.HistogramVec
+	requestsCount               *prometheus.CounterVec
+	taskDuration                *prometheus.HistogramVec
+	taskOverrunsCount           *prometheus.CounterVec
+	tasksCount                  *prometheus.CounterVec
+}
+
+func NewRecorder() *Recorder {
+	recorder := new(Recorder)
+	recorder.blockchainForksCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "blockchain",
+		Name:      "forks_total",
+		Help:      "The count of blockchain replacements abandoning host blocks.",
+	})
+	recorder.blockchainReplacementsCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "blockchain",
+		Name:      "replacements_total",
+		Help:      "The count of blockchain replacements by a neighbor blockchain.",
+	})
+	recorder.blockchainUpdateDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "blockchain",
+		Name:      "update_duration_seconds",
+		Help:      "The duration of the blockchain updates.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	})
+	recorder.producedBlocksCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "blocks",
+		Name:      "produced_total",
+		Help:      "The count of blocks produced by the validator.",
+	})
+	recorder.registryChecksCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "registry",
+		Name:      "checks_total",
+		Help:      "The count of proof of humanity registry checks by result.",
+	}, []string{"result"})
+	recorder.rejectedBlocksCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "blocks",
+		Name:      "rejected_total",
+		Help:      "The count of blocks failing verification.",
+	})
+	recorder.rejectedRequestsCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "host",
+		Name:      "rejected_requests_total",
+		Help:      "The count of rejected requests by reason.",
+	}, []string{"reason"})
+	recorder.requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "host",
+		Name:      "request_duration_seconds",
+		Help:      "The duration of the handled requests by topic.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+	}, []string{"topic"})
+	recorder.requestsCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "host",
+		Name:      "requests_total",
+		Help:      "The count of handled requests by topic and result.",
+	}, []string{"topic", "result"})
+	recorder.taskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "engine",
+		Name:      "task_duration_seconds",
+		Help:      "The duration of the scheduled tasks by task.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{"task"})
+	recorder.taskOverrunsCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "engine",
+		Name:      "task_overruns_total",
+		Help:      "The count of scheduled tasks lasting longer than their interval by task.",
+	}, []string{"task"})
+	recorder.tasksCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "engine",
+		Name:      "tasks_total",
+		Help:      "The count of scheduled tasks by task and result.",
+	}, []string{"task", "result"})
+	recorder.registry = prometheus.NewRegistry()
+	recorder.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		recorder.blockchainForksCount,
+		recorder.blockchainReplacementsCount,
+		recorder.blockchainUpdateDuration,
+		recorder.producedBlocksCount,
+		recorder.registryChecksCount,
+		recorder.rejectedBlocksCount,
+		recorder.rejectedRequestsCount,
+		recorder.requestDuration,
+		recorder.requestsCount,
+		recorder.taskDuration,
+		recorder.taskOverrunsCount,
+		recorder.tasksCount,
+	)
+	return recorder
+}
+
+func (recorder *Recorder) IncrementProducedBlocks() {
+	recorder.producedBlocksCount.Inc()
+}
+
+func (recorder *Recorder) IncrementRejectedBlocks() {
+	recorder.rejectedBlocksCount.Inc()
+}
+
+func (recorder *Recorder) IncrementRejectedRequests(reason string) {
+	recorder.rejectedRequestsCount.WithLabelValues(reason).Inc()
+}
+
+func (recorder *Recorder) ObserveBlockchainUpdate(duration time.Duration, isReplaced bool, isForked bool) {
+	recorder.blockchainUpdateDuration.Observe(duration.Seconds())
+	if isReplaced {
+		recorder.blockchainReplacementsCount.Inc()
+	}
+	if isForked {
+		recorder.blockchainForksCount.Inc()
+	}
+}
+
+func (recorder *Recorder) ObserveRegistrySynchronization(keptAddressesCount int, removedAddressesCount int, failedChecksCount int) {
+	recorder.registryChecksCount.WithLabelValues("kept").Add(float64(keptAddressesCount))
+	recorder.registryChecksCount.WithLabelValues("removed").Add(float64(removedAddressesCount))
+	recorder.registryChecksCount.WithLabelValues("failed").Add(float64(failedChecksCount))
+}
+
+func (recorder *Recorder) ObserveRequest(topic string, duration time.Duration, isSuccessful bool) {
+	result := "success"
+	if !isSuccessful {
+		result = "failure"
+	}
+	recorder.requestsCount.WithLabelValues(topic, result).Inc()
+	recorder.requestDuration.WithLabelValues(topic).Observe(duration.Seconds())
+}
+
+func (recorder *Recorder) ObserveTask(task string, duration time.Duration, isSuccessful bool, isOverrun bool) {
+	result := "success"
+	if !isSuccessful {
+		result = "failure"
+	}
+	recorder.tasksCount.WithLabelValues(task, result).Inc()
+	recorder.taskDuration.WithLabelValues(task).Observe(duration.Seconds())
+	if isOverrun {
+		recorder.taskOverrunsCount.WithLabelValues(task).Inc()
+	}
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+)
+
+const readHeaderTimeout = 5 * time.Second
+
+type Server struct {
+	httpServer *http.Server
+}
+
+func NewServer(port string, recorder *Recorder, blocksManager application.BlocksManager, sendersManager application.SendersManager, transactionsManager application.TransactionsManager, watch application.TimeProvider) *Server {
+	recorder.registry.MustRegister(newStateCollector(blocksManager, sendersManager, transactionsManager, watch))
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(recorder.registry, promhttp.HandlerOpts{}))
+	server := new(Server)
+	server.httpServer = &http.Server{
+		Addr:              net.JoinHostPort("0.0.0.0", port),
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	return server
+}
+
+func (server *Server) Serve() error {
+	return server.httpServer.ListenAndServe()
+}
+
+func (server *Server) Shutdown(ctx context.Context) error {
+	return server.httpServer.Shutdown(ctx)
+}
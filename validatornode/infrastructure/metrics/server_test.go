@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_Metrics_RecordedEvents_MetricsExposed(t *testing.T) {
+	// Arrange
+	recorder := NewRecorder()
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.TipFunc = func() (*ledger.BlockHeader, error) {
+		return ledger.NewBlockHeader(ledger.NewBlock([32]byte{}, nil, nil, 0, nil), 3)
+	}
+	blocksManagerMock.LastBlockTimestampFunc = func() int64 { return 0 }
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.NeighborsStatusFunc = func() []*ledger.NeighborStatus {
+		return []*ledger.NeighborStatus{ledger.NewNeighborStatus(0, true, 10, 2, "0.0.0.0:0"), ledger.NewNeighborStatus(0, false, 0, 0, "0.0.0.0:1")}
+	}
+	transactionsManagerMock := new(application.TransactionsManagerMock)
+	transactionsManagerMock.TransactionsFunc = func() []*ledger.Transaction { return []*ledger.Transaction{{}} }
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(5, 0) }
+	server := NewServer("0", recorder, blocksManagerMock, sendersManagerMock, transactionsManagerMock, watchMock)
+	recorder.IncrementProducedBlocks()
+	recorder.ObserveBlockchainUpdate(time.Millisecond, true, true)
+	recorder.ObserveRegistrySynchronization(1, 2, 0)
+	recorder.ObserveRequest("tip", time.Millisecond, false)
+
+	// Act
+	body := scrape(server)
+
+	// Assert
+	expectedLines := []string{
+		"ruthenium_blockchain_forks_total 1",
+		"ruthenium_blockchain_height 3",
+		"ruthenium_blockchain_replacements_total 1",
+		"ruthenium_blockchain_tip_age_seconds 5",
+		"ruthenium_blockchain_update_duration_seconds_count 1",
+		"ruthenium_blocks_produced_total 1",
+		"ruthenium_blocks_rejected_total 0",
+		`ruthenium_host_requests_total{result="failure",topic="tip"} 1`,
+		"ruthenium_network_known_neighbors 2",
+		`ruthenium_network_neighbor_misbehavior_score{target="0.0.0.0:0"} 10`,
+		`ruthenium_network_neighbor_score{target="0.0.0.0:0"} 2`,
+		"ruthenium_network_neighbors 1",
+		"ruthenium_pool_transactions 1",
+		`ruthenium_registry_checks_total{result="removed"} 2`,
+	}
+	for _, expectedLine := range expectedLines {
+		test.Assert(t, strings.Contains(body, expectedLine+"\n"), fmt.Sprintf("Metric is not exposed whereas it should be: %s", expectedLine))
+	}
+	test.Assert(t, !strings.Contains(body, `target="0.0.0.0:1"`), "Non-outbound neighbor is labeled whereas it should not.")
+}
+
+func Test_Metrics_EmptyBlockchain_HeightNotExposed(t *testing.T) {
+	// Arrange
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.TipFunc = func() (*ledger.BlockHeader, error) { return nil, errors.New("") }
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.NeighborsStatusFunc = func() []*ledger.NeighborStatus { return nil }
+	transactionsManagerMock := new(application.TransactionsManagerMock)
+	transactionsManagerMock.TransactionsFunc = func() []*ledger.Transaction { return nil }
+	server := NewServer("0", NewRecorder(), blocksManagerMock, sendersManagerMock, transactionsManagerMock, new(application.TimeProviderMock))
+
+	// Act
+	body := scrape(server)
+
+	// Assert
+	test.Assert(t, !strings.Contains(body, "ruthenium_blockchain_height "), "Height is exposed whereas it should not.")
+	test.Assert(t, strings.Contains(body, "ruthenium_pool_transactions 0\n"), "Pool size is not exposed whereas it should be.")
+}
+
+func scrape(server *Server) string {
+	responseRecorder := httptest.NewRecorder()
+	server.httpServer.Handler.ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(responseRecorder.Body)
+	return string(body)
+}
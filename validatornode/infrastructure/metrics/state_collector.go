@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+)
+
+type stateCollector struct {
+	blocksManager             application.BlocksManager
+	sendersManager            application.SendersManager
+	transactionsManager       application.TransactionsManager
+	watch                     application.TimeProvider
+	bannedNeighborsCountDesc  *prometheus.Desc
+	blockchainHeightDesc      *prometheus.Desc
+	knownNeighborsCountDesc   *prometheus.Desc
+	neighborMisbehaviorDesc   *prometheus.Desc
+	neighborScoreDesc         *prometheus.Desc
+	neighborsCountDesc        *prometheus.Desc
+	poolTransactionsCountDesc *prometheus.Desc
+	tipAgeDesc                *prometheus.Desc
+}
+
+func newStateCollector(blocksManager application.BlocksManager, sendersManager application.SendersManager, transactionsManager application.TransactionsManager, watch application.TimeProvider) *stateCollector {
+	collector := new(stateCollector)
+	collector.blocksManager = blocksManager
+	collector.sendersManager = sendersManager
+	collector.transactionsManager = transactionsManager
+	collector.watch = watch
+	collector.bannedNeighborsCountDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "network", "banned_neighbors"), "The count of banned neighbors.", nil, nil)
+	collector.blockchainHeightDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "blockchain", "height"), "The height of the blockchain tip.", nil, nil)
+	collector.knownNeighborsCountDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "network", "known_neighbors"), "The count of known neighbors.", nil, nil)
+	collector.neighborMisbehaviorDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "network", "neighbor_misbehavior_score"), "The misbehavior score of each outbound neighbor.", []string{"target"}, nil)
+	collector.neighborScoreDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "network", "neighbor_score"), "The score of each outbound neighbor.", []string{"target"}, nil)
+	collector.neighborsCountDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "network", "neighbors"), "The count of outbound neighbors.", nil, nil)
+	collector.poolTransactionsCountDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "pool", "transactions"), "The count of pending transactions.", nil, nil)
+	collector.tipAgeDesc = prometheus.NewDesc(prometheus.BuildFQName(namespace, "blockchain", "tip_age_seconds"), "The duration since the blockchain tip timestamp.", nil, nil)
+	return collector
+}
+
+func (collector *stateCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- collector.bannedNeighborsCountDesc
+	descs <- collector.blockchainHeightDesc
+	descs <- collector.knownNeighborsCountDesc
+	descs <- collector.neighborMisbehaviorDesc
+	descs <- collector.neighborScoreDesc
+	descs <- collector.neighborsCountDesc
+	descs <- collector.poolTransactionsCountDesc
+	descs <- collector.tipAgeDesc
+}
+
+func (collector *stateCollector) Collect(metrics chan<- prometheus.Metric) {
+	if tip, err := collector.blocksManager.Tip(); err == nil {
+		metrics <- prometheus.MustNewConstMetric(collector.blockchainHeightDesc, prometheus.GaugeValue, float64(tip.Height()))
+		tipAge := collector.watch.Now().Sub(time.Unix(0, collector.blocksManager.LastBlockTimestamp()))
+		metrics <- prometheus.MustNewConstMetric(collector.tipAgeDesc, prometheus.GaugeValue, tipAge.Seconds())
+	}
+	var bannedNeighborsCount, neighborsCount int
+	neighborsStatus := collector.sendersManager.NeighborsStatus()
+	for _, status := range neighborsStatus {
+		if status.IsBanned() {
+			bannedNeighborsCount++
+		}
+		// The known neighbors are unbounded, only the outbound ones are labeled by target to bound the series count
+		if status.IsOutbound() {
+			neighborsCount++
+			metrics <- prometheus.MustNewConstMetric(collector.neighborMisbehaviorDesc, prometheus.GaugeValue, float64(status.MisbehaviorScore()), status.Target())
+			metrics <- prometheus.MustNewConstMetric(collector.neighborScoreDesc, prometheus.GaugeValue, float64(status.Score()), status.Target())
+		}
+	}
+	metrics <- prometheus.MustNewConstMetric(collector.bannedNeighborsCountDesc, prometheus.GaugeValue, float64(bannedNeighborsCount))
+	metrics <- prometheus.MustNewConstMetric(collector.knownNeighborsCountDesc, prometheus.GaugeValue, float64(len(neighborsStatus)))
+	metrics <- prometheus.MustNewConstMetric(collector.neighborsCountDesc, prometheus.GaugeValue, float64(neighborsCount))
+	metrics <- prometheus.MustNewConstMetric(collector.poolTransactionsCountDesc, prometheus.GaugeValue, float64(len(collector.transactionsManager.Transactions())))
+}
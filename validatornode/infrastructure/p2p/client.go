@@ -0,0 +1,131 @@
+package p2p
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	gp2p "github.com/leprosus/golang-p2p"
+)
+
+type Client struct {
+	address           string
+	cipherKey         gp2p.CipherKey
+	cipherKeyMutex    sync.Mutex
+	connectionTimeout time.Duration
+	rsa               *gp2p.RSA
+	tlsConfig         *tls.Config
+}
+
+func NewClient(ip string, port string, connectionTimeout time.Duration, tlsConfig *tls.Config) (*Client, error) {
+	rsa, err := gp2p.NewRSA()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate RSA key: %w", err)
+	}
+	client := new(Client)
+	client.address = net.JoinHostPort(ip, port)
+	client.connectionTimeout = connectionTimeout
+	client.rsa = rsa
+	client.tlsConfig = tlsConfig
+	return client, nil
+}
+
+func (client *Client) Send(topic string, request gp2p.Data) (gp2p.Data, error) {
+	connection, err := client.dial()
+	if err != nil {
+		return gp2p.Data{}, fmt.Errorf("failed to connect to %s: %w", client.address, err)
+	}
+	defer func() { _ = connection.Close() }()
+	settings := gp2p.NewClientSettings()
+	settings.SetConnTimeout(client.connectionTimeout)
+	conn, err := gp2p.NewConn(connection, settings.Limiter)
+	if err != nil {
+		return gp2p.Data{}, fmt.Errorf("failed to set connection deadline: %w", err)
+	}
+	client.cipherKeyMutex.Lock()
+	cipherKey := client.cipherKey
+	client.cipherKeyMutex.Unlock()
+	if cipherKey == nil {
+		if cipherKey, err = client.shakeHands(conn); err != nil {
+			return gp2p.Data{}, err
+		}
+		client.setCipherKey(cipherKey)
+	}
+	message, err := client.exchange(conn, cipherKey, gp2p.Message{Topic: topic, Content: request.GetBytes()})
+	if err != nil {
+		// The host may have restarted with another cipher key
+		client.setCipherKey(nil)
+		return gp2p.Data{}, err
+	}
+	return gp2p.Data{Bytes: message.Content}, nil
+}
+
+func (client *Client) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: client.connectionTimeout}
+	if client.tlsConfig == nil {
+		return dialer.Dial("tcp", client.address)
+	}
+	return tls.DialWithDialer(dialer, "tcp", client.address, client.tlsConfig)
+}
+
+func (client *Client) exchange(conn gp2p.Conn, cipherKey gp2p.CipherKey, message gp2p.Message) (gp2p.Message, error) {
+	cryptMessage, err := message.Encode(cipherKey)
+	if err != nil {
+		return gp2p.Message{}, fmt.Errorf("failed to encrypt message: %w", err)
+	}
+	p := gp2p.Package{Type: gp2p.Exchange}
+	if err = p.SetGob(cryptMessage); err != nil {
+		return gp2p.Message{}, fmt.Errorf("failed to encode package: %w", err)
+	}
+	if err = conn.WritePackage(p); err != nil {
+		return gp2p.Message{}, fmt.Errorf("failed to write request: %w", err)
+	}
+	if err = conn.ReadPackage(&p); err != nil {
+		return gp2p.Message{}, fmt.Errorf("failed to read response: %w", err)
+	}
+	if p.Type == gp2p.Error {
+		return gp2p.Message{}, errors.New("request failed")
+	}
+	if err = p.GetGob(&cryptMessage); err != nil {
+		return gp2p.Message{}, fmt.Errorf("failed to decode package: %w", err)
+	}
+	response, err := cryptMessage.Decode(cipherKey)
+	if err != nil {
+		return gp2p.Message{}, fmt.Errorf("failed to decrypt message: %w", err)
+	}
+	if response.Error != nil {
+		return gp2p.Message{}, response.Error
+	}
+	return response, nil
+}
+
+func (client *Client) setCipherKey(cipherKey gp2p.CipherKey) {
+	client.cipherKeyMutex.Lock()
+	defer client.cipherKeyMutex.Unlock()
+	client.cipherKey = cipherKey
+}
+
+func (client *Client) shakeHands(conn gp2p.Conn) (gp2p.CipherKey, error) {
+	p := gp2p.Package{Type: gp2p.Handshake}
+	if err := p.SetGob(client.rsa.PublicKey()); err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+	if err := conn.WritePackage(p); err != nil {
+		return nil, fmt.Errorf("failed to write handshake: %w", err)
+	}
+	if err := conn.ReadPackage(&p); err != nil {
+		return nil, fmt.Errorf("failed to read handshake: %w", err)
+	}
+	var cryptCipherKey gp2p.CryptCipherKey
+	if err := p.GetGob(&cryptCipherKey); err != nil {
+		return nil, fmt.Errorf("failed to decode cipher key: %w", err)
+	}
+	cipherKey, err := client.rsa.PrivateKey().Decode(cryptCipherKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt cipher key: %w", err)
+	}
+	return cipherKey, nil
+}
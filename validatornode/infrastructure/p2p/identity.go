@@ -0,0 +1,70 @@
+package p2p
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const identityKeyPemType = "EC PRIVATE KEY"
+
+// LoadIdentity returns a self-signed certificate for the node identity key persisted at the given path, the key is generated if it does not exist yet
+func LoadIdentity(path string) (tls.Certificate, error) {
+	privateKey, err := loadIdentityKey(path)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(10, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to create identity certificate: %w", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{certificate}, PrivateKey: privateKey}, nil
+}
+
+func loadIdentityKey(path string) (*ecdsa.PrivateKey, error) {
+	bytes, err := os.ReadFile(path)
+	if err == nil {
+		block, _ := pem.Decode(bytes)
+		if block == nil || block.Type != identityKeyPemType {
+			return nil, fmt.Errorf("failed to decode identity key file %s", path)
+		}
+		privateKey, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse identity key: %w", err)
+		}
+		return privateKey, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read identity key file: %w", err)
+	}
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate identity key: %w", err)
+	}
+	der, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal identity key: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create identity key directory: %w", err)
+	}
+	if err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: identityKeyPemType, Bytes: der}), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write identity key file: %w", err)
+	}
+	return privateKey, nil
+}
@@ -0,0 +1,26 @@
+package p2p
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_LoadIdentity_KeyAlreadyGenerated_SameKeyLoaded(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "identity-key")
+	generatedIdentity, _ := LoadIdentity(path)
+
+	// Act
+	loadedIdentity, err := LoadIdentity(path)
+
+	// Assert
+	test.Assert(t, err == nil, "Failed to load identity.")
+	generatedCertificate, _ := x509.ParseCertificate(generatedIdentity.Certificate[0])
+	loadedCertificate, _ := x509.ParseCertificate(loadedIdentity.Certificate[0])
+	isSameKey := sha256.Sum256(generatedCertificate.RawSubjectPublicKeyInfo) == sha256.Sum256(loadedCertificate.RawSubjectPublicKeyInfo)
+	test.Assert(t, isSameKey, "Loaded identity key differs from the generated one.")
+}
@@ -1,42 +1,54 @@
 package p2p
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"time"
 
 	gp2p "github.com/leprosus/golang-p2p"
 
 	"github.com/my-cloud/ruthenium/validatornode/application/network"
-	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
 )
 
 const (
-	BlocksEndpoint              = "blocks"
-	FirstBlockTimestampEndpoint = "first-block-timestamp"
-	SettingsEndpoint            = "settings"
-	TargetsEndpoint             = "targets"
-	TransactionEndpoint         = "transaction"
-	TransactionsEndpoint        = "transactions"
-	UtxosEndpoint               = "utxos"
+	AddressHistoryEndpoint           = "address-history"
+	BlockEndpoint                    = "block"
+	BlockAnnouncementEndpoint        = "block-announcement"
+	BlockByHashEndpoint              = "block-by-hash"
+	BlocksEndpoint                   = "blocks"
+	BlocksRangeEndpoint              = "blocks-range"
+	FirstBlockTimestampEndpoint      = "first-block-timestamp"
+	HandshakeEndpoint                = "handshake"
+	HeadersEndpoint                  = "headers"
+	NeighborsEndpoint                = "neighbors"
+	PendingTransactionsEndpoint      = "pending-transactions"
+	SettingsEndpoint                 = "settings"
+	TargetsEndpoint                  = "targets"
+	TipEndpoint                      = "tip"
+	TransactionEndpoint              = "transaction"
+	TransactionByIdEndpoint          = "transaction-by-id"
+	TransactionProofEndpoint         = "transaction-proof"
+	TransactionsEndpoint             = "transactions"
+	TransactionsAnnouncementEndpoint = "transactions-announcement"
+	UtxosEndpoint                    = "utxos"
 )
 
 type Neighbor struct {
-	*gp2p.Client
+	*Client
 	target *network.Target
 }
 
-func NewNeighbor(ip string, port string, connectionTimeout time.Duration, logger log.Logger) (*Neighbor, error) {
-	tcp := gp2p.NewTCP(ip, port)
-	client, err := gp2p.NewClient(tcp)
+func NewNeighbor(ip string, port string, connectionTimeout time.Duration, trustStore *TrustStore) (*Neighbor, error) {
+	target := network.NewTarget(ip, port)
+	var tlsConfig *tls.Config
+	if trustStore != nil {
+		tlsConfig = trustStore.TlsConfig(target.Value())
+	}
+	client, err := NewClient(ip, port, connectionTimeout, tlsConfig)
 	if err != nil {
 		return nil, err
 	}
-	settings := gp2p.NewClientSettings()
-	settings.SetRetry(1, time.Nanosecond)
-	settings.SetConnTimeout(connectionTimeout)
-	client.SetSettings(settings)
-	client.SetLogger(logger)
-	target := network.NewTarget(ip, port)
 	return &Neighbor{client, target}, err
 }
 
@@ -44,10 +56,26 @@ func (neighbor *Neighbor) Target() string {
 	return neighbor.target.Value()
 }
 
+func (neighbor *Neighbor) GetAddressHistory(address string, offset uint64, limit uint64) ([]byte, error) {
+	return neighbor.sendRequest(AddressHistoryEndpoint, ledger.NewAddressHistoryRequest(address, offset, limit))
+}
+
+func (neighbor *Neighbor) GetBlock(blockHeight uint64) ([]byte, error) {
+	return neighbor.sendRequest(BlockEndpoint, blockHeight)
+}
+
+func (neighbor *Neighbor) GetBlockByHash(hash [32]byte) ([]byte, error) {
+	return neighbor.sendRequest(BlockByHashEndpoint, hash)
+}
+
 func (neighbor *Neighbor) GetBlocks(startingBlockHeight uint64) ([]byte, error) {
 	return neighbor.sendRequest(BlocksEndpoint, startingBlockHeight)
 }
 
+func (neighbor *Neighbor) GetBlocksRange(startingBlockHeight uint64, endingBlockHeight uint64) ([]byte, error) {
+	return neighbor.sendRequest(BlocksRangeEndpoint, ledger.NewBlocksRangeRequest(startingBlockHeight, endingBlockHeight))
+}
+
 func (neighbor *Neighbor) GetFirstBlockTimestamp() (int64, error) {
 	res, err := neighbor.sendRequestBytes(FirstBlockTimestampEndpoint, []byte{})
 	var timestamp int64
@@ -61,20 +89,57 @@ func (neighbor *Neighbor) GetFirstBlockTimestamp() (int64, error) {
 	return timestamp, err
 }
 
+func (neighbor *Neighbor) Handshake(handshake []byte) ([]byte, error) {
+	return neighbor.sendRequestBytes(HandshakeEndpoint, handshake)
+}
+
+func (neighbor *Neighbor) GetHeaders(startingBlockHeight uint64) ([]byte, error) {
+	return neighbor.sendRequest(HeadersEndpoint, startingBlockHeight)
+}
+
+func (neighbor *Neighbor) GetNeighbors() ([]byte, error) {
+	return neighbor.sendRequestBytes(NeighborsEndpoint, []byte{})
+}
+
+func (neighbor *Neighbor) GetPendingTransactions(transactionIds []string) ([]byte, error) {
+	return neighbor.sendRequest(PendingTransactionsEndpoint, transactionIds)
+}
+
 func (neighbor *Neighbor) GetSettings() ([]byte, error) {
 	return neighbor.sendRequestBytes(SettingsEndpoint, []byte{})
 }
 
+func (neighbor *Neighbor) GetTip() ([]byte, error) {
+	return neighbor.sendRequestBytes(TipEndpoint, []byte{})
+}
+
 func (neighbor *Neighbor) SendTargets(targets []string) error {
 	_, err := neighbor.sendRequest(TargetsEndpoint, targets)
 	return err
 }
 
-func (neighbor *Neighbor) AddTransaction(transaction []byte) error {
-	_, err := neighbor.sendRequestBytes(TransactionEndpoint, transaction)
+func (neighbor *Neighbor) AnnounceBlock(announcement []byte) error {
+	_, err := neighbor.sendRequestBytes(BlockAnnouncementEndpoint, announcement)
 	return err
 }
 
+func (neighbor *Neighbor) AnnounceTransactions(announcement []byte) error {
+	_, err := neighbor.sendRequestBytes(TransactionsAnnouncementEndpoint, announcement)
+	return err
+}
+
+func (neighbor *Neighbor) AddTransaction(transaction []byte) ([]byte, error) {
+	return neighbor.sendRequestBytes(TransactionEndpoint, transaction)
+}
+
+func (neighbor *Neighbor) GetTransactionById(transactionId string) ([]byte, error) {
+	return neighbor.sendRequest(TransactionByIdEndpoint, transactionId)
+}
+
+func (neighbor *Neighbor) GetTransactionProof(transactionId string) ([]byte, error) {
+	return neighbor.sendRequest(TransactionProofEndpoint, transactionId)
+}
+
 func (neighbor *Neighbor) GetTransactions() ([]byte, error) {
 	return neighbor.sendRequestBytes(TransactionsEndpoint, []byte{})
 }
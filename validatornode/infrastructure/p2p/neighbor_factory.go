@@ -4,18 +4,16 @@ import (
 	"fmt"
 	"github.com/my-cloud/ruthenium/validatornode/application"
 	"time"
-
-	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 )
 
 type NeighborFactory struct {
 	ipFinder          IpFinder
 	connectionTimeout time.Duration
-	logger            log.Logger
+	trustStore        *TrustStore
 }
 
-func NewNeighborFactory(ipFinder IpFinder, connectionTimeout time.Duration, logger log.Logger) *NeighborFactory {
-	return &NeighborFactory{ipFinder, connectionTimeout, logger}
+func NewNeighborFactory(ipFinder IpFinder, connectionTimeout time.Duration, trustStore *TrustStore) *NeighborFactory {
+	return &NeighborFactory{ipFinder, connectionTimeout, trustStore}
 }
 
 func (factory *NeighborFactory) CreateSender(ip string, port string) (application.Sender, error) {
@@ -23,7 +21,7 @@ func (factory *NeighborFactory) CreateSender(ip string, port string) (applicatio
 	if err != nil {
 		return nil, fmt.Errorf("failed to look up IP on addresse %s: %w", ip, err)
 	}
-	neighbor, err := NewNeighbor(lookedUpIp, port, factory.connectionTimeout, factory.logger)
+	neighbor, err := NewNeighbor(lookedUpIp, port, factory.connectionTimeout, factory.trustStore)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create neighbor for address %s: %w", ip, err)
 	}
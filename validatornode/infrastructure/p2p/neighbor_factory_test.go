@@ -4,7 +4,6 @@ import (
 	"errors"
 	"testing"
 
-	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
 )
 
@@ -12,8 +11,7 @@ func Test_CreateSender_IpFinderError_ReturnsNil(t *testing.T) {
 	// Arrange
 	ipFinder := new(IpFinderMock)
 	ipFinder.LookupIPFunc = func(string) (string, error) { return "", errors.New("") }
-	logger := log.NewLoggerMock()
-	neighborFactory := NewNeighborFactory(ipFinder, 0, logger)
+	neighborFactory := NewNeighborFactory(ipFinder, 0, nil)
 
 	// Act
 	client, _ := neighborFactory.CreateSender("", "0")
@@ -26,8 +24,7 @@ func Test_CreateSender_ValidIp_ReturnsClient(t *testing.T) {
 	// Arrange
 	ipFinder := new(IpFinderMock)
 	ipFinder.LookupIPFunc = func(string) (string, error) { return "", nil }
-	logger := log.NewLoggerMock()
-	neighborFactory := NewNeighborFactory(ipFinder, 0, logger)
+	neighborFactory := NewNeighborFactory(ipFinder, 0, nil)
 
 	// Act
 	client, _ := neighborFactory.CreateSender("", "0")
@@ -0,0 +1,15 @@
+package p2p
+
+import "context"
+
+type remoteIpKey struct{}
+
+// RemoteIp returns the IP address of the connection the request handled with the given context was received from
+func RemoteIp(ctx context.Context) string {
+	remoteIp, _ := ctx.Value(remoteIpKey{}).(string)
+	return remoteIp
+}
+
+func withRemoteIp(ctx context.Context, remoteIp string) context.Context {
+	return context.WithValue(ctx, remoteIpKey{}, remoteIp)
+}
@@ -0,0 +1,20 @@
+package p2p
+
+import "encoding/gob"
+
+// requestError is sent back to the client in the response message, it is registered since the message error is gob encoded
+type requestError struct {
+	Message string
+}
+
+func init() {
+	gob.Register(&requestError{})
+}
+
+func newRequestError(message string) *requestError {
+	return &requestError{message}
+}
+
+func (err *requestError) Error() string {
+	return err.Message
+}
@@ -0,0 +1,367 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	gp2p "github.com/leprosus/golang-p2p"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/application/network"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+)
+
+const (
+	InboundsLimitRejection    = "inbounds limit"
+	RequestRateRejection      = "request rate"
+	RequestSizeRejection      = "request size"
+	UnsupportedTopicRejection = "unsupported topic"
+)
+
+// tlsHandshakeRecordType is the first byte sent by a TLS client, it distinguishes encrypted connections from plain ones
+const tlsHandshakeRecordType = 0x16
+
+var errRequestTooLarge = errors.New("request too large")
+
+type Server struct {
+	cipherKey               gp2p.CipherKey
+	connectionTimeout       time.Duration
+	connectionsWaitGroup    sync.WaitGroup
+	handlersByTopic         map[string]gp2p.Handler
+	handlersMutex           sync.RWMutex
+	inboundsCount           int
+	inboundsMutex           sync.Mutex
+	isShutDown              bool
+	listener                net.Listener
+	maxInboundsCount        int
+	maxRequestSize          int
+	metricsRecorder         application.MetricsRecorder
+	port                    string
+	requestsLimiter         *network.RateLimiter
+	requestsLimitersByTopic map[string]*network.RateLimiter
+	shutdownMutex           sync.Mutex
+	tlsConfig               *tls.Config
+	logger                  log.Logger
+}
+
+func NewServer(port string, connectionTimeout time.Duration, identity tls.Certificate, maxInboundsCount int, maxRequestsPerSecond int, maxRequestsPerSecondByTopic map[string]int, maxRequestSize int, metricsRecorder application.MetricsRecorder, watch application.TimeProvider, logger log.Logger) (*Server, error) {
+	cipherKey, err := gp2p.NewCipherKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate cipher key: %w", err)
+	}
+	server := new(Server)
+	server.cipherKey = cipherKey
+	server.connectionTimeout = connectionTimeout
+	server.handlersByTopic = map[string]gp2p.Handler{}
+	server.maxInboundsCount = maxInboundsCount
+	server.maxRequestSize = maxRequestSize
+	server.metricsRecorder = metricsRecorder
+	server.port = port
+	server.requestsLimiter = network.NewRateLimiter(maxRequestsPerSecond, watch)
+	server.requestsLimitersByTopic = map[string]*network.RateLimiter{}
+	for topic, topicMaxRequestsPerSecond := range maxRequestsPerSecondByTopic {
+		server.requestsLimitersByTopic[topic] = network.NewRateLimiter(topicMaxRequestsPerSecond, watch)
+	}
+	server.tlsConfig = &tls.Config{Certificates: []tls.Certificate{identity}, MinVersion: tls.VersionTLS13}
+	server.logger = logger
+	return server, nil
+}
+
+func (server *Server) Serve() error {
+	listener, err := net.Listen("tcp", net.JoinHostPort("0.0.0.0", server.port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %s: %w", server.port, err)
+	}
+	return server.serve(listener)
+}
+
+func (server *Server) Shutdown(ctx context.Context) error {
+	server.shutdownMutex.Lock()
+	server.isShutDown = true
+	listener := server.listener
+	server.shutdownMutex.Unlock()
+	if listener != nil {
+		if err := listener.Close(); err != nil {
+			return fmt.Errorf("failed to close listener: %w", err)
+		}
+	}
+	drained := make(chan struct{})
+	go func() {
+		server.connectionsWaitGroup.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain in-flight requests: %w", ctx.Err())
+	}
+}
+
+func (server *Server) SetHandle(topic string, handler gp2p.Handler) {
+	server.handlersMutex.Lock()
+	defer server.handlersMutex.Unlock()
+	server.handlersByTopic[topic] = handler
+}
+
+func (server *Server) serve(listener net.Listener) error {
+	server.shutdownMutex.Lock()
+	if server.isShutDown {
+		server.shutdownMutex.Unlock()
+		return listener.Close()
+	}
+	server.listener = listener
+	server.shutdownMutex.Unlock()
+	defer func() {
+		if server.isShuttingDown() {
+			return
+		}
+		if err := listener.Close(); err != nil {
+			server.logger.Error(fmt.Errorf("failed to close listener: %w", err).Error())
+		}
+	}()
+	for {
+		connection, err := listener.Accept()
+		if err != nil {
+			if server.isShuttingDown() {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		if !server.acquireInbound() {
+			server.reject(connection, "", InboundsLimitRejection)
+			server.close(connection)
+			continue
+		}
+		server.shutdownMutex.Lock()
+		if server.isShutDown {
+			server.shutdownMutex.Unlock()
+			server.releaseInbound()
+			server.close(connection)
+			return nil
+		}
+		// The connection is tracked before the shutdown can wait for the in-flight requests
+		server.connectionsWaitGroup.Add(1)
+		server.shutdownMutex.Unlock()
+		go func() {
+			defer server.connectionsWaitGroup.Done()
+			defer server.releaseInbound()
+			defer server.close(connection)
+			server.handle(connection)
+		}()
+	}
+}
+
+func (server *Server) acquireInbound() bool {
+	server.inboundsMutex.Lock()
+	defer server.inboundsMutex.Unlock()
+	if server.maxInboundsCount > 0 && server.inboundsCount >= server.maxInboundsCount {
+		return false
+	}
+	server.inboundsCount++
+	return true
+}
+
+func (server *Server) close(connection net.Conn) {
+	if err := connection.Close(); err != nil {
+		server.logger.Debug(fmt.Errorf("failed to close connection: %w", err).Error())
+	}
+}
+
+func (server *Server) handle(connection net.Conn) {
+	settings := gp2p.NewServerSettings()
+	settings.SetConnTimeout(server.connectionTimeout)
+	if err := connection.SetDeadline(time.Now().Add(server.connectionTimeout)); err != nil {
+		server.logger.Debug(fmt.Errorf("failed to set connection deadline: %w", err).Error())
+		return
+	}
+	// Plain connections are still accepted for backward compatibility
+	peekedConnection := &peekedConnection{connection, bufio.NewReader(connection)}
+	firstBytes, err := peekedConnection.reader.Peek(1)
+	if err != nil {
+		return
+	}
+	var transportConnection net.Conn = peekedConnection
+	if firstBytes[0] == tlsHandshakeRecordType {
+		transportConnection = tls.Server(peekedConnection, server.tlsConfig)
+	}
+	if server.maxRequestSize > 0 {
+		transportConnection = &sizeLimitedConnection{transportConnection, server.maxRequestSize}
+	}
+	conn, err := gp2p.NewConn(transportConnection, settings.Limiter)
+	if err != nil {
+		server.logger.Debug(fmt.Errorf("failed to set connection deadline: %w", err).Error())
+		return
+	}
+	for {
+		var p gp2p.Package
+		if err = conn.ReadPackage(&p); err != nil {
+			if errors.Is(err, errRequestTooLarge) {
+				server.reject(connection, "", RequestSizeRejection)
+				server.writeError(connection, conn)
+			}
+			return
+		}
+		switch p.Type {
+		case gp2p.Handshake:
+			if err = server.shakeHands(conn, p); err != nil {
+				server.logger.With(log.TargetKey, connection.RemoteAddr().String()).With(log.ErrorKey, err).Debug(fmt.Errorf("failed to shake hands with %s: %w", connection.RemoteAddr(), err).Error())
+				return
+			}
+		case gp2p.Exchange:
+			if err = server.exchange(connection, conn, p); err != nil {
+				server.logger.With(log.TargetKey, connection.RemoteAddr().String()).With(log.ErrorKey, err).Debug(fmt.Errorf("failed to exchange with %s: %w", connection.RemoteAddr(), err).Error())
+			}
+			return
+		default:
+			return
+		}
+	}
+}
+
+func (server *Server) exchange(connection net.Conn, conn gp2p.Conn, p gp2p.Package) error {
+	var cryptMessage gp2p.CryptMessage
+	if err := p.GetGob(&cryptMessage); err != nil {
+		return fmt.Errorf("failed to decode package: %w", err)
+	}
+	message, err := cryptMessage.Decode(server.cipherKey)
+	if err != nil {
+		server.writeError(connection, conn)
+		return fmt.Errorf("failed to decrypt message: %w", err)
+	}
+	server.handlersMutex.RLock()
+	handler, isSupported := server.handlersByTopic[message.Topic]
+	server.handlersMutex.RUnlock()
+	if !isSupported {
+		server.reject(connection, message.Topic, UnsupportedTopicRejection)
+		return server.respond(conn, p, message, gp2p.Data{}, newRequestError(UnsupportedTopicRejection))
+	}
+	requestsLimiter, isLimitedByTopic := server.requestsLimitersByTopic[message.Topic]
+	if !isLimitedByTopic {
+		requestsLimiter = server.requestsLimiter
+	}
+	if requestsLimiter.Allow(ip(connection)+"/"+message.Topic, 1) == 0 {
+		server.reject(connection, message.Topic, RequestRateRejection)
+		return server.respond(conn, p, message, gp2p.Data{}, newRequestError(RequestRateRejection))
+	}
+	ctx, cancel := context.WithTimeout(withRemoteIp(context.Background(), ip(connection)), server.connectionTimeout)
+	defer cancel()
+	var request gp2p.Data
+	request.SetBytes(message.Content)
+	response, err := server.call(ctx, handler, request)
+	if err != nil {
+		if responseError := server.respond(conn, p, message, gp2p.Data{}, newRequestError(err.Error())); responseError != nil {
+			server.logger.Debug(fmt.Errorf("failed to write error response: %w", responseError).Error())
+		}
+		return fmt.Errorf("failed to handle %s request: %w", message.Topic, err)
+	}
+	return server.respond(conn, p, message, response, nil)
+}
+
+func (server *Server) call(ctx context.Context, handler gp2p.Handler, request gp2p.Data) (response gp2p.Data, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("handler panicked: %v", recovered)
+		}
+	}()
+	return handler(ctx, request)
+}
+
+func (server *Server) respond(conn gp2p.Conn, p gp2p.Package, message gp2p.Message, response gp2p.Data, requestError *requestError) error {
+	message.Content = response.GetBytes()
+	message.Error = nil
+	if requestError != nil {
+		message.Error = requestError
+	}
+	cryptMessage, err := message.Encode(server.cipherKey)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt message: %w", err)
+	}
+	if err = p.SetGob(cryptMessage); err != nil {
+		return fmt.Errorf("failed to encode package: %w", err)
+	}
+	return conn.WritePackage(p)
+}
+
+func (server *Server) isShuttingDown() bool {
+	server.shutdownMutex.Lock()
+	defer server.shutdownMutex.Unlock()
+	return server.isShutDown
+}
+
+func (server *Server) reject(connection net.Conn, topic string, reason string) {
+	server.metricsRecorder.IncrementRejectedRequests(reason)
+	logger := server.logger.With(log.TargetKey, ip(connection))
+	if topic == "" {
+		logger.Warn(fmt.Sprintf("request from %s rejected: %s", ip(connection), reason))
+	} else {
+		logger.Warn(fmt.Sprintf("%s request from %s rejected: %s", topic, ip(connection), reason))
+	}
+}
+
+func (server *Server) releaseInbound() {
+	server.inboundsMutex.Lock()
+	defer server.inboundsMutex.Unlock()
+	server.inboundsCount--
+}
+
+func (server *Server) shakeHands(conn gp2p.Conn, p gp2p.Package) error {
+	var publicKey gp2p.PublicKey
+	if err := p.GetGob(&publicKey); err != nil {
+		return fmt.Errorf("failed to decode public key: %w", err)
+	}
+	cryptCipherKey, err := publicKey.Encode(server.cipherKey)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt cipher key: %w", err)
+	}
+	if err = p.SetGob(cryptCipherKey); err != nil {
+		return fmt.Errorf("failed to encode package: %w", err)
+	}
+	return conn.WritePackage(p)
+}
+
+func (server *Server) writeError(connection net.Conn, conn gp2p.Conn) {
+	if err := conn.WritePackage(gp2p.Package{Type: gp2p.Error}); err != nil {
+		server.logger.Debug(fmt.Errorf("failed to write error response to %s: %w", ip(connection), err).Error())
+	}
+}
+
+func ip(connection net.Conn) string {
+	host, _, err := net.SplitHostPort(connection.RemoteAddr().String())
+	if err != nil {
+		return connection.RemoteAddr().String()
+	}
+	return host
+}
+
+type sizeLimitedConnection struct {
+	net.Conn
+	remainingSize int
+}
+
+func (connection *sizeLimitedConnection) Read(bytes []byte) (int, error) {
+	if connection.remainingSize <= 0 {
+		return 0, errRequestTooLarge
+	}
+	if len(bytes) > connection.remainingSize {
+		bytes = bytes[:connection.remainingSize]
+	}
+	n, err := connection.Conn.Read(bytes)
+	connection.remainingSize -= n
+	return n, err
+}
+
+type peekedConnection struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (connection *peekedConnection) Read(bytes []byte) (int, error) {
+	return connection.reader.Read(bytes)
+}
@@ -0,0 +1,235 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gp2p "github.com/leprosus/golang-p2p"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_Serve_RequestAllowed_ResponseReturned(t *testing.T) {
+	// Arrange
+	metricsRecorderMock := newMetricsRecorderMock()
+	_, neighbor := startServer(t, 0, nil, 0, metricsRecorderMock, nil)
+
+	// Act
+	response, err := neighbor.GetSettings()
+
+	// Assert
+	test.Assert(t, err == nil, fmt.Sprintf("Failed to send request: %v", err))
+	test.Assert(t, string(response) == "settings", fmt.Sprintf("Wrong response. Expected: settings - Actual: %s", response))
+	rejectedRequestsCount := len(metricsRecorderMock.IncrementRejectedRequestsCalls())
+	test.Assert(t, rejectedRequestsCount == 0, fmt.Sprintf("Wrong rejected requests count. Expected: 0 - Actual: %d", rejectedRequestsCount))
+}
+
+func Test_Serve_RequestRateExceeded_RequestRejected(t *testing.T) {
+	// Arrange
+	metricsRecorderMock := newMetricsRecorderMock()
+	_, neighbor := startServer(t, 1, nil, 0, metricsRecorderMock, nil)
+	_, _ = neighbor.GetSettings()
+
+	// Act
+	_, err := neighbor.GetSettings()
+
+	// Assert
+	test.Assert(t, err != nil, "Request is not rejected whereas it should be.")
+	test.Assert(t, strings.Contains(err.Error(), RequestRateRejection), fmt.Sprintf("Wrong error. Expected: %s - Actual: %v", RequestRateRejection, err))
+	assertRejectedRequests(t, metricsRecorderMock, RequestRateRejection)
+}
+
+func Test_Serve_TopicRequestRateExceeded_RequestRejected(t *testing.T) {
+	// Arrange
+	metricsRecorderMock := newMetricsRecorderMock()
+	_, neighbor := startServer(t, 0, map[string]int{SettingsEndpoint: 1}, 0, metricsRecorderMock, nil)
+	_, _ = neighbor.GetSettings()
+
+	// Act
+	_, err := neighbor.GetSettings()
+
+	// Assert
+	test.Assert(t, err != nil, "Request is not rejected whereas it should be.")
+	assertRejectedRequests(t, metricsRecorderMock, RequestRateRejection)
+}
+
+func Test_Serve_RequestTooLarge_RequestRejected(t *testing.T) {
+	// Arrange
+	metricsRecorderMock := newMetricsRecorderMock()
+	_, neighbor := startServer(t, 0, nil, 64, metricsRecorderMock, nil)
+
+	// Act
+	_, err := neighbor.GetSettings()
+
+	// Assert
+	test.Assert(t, err != nil, "Request is not rejected whereas it should be.")
+	test.Assert(t, !errors.Is(err, io.EOF), "Connection is closed whereas an error response should be written.")
+	assertRejectedRequests(t, metricsRecorderMock, RequestSizeRejection)
+}
+
+func Test_Serve_HandlerFails_ErrorReturned(t *testing.T) {
+	// Arrange
+	server, neighbor := startServer(t, 0, nil, 0, newMetricsRecorderMock(), nil)
+	server.SetHandle(SettingsEndpoint, func(context.Context, gp2p.Data) (gp2p.Data, error) {
+		return gp2p.Data{}, errors.New("settings unavailable")
+	})
+
+	// Act
+	_, err := neighbor.GetSettings()
+
+	// Assert
+	test.Assert(t, err != nil, "Request succeeded whereas the handler failed.")
+	test.Assert(t, strings.Contains(err.Error(), "settings unavailable"), fmt.Sprintf("Wrong error. Expected: settings unavailable - Actual: %v", err))
+}
+
+func Test_Serve_HandlerPanics_ErrorReturned(t *testing.T) {
+	// Arrange
+	server, neighbor := startServer(t, 0, nil, 0, newMetricsRecorderMock(), nil)
+	server.SetHandle(SettingsEndpoint, func(context.Context, gp2p.Data) (gp2p.Data, error) {
+		panic("unexpected")
+	})
+
+	// Act
+	_, err := neighbor.GetSettings()
+
+	// Assert
+	test.Assert(t, err != nil, "Request succeeded whereas the handler panicked.")
+	test.Assert(t, strings.Contains(err.Error(), "handler panicked"), fmt.Sprintf("Wrong error. Expected: handler panicked - Actual: %v", err))
+	server.SetHandle(SettingsEndpoint, func(context.Context, gp2p.Data) (gp2p.Data, error) {
+		return gp2p.Data{Bytes: []byte("settings")}, nil
+	})
+	_, err = neighbor.GetSettings()
+	test.Assert(t, err == nil, fmt.Sprintf("Server does not serve anymore after a handler panicked: %v", err))
+}
+
+func Test_Serve_EncryptedRequest_ResponseReturned(t *testing.T) {
+	// Arrange
+	_, neighbor := startServer(t, 0, nil, 0, newMetricsRecorderMock(), newTrustStore(t))
+
+	// Act
+	response, err := neighbor.GetSettings()
+
+	// Assert
+	test.Assert(t, err == nil, fmt.Sprintf("Failed to send request: %v", err))
+	test.Assert(t, string(response) == "settings", fmt.Sprintf("Wrong response. Expected: settings - Actual: %s", response))
+}
+
+func Test_Serve_IdentityKeyChanged_RequestFailed(t *testing.T) {
+	// Arrange
+	trustStore := newTrustStore(t)
+	_, neighbor := startServer(t, 0, nil, 0, newMetricsRecorderMock(), trustStore)
+	otherIdentity, err := LoadIdentity(filepath.Join(t.TempDir(), "identity-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = trustStore.verify(neighbor.Target(), otherIdentity.Certificate)
+
+	// Act
+	_, err = neighbor.GetSettings()
+
+	// Assert
+	test.Assert(t, err != nil, "Request succeeded whereas it should have failed.")
+}
+
+func Test_Shutdown_InFlightRequest_RequestCompleted(t *testing.T) {
+	// Arrange
+	server, neighbor := startServer(t, 0, nil, 0, newMetricsRecorderMock(), nil)
+	isHandling := make(chan struct{})
+	isReleased := make(chan struct{})
+	server.SetHandle(SettingsEndpoint, func(context.Context, gp2p.Data) (gp2p.Data, error) {
+		close(isHandling)
+		<-isReleased
+		return gp2p.Data{Bytes: []byte("settings")}, nil
+	})
+	responses := make(chan error, 1)
+	go func() {
+		_, err := neighbor.GetSettings()
+		responses <- err
+	}()
+	<-isHandling
+	shutdownErrors := make(chan error, 1)
+
+	// Act
+	go func() { shutdownErrors <- server.Shutdown(context.Background()) }()
+
+	// Assert
+	close(isReleased)
+	err := <-responses
+	test.Assert(t, err == nil, fmt.Sprintf("In-flight request failed: %v", err))
+	err = <-shutdownErrors
+	test.Assert(t, err == nil, fmt.Sprintf("Failed to shut down: %v", err))
+	_, err = neighbor.GetSettings()
+	test.Assert(t, err != nil, "Request succeeded whereas the server is shut down.")
+}
+
+func Test_Shutdown_RequestNotDrainedBeforeDeadline_ErrorReturned(t *testing.T) {
+	// Arrange
+	server, neighbor := startServer(t, 0, nil, 0, newMetricsRecorderMock(), nil)
+	isHandling := make(chan struct{})
+	isReleased := make(chan struct{})
+	defer close(isReleased)
+	server.SetHandle(SettingsEndpoint, func(context.Context, gp2p.Data) (gp2p.Data, error) {
+		close(isHandling)
+		<-isReleased
+		return gp2p.Data{}, nil
+	})
+	go func() { _, _ = neighbor.GetSettings() }()
+	<-isHandling
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err := server.Shutdown(ctx)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is nil whereas the in-flight request is not drained.")
+}
+
+func startServer(t *testing.T, maxRequestsPerSecond int, maxRequestsPerSecondByTopic map[string]int, maxRequestSize int, metricsRecorder application.MetricsRecorder, trustStore *TrustStore) (*Server, *Neighbor) {
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := LoadIdentity(filepath.Join(t.TempDir(), "identity-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := NewServer("0", time.Second, identity, 0, maxRequestsPerSecond, maxRequestsPerSecondByTopic, maxRequestSize, metricsRecorder, watchMock, log.NewLoggerMock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.SetHandle(SettingsEndpoint, func(context.Context, gp2p.Data) (gp2p.Data, error) {
+		return gp2p.Data{Bytes: []byte("settings")}, nil
+	})
+	go func() { _ = server.serve(listener) }()
+	t.Cleanup(func() { _ = listener.Close() })
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	neighbor, err := NewNeighbor("127.0.0.1", port, time.Second, trustStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, neighbor
+}
+
+func newMetricsRecorderMock() *application.MetricsRecorderMock {
+	metricsRecorderMock := new(application.MetricsRecorderMock)
+	metricsRecorderMock.IncrementRejectedRequestsFunc = func(string) {}
+	return metricsRecorderMock
+}
+
+func assertRejectedRequests(t *testing.T, metricsRecorderMock *application.MetricsRecorderMock, reason string) {
+	calls := metricsRecorderMock.IncrementRejectedRequestsCalls()
+	test.Assert(t, len(calls) == 1, fmt.Sprintf("Wrong rejected requests count. Expected: 1 - Actual: %d", len(calls)))
+	test.Assert(t, calls[0].Reason == reason, fmt.Sprintf("Wrong rejection reason. Expected: %s - Actual: %s", reason, calls[0].Reason))
+}
@@ -0,0 +1,122 @@
+package p2p
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// TrustStore authenticates the servers identity keys, either against the trusted keys or against the keys pinned on first use.
+// The authentication is one-directional: the server never authenticates its clients, which are only rate limited by IP.
+type TrustStore struct {
+	fingerprintsByTarget        map[string]string
+	mutex                       sync.Mutex
+	path                        string
+	trustedFingerprintsByTarget map[string]string
+}
+
+// NewTrustStore loads the pinned identity keys persisted at the given path, they are kept in memory only if the path is empty
+func NewTrustStore(path string, trustedFingerprintsByTarget map[string]string) (*TrustStore, error) {
+	store := &TrustStore{fingerprintsByTarget: map[string]string{}, path: path, trustedFingerprintsByTarget: trustedFingerprintsByTarget}
+	if path == "" {
+		return store, nil
+	}
+	fingerprintsBytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read pinned identity keys: %w", err)
+	}
+	if err = json.Unmarshal(fingerprintsBytes, &store.fingerprintsByTarget); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pinned identity keys: %w", err)
+	}
+	if store.fingerprintsByTarget == nil {
+		store.fingerprintsByTarget = map[string]string{}
+	}
+	return store, nil
+}
+
+// Fingerprint returns the value to configure as trusted identity key for the node presenting the given certificate
+func Fingerprint(identity tls.Certificate) (string, error) {
+	if len(identity.Certificate) == 0 {
+		return "", errors.New("no identity certificate")
+	}
+	certificate, err := x509.ParseCertificate(identity.Certificate[0])
+	if err != nil {
+		return "", fmt.Errorf("failed to parse identity certificate: %w", err)
+	}
+	return fingerprint(certificate), nil
+}
+
+func (store *TrustStore) TlsConfig(target string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		// The certificates are self-signed, they are verified against the trusted or pinned identity keys instead
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCertificates [][]byte, _ [][]*x509.Certificate) error {
+			return store.verify(target, rawCertificates)
+		},
+	}
+}
+
+func (store *TrustStore) verify(target string, rawCertificates [][]byte) error {
+	if len(rawCertificates) == 0 {
+		return errors.New("no identity certificate presented")
+	}
+	certificate, err := x509.ParseCertificate(rawCertificates[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse identity certificate: %w", err)
+	}
+	certificateFingerprint := fingerprint(certificate)
+	if trustedFingerprint, isTrusted := store.trustedFingerprintsByTarget[target]; isTrusted {
+		if trustedFingerprint != certificateFingerprint {
+			return fmt.Errorf("identity key of %s is not the trusted one", target)
+		}
+		return nil
+	}
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	pinnedFingerprint, isPinned := store.fingerprintsByTarget[target]
+	if isPinned {
+		if pinnedFingerprint != certificateFingerprint {
+			return fmt.Errorf("identity key of %s changed", target)
+		}
+		return nil
+	}
+	store.fingerprintsByTarget[target] = certificateFingerprint
+	return store.save()
+}
+
+func (store *TrustStore) save() error {
+	if store.path == "" {
+		return nil
+	}
+	fingerprintsBytes, err := json.Marshal(store.fingerprintsByTarget)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pinned identity keys: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(store.path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	temporaryPath := store.path + ".tmp"
+	if err = os.WriteFile(temporaryPath, fingerprintsBytes, 0644); err != nil {
+		return fmt.Errorf("failed to write pinned identity keys: %w", err)
+	}
+	if err = os.Rename(temporaryPath, store.path); err != nil {
+		return fmt.Errorf("failed to rename pinned identity keys file: %w", err)
+	}
+	return nil
+}
+
+func fingerprint(certificate *x509.Certificate) string {
+	publicKeyHash := sha256.Sum256(certificate.RawSubjectPublicKeyInfo)
+	return hex.EncodeToString(publicKeyHash[:])
+}
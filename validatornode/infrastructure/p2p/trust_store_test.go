@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_Verify_KeyPinnedBeforeRestart_ChangedKeyRejected(t *testing.T) {
+	// Arrange
+	directory := t.TempDir()
+	path := filepath.Join(directory, "trust-store")
+	pinnedIdentity, _ := LoadIdentity(filepath.Join(directory, "pinned-identity-key"))
+	otherIdentity, _ := LoadIdentity(filepath.Join(directory, "other-identity-key"))
+	trustStore, _ := NewTrustStore(path, nil)
+	_ = trustStore.verify("0.0.0.0:1", pinnedIdentity.Certificate)
+	restartedTrustStore, err := NewTrustStore(path, nil)
+	test.Assert(t, err == nil, "Failed to load trust store.")
+
+	// Act
+	err = restartedTrustStore.verify("0.0.0.0:1", otherIdentity.Certificate)
+
+	// Assert
+	test.Assert(t, err != nil, "Changed identity key is accepted whereas the pinned one is persisted.")
+}
+
+func Test_Verify_TrustedKey_OtherKeyRejected(t *testing.T) {
+	// Arrange
+	directory := t.TempDir()
+	trustedIdentity, _ := LoadIdentity(filepath.Join(directory, "trusted-identity-key"))
+	otherIdentity, _ := LoadIdentity(filepath.Join(directory, "other-identity-key"))
+	trustedFingerprint, _ := Fingerprint(trustedIdentity)
+	trustStore, _ := NewTrustStore("", map[string]string{"0.0.0.0:1": trustedFingerprint})
+
+	// Act
+	err := trustStore.verify("0.0.0.0:1", otherIdentity.Certificate)
+
+	// Assert
+	test.Assert(t, err != nil, "Identity key is accepted on first use whereas another one is trusted.")
+	err = trustStore.verify("0.0.0.0:1", trustedIdentity.Certificate)
+	test.Assert(t, err == nil, "Trusted identity key is rejected.")
+}
+
+func newTrustStore(t *testing.T) *TrustStore {
+	trustStore, err := NewTrustStore("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return trustStore
+}
@@ -1,6 +1,7 @@
 package poh
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -25,6 +26,21 @@ func NewHumanityRegistry(infuraKey string, logger log.Logger) *HumanityRegistry
 	return &HumanityRegistry{infuraKey}
 }
 
+func (registry *HumanityRegistry) Ping(ctx context.Context) error {
+	if registry.infuraKey == "" {
+		return nil
+	}
+	client, err := ethclient.DialContext(ctx, fmt.Sprint(clientUrl, registry.infuraKey))
+	if err != nil {
+		return fmt.Errorf("failed to reach proof of humanity registry: %w", err)
+	}
+	defer client.Close()
+	if _, err = client.BlockNumber(ctx); err != nil {
+		return fmt.Errorf("failed to reach proof of humanity registry: %w", err)
+	}
+	return nil
+}
+
 func (registry *HumanityRegistry) IsRegistered(address string) (isRegistered bool, err error) {
 	if registry.infuraKey == "" {
 		return true, nil
@@ -0,0 +1,151 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+)
+
+const (
+	pingCacheDuration = time.Minute
+	pingTimeout       = 3 * time.Second
+	readHeaderTimeout = 5 * time.Second
+)
+
+type livenessDto struct {
+	IsAlive bool `json:"is_alive"`
+}
+
+type readinessDto struct {
+	IsReady bool     `json:"is_ready"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+type statusDto struct {
+	Version            string                   `json:"version"`
+	Tip                *ledger.BlockHeader      `json:"tip"`
+	NeighborsTipHeight uint64                   `json:"neighbors_tip_height"`
+	Neighbors          []*ledger.NeighborStatus `json:"neighbors"`
+	PoolSize           int                      `json:"pool_size"`
+}
+
+type Server struct {
+	blocksManager       application.BlocksManager
+	humanityRegistry    application.Pinger
+	maxBlocksBehind     uint64
+	sendersManager      application.SendersManager
+	transactionsManager application.TransactionsManager
+	version             string
+	watch               application.TimeProvider
+	logger              log.Logger
+
+	httpServer *http.Server
+	pingMutex  sync.Mutex
+	pingError  error
+	pingTime   time.Time
+}
+
+func NewServer(port string, version string, maxBlocksBehind uint64, blocksManager application.BlocksManager, sendersManager application.SendersManager, transactionsManager application.TransactionsManager, humanityRegistry application.Pinger, watch application.TimeProvider, logger log.Logger) *Server {
+	server := &Server{
+		blocksManager:       blocksManager,
+		humanityRegistry:    humanityRegistry,
+		maxBlocksBehind:     maxBlocksBehind,
+		sendersManager:      sendersManager,
+		transactionsManager: transactionsManager,
+		version:             version,
+		watch:               watch,
+		logger:              logger,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health/liveness", server.handleLiveness)
+	mux.HandleFunc("/health/readiness", server.handleReadiness)
+	mux.HandleFunc("/status", server.handleStatus)
+	server.httpServer = &http.Server{
+		Addr:              net.JoinHostPort("0.0.0.0", port),
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	return server
+}
+
+func (server *Server) Serve() error {
+	return server.httpServer.ListenAndServe()
+}
+
+func (server *Server) Shutdown(ctx context.Context) error {
+	return server.httpServer.Shutdown(ctx)
+}
+
+func (server *Server) handleLiveness(writer http.ResponseWriter, _ *http.Request) {
+	server.writeJson(writer, http.StatusOK, &livenessDto{IsAlive: true})
+}
+
+func (server *Server) handleReadiness(writer http.ResponseWriter, req *http.Request) {
+	var reasons []string
+	var hostTipHeight uint64
+	if tip, err := server.blocksManager.Tip(); err == nil {
+		hostTipHeight = tip.Height()
+	}
+	neighborsTipHeight := server.sendersManager.NeighborsTipHeight()
+	if neighborsTipHeight > hostTipHeight+server.maxBlocksBehind {
+		reasons = append(reasons, fmt.Sprintf("blockchain is %d blocks behind the neighbors median tip", neighborsTipHeight-hostTipHeight))
+	}
+	if err := server.pingHumanityRegistry(req.Context()); err != nil {
+		reasons = append(reasons, err.Error())
+	}
+	if len(reasons) > 0 {
+		server.writeJson(writer, http.StatusServiceUnavailable, &readinessDto{Reasons: reasons})
+		return
+	}
+	server.writeJson(writer, http.StatusOK, &readinessDto{IsReady: true})
+}
+
+func (server *Server) handleStatus(writer http.ResponseWriter, _ *http.Request) {
+	status := &statusDto{
+		Version:            server.version,
+		NeighborsTipHeight: server.sendersManager.NeighborsTipHeight(),
+		Neighbors:          server.sendersManager.NeighborsStatus(),
+		PoolSize:           len(server.transactionsManager.Transactions()),
+	}
+	if tip, err := server.blocksManager.Tip(); err == nil {
+		status.Tip = tip
+	}
+	server.writeJson(writer, http.StatusOK, status)
+}
+
+func (server *Server) pingHumanityRegistry(ctx context.Context) error {
+	server.pingMutex.Lock()
+	defer server.pingMutex.Unlock()
+	// The result is cached so that frequent probes do not exhaust the registry provider quota
+	now := server.watch.Now()
+	if !server.pingTime.IsZero() && now.Sub(server.pingTime) < pingCacheDuration {
+		return server.pingError
+	}
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	server.pingError = server.humanityRegistry.Ping(ctx)
+	server.pingTime = now
+	return server.pingError
+}
+
+func (server *Server) writeJson(writer http.ResponseWriter, statusCode int, object interface{}) {
+	marshaledObject, err := json.Marshal(object)
+	if err != nil {
+		server.logger.Error(fmt.Errorf("failed to marshal status response: %w", err).Error())
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Add("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	if _, err = writer.Write(marshaledObject); err != nil {
+		server.logger.Error(fmt.Errorf("failed to write status response: %w", err).Error())
+	}
+}
@@ -0,0 +1,122 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
+)
+
+func Test_Liveness_ServerRunning_ReturnsOk(t *testing.T) {
+	// Arrange
+	server := NewServer("0", "", 0, nil, nil, nil, nil, nil, log.NewLoggerMock())
+
+	// Act
+	recorder := get(server, "/health/liveness")
+
+	// Assert
+	test.Assert(t, recorder.Code == http.StatusOK, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", http.StatusOK, recorder.Code))
+}
+
+func Test_Readiness_BlockchainSynchronizedAndRegistryReachable_ReturnsOk(t *testing.T) {
+	// Arrange
+	pingerMock := newPingerMock(nil)
+	server := NewServer("0", "", 2, newBlocksManagerMock(3), newSendersManagerMock(5), nil, pingerMock, newWatchMock(), log.NewLoggerMock())
+
+	// Act
+	recorder := get(server, "/health/readiness")
+	_ = get(server, "/health/readiness")
+
+	// Assert
+	test.Assert(t, recorder.Code == http.StatusOK, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", http.StatusOK, recorder.Code))
+	test.Assert(t, len(pingerMock.PingCalls()) == 1, "Registry reachability is not cached whereas it should be.")
+}
+
+func Test_Readiness_BlockchainBehindNeighbors_ReturnsServiceUnavailable(t *testing.T) {
+	// Arrange
+	server := NewServer("0", "", 2, newBlocksManagerMock(3), newSendersManagerMock(6), nil, newPingerMock(nil), newWatchMock(), log.NewLoggerMock())
+
+	// Act
+	recorder := get(server, "/health/readiness")
+
+	// Assert
+	test.Assert(t, recorder.Code == http.StatusServiceUnavailable, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", http.StatusServiceUnavailable, recorder.Code))
+	test.Assert(t, strings.Contains(recorder.Body.String(), "blockchain is 3 blocks behind the neighbors"), "Reason is not given whereas it should be.")
+}
+
+func Test_Readiness_RegistryUnreachable_ReturnsServiceUnavailable(t *testing.T) {
+	// Arrange
+	server := NewServer("0", "", 2, newBlocksManagerMock(3), newSendersManagerMock(3), nil, newPingerMock(errors.New("registry unreachable")), newWatchMock(), log.NewLoggerMock())
+
+	// Act
+	recorder := get(server, "/health/readiness")
+
+	// Assert
+	test.Assert(t, recorder.Code == http.StatusServiceUnavailable, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", http.StatusServiceUnavailable, recorder.Code))
+	test.Assert(t, strings.Contains(recorder.Body.String(), "registry unreachable"), "Reason is not given whereas it should be.")
+}
+
+func Test_Status_NodeRunning_ReturnsStatus(t *testing.T) {
+	// Arrange
+	transactionsManagerMock := new(application.TransactionsManagerMock)
+	transactionsManagerMock.TransactionsFunc = func() []*ledger.Transaction { return []*ledger.Transaction{{}} }
+	server := NewServer("0", "v1.0.0", 2, newBlocksManagerMock(3), newSendersManagerMock(4), transactionsManagerMock, nil, nil, log.NewLoggerMock())
+
+	// Act
+	recorder := get(server, "/status")
+
+	// Assert
+	test.Assert(t, recorder.Code == http.StatusOK, fmt.Sprintf("Wrong response status code. expected: %d actual: %d", http.StatusOK, recorder.Code))
+	var status *statusDto
+	_ = json.Unmarshal(recorder.Body.Bytes(), &status)
+	test.Assert(t, status.Version == "v1.0.0", "Wrong version.")
+	test.Assert(t, status.Tip.Height() == 3, "Wrong tip height.")
+	test.Assert(t, status.NeighborsTipHeight == 4, "Wrong neighbors tip height.")
+	test.Assert(t, len(status.Neighbors) == 1, "Wrong neighbors count.")
+	test.Assert(t, status.PoolSize == 1, "Wrong pool size.")
+}
+
+func get(server *Server, path string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	server.httpServer.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder
+}
+
+func newBlocksManagerMock(tipHeight uint64) *application.BlocksManagerMock {
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.TipFunc = func() (*ledger.BlockHeader, error) {
+		return ledger.NewBlockHeader(ledger.NewBlock([32]byte{}, nil, nil, 0, nil), tipHeight)
+	}
+	return blocksManagerMock
+}
+
+func newPingerMock(err error) *application.PingerMock {
+	pingerMock := new(application.PingerMock)
+	pingerMock.PingFunc = func(context.Context) error { return err }
+	return pingerMock
+}
+
+func newSendersManagerMock(neighborsTipHeight uint64) *application.SendersManagerMock {
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.NeighborsTipHeightFunc = func() uint64 { return neighborsTipHeight }
+	sendersManagerMock.NeighborsStatusFunc = func() []*ledger.NeighborStatus {
+		return []*ledger.NeighborStatus{ledger.NewNeighborStatus(0, true, 0, 0, "0.0.0.0:0")}
+	}
+	return sendersManagerMock
+}
+
+func newWatchMock() *application.TimeProviderMock {
+	watchMock := new(application.TimeProviderMock)
+	watchMock.NowFunc = func() time.Time { return time.Unix(0, 0) }
+	return watchMock
+}
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"crypto/sha256"
+	"errors"
 	"flag"
 	"fmt"
 	"github.com/my-cloud/ruthenium/validatornode/application/validation"
@@ -9,65 +12,182 @@ import (
 	"github.com/my-cloud/ruthenium/validatornode/presentation/api"
 	"io"
 	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/my-cloud/ruthenium/validatornode/application/network"
 	"github.com/my-cloud/ruthenium/validatornode/domain/clock"
+	"github.com/my-cloud/ruthenium/validatornode/domain/encryption"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/configuration"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/environment"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/file"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log/console"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log/structured"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/metrics"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/p2p"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/poh"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/status"
 	"github.com/my-cloud/ruthenium/validatornode/presentation"
 )
 
+// version is set at build time with -ldflags "-X main.version=<version>"
+var version = "dev"
+
+// shutdownTimeout is kept below the default Kubernetes termination grace period of 30 seconds
+const shutdownTimeout = 20 * time.Second
+
+type fatalLogger interface {
+	log.Logger
+	Fatal(msg string)
+}
+
 func main() {
+	privateKeyString := flag.String("private-key", environment.NewVariable("PRIVATE_KEY").GetStringValue(""), "The validator private key")
 	settingsPath := flag.String("settings-path", environment.NewVariable("SETTINGS_PATH").GetStringValue("validatornode/settings.json"), "The settings file path")
 	flag.Parse()
 	settings, err := configuration.NewSettings(*settingsPath)
 	if err != nil {
-		panic(err.Error())
+		console.NewFatalLogger().Fatal(err.Error())
+		os.Exit(1)
 	}
-	logger := console.NewLogger(settings.Log().Level())
-	node, err := createHostNode(settings, logger)
+	logger := newLogger(settings.Log())
+	node, err := createHostNode(*privateKeyString, settings, logger)
 	if err != nil {
 		logger.Fatal(err.Error())
-	} else if err = node.Run(); err != nil {
+		os.Exit(1)
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	runErrors := make(chan error, 1)
+	go func() {
+		runErrors <- node.Run()
+	}()
+	select {
+	case err = <-runErrors:
 		logger.Fatal(fmt.Errorf("failed to run host validator node: %w", err).Error())
+		os.Exit(1)
+	case receivedSignal := <-signals:
+		logger.Info(fmt.Sprintf("%s signal received, shutting down host validator node", receivedSignal))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	err = node.Shutdown(ctx)
+	cancel()
+	if err != nil {
+		logger.Fatal(fmt.Errorf("failed to shut down host validator node: %w", err).Error())
+		os.Exit(1)
 	}
+	logger.Info("host validator node shut down")
 }
 
-func createHostNode(settings *configuration.Settings, logger *console.Logger) (*presentation.Node, error) {
-	humanityRegistry := poh.NewHumanityRegistry(settings.Validator().InfuraKey(), logger)
-	addressesRegistry := verification.NewAddressesRegistry(humanityRegistry, logger)
+func createHostNode(privateKeyString string, settings *configuration.Settings, logger log.Logger) (*presentation.Node, error) {
+	privateKey, err := encryption.NewPrivateKeyFromHex(privateKeyString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode validator private key: %w", err)
+	}
+	validatorAddress := encryption.NewPublicKey(privateKey).Address()
+	if settings.Validator().Address() != "" && settings.Validator().Address() != validatorAddress {
+		return nil, fmt.Errorf("validator private key does not match the validator address: private key address: %s, validator address: %s", validatorAddress, settings.Validator().Address())
+	}
+	metricsRecorder := metrics.NewRecorder()
+	humanityRegistry := poh.NewHumanityRegistry(settings.Validator().InfuraKey(), logger.With(log.ComponentKey, "humanity-registry"))
+	addressesRegistry := verification.NewAddressesRegistry(humanityRegistry, metricsRecorder, logger.With(log.ComponentKey, "addresses-registry"))
 	watch := clock.NewWatch()
 	seedsStringTargets := settings.Network().Seeds()
 	scoresBySeedTargetValue := map[string]int{}
 	for _, seedStringTargetValue := range seedsStringTargets {
 		scoresBySeedTargetValue[seedStringTargetValue] = 0
 	}
-	ipFinder := net.NewIpFinderImplementation(logger)
-	neighborFactory := p2p.NewNeighborFactory(ipFinder, settings.Network().ConnectionTimeout(), console.NewFatalLogger())
+	ipFinder := net.NewIpFinderImplementation(logger.With(log.ComponentKey, "ip-finder"))
+	var trustStore *p2p.TrustStore
+	if settings.Network().Transport() == configuration.TlsTransport {
+		trustStore, err = p2p.NewTrustStore(filepath.Join(settings.Storage().Directory(), "trust-store"), settings.Network().TrustedIdentityKeys())
+		if err != nil {
+			return nil, err
+		}
+	}
+	neighborFactory := p2p.NewNeighborFactory(ipFinder, settings.Network().ConnectionTimeout(), trustStore)
 	hostIp, err := findHostPublicIp(settings.Host().Ip(), logger)
 	if err != nil {
 		return nil, err
 	}
-	neighborhood := network.NewNeighborhood(neighborFactory, hostIp, settings.Host().Port(), settings.Network().MaxOutboundsCount(), scoresBySeedTargetValue, watch)
+	protocolSettingsHash := sha256.Sum256(settings.ProtocolBytes())
+	addressBookFile := file.NewAddressBookFile(filepath.Join(settings.Storage().Directory(), "address-book"))
+	neighborhood := network.NewNeighborhood(neighborFactory, hostIp, settings.Host().Port(), settings.Network().MaxOutboundsCount(), settings.Network().NetworkId(), protocolSettingsHash, settings.Network().BanDuration(), settings.Network().BanScoreThreshold(), addressBookFile, settings.Network().AddressBookEntryLifetime(), settings.Network().AddressBookMaxFailuresCount(), scoresBySeedTargetValue, watch, logger.With(log.ComponentKey, "neighborhood"))
+	if err = neighborhood.Load(); err != nil {
+		return nil, err
+	}
 	utxosRegistry := verification.NewUtxosRegistry(settings.Protocol())
-	blockchain := verification.NewBlockchain(addressesRegistry, settings.Protocol(), neighborhood, utxosRegistry, logger)
-	transactionsPool := validation.NewTransactionsPool(blockchain, settings.Protocol(), neighborhood, utxosRegistry, settings.Validator().Address(), logger)
-	neighborhoodSynchronizationEngine := clock.NewEngine(neighborhood.Synchronize, watch, settings.Network().SynchronizationTimer(), 1, 0)
-	validationEngine := clock.NewEngine(transactionsPool.Validate, watch, settings.Protocol().ValidationTimer(), 1, 0)
-	verificationEngine := clock.NewEngine(blockchain.Update, watch, settings.Protocol().ValidationTimer(), settings.Protocol().VerificationsCountPerValidation(), 1)
-	registrySynchronizationEngine := clock.NewEngine(addressesRegistry.Synchronize, watch, settings.Registry().SynchronizationTimer(), 1, 0)
-	host, err := api.NewHost(blockchain, neighborhood, transactionsPool, utxosRegistry, settings.Host().Port(), settings.ProtocolBytes(), settings.Protocol().ValidationTimeout())
+	blocksFile := file.NewBlocksFile(filepath.Join(settings.Storage().Directory(), "blocks"))
+	snapshotFile := file.NewSnapshotFile(filepath.Join(settings.Storage().Directory(), "snapshot"))
+	blocksRelay := network.NewBlocksRelay(neighborFactory, neighborhood, settings.Network().SeenBlocksTtl(), watch, logger.With(log.ComponentKey, "blocks-relay"))
+	blockchain := verification.NewBlockchain(blocksFile, snapshotFile, settings.Storage().SnapshotInterval(), settings.Storage().IsAddressIndexEnabled(), addressesRegistry, settings.Protocol(), neighborhood, blocksRelay, utxosRegistry, metricsRecorder, logger.With(log.ComponentKey, "blockchain"))
+	if err = blockchain.Load(watch.Now().UnixNano()); err != nil {
+		return nil, err
+	}
+	neighborhood.SetBlocksManager(blockchain)
+	transactionsFile := file.NewTransactionsFile(filepath.Join(settings.Storage().Directory(), "transactions"))
+	transactionsRelay := network.NewTransactionsRelay(neighborFactory, neighborhood, settings.Network().SeenTransactionsTtl(), settings.Network().MaxRelayedTransactionsPerSecond(), watch, logger.With(log.ComponentKey, "transactions-relay"))
+	transactionsPool := validation.NewTransactionsPool(blockchain, settings.Protocol(), neighborhood, transactionsRelay, transactionsFile, utxosRegistry, settings.Pool().MaxTransactionsCount(), settings.Pool().MaxTransactionsPerBlock(), privateKey, logger.With(log.ComponentKey, "transactions-pool"))
+	if err = transactionsPool.Load(); err != nil {
+		return nil, err
+	}
+	engineLogger := logger.With(log.ComponentKey, "engine")
+	neighborhoodSynchronizationEngine := clock.NewEngine("neighborhood-synchronization", neighborhood.Synchronize, watch, settings.Network().SynchronizationTimer(), 1, 0, metricsRecorder, engineLogger)
+	transactionsRelayEngine := clock.NewEngine("transactions-relay", transactionsRelay.Relay, watch, settings.Network().RelayTimer(), 1, 0, metricsRecorder, engineLogger)
+	validationEngine := clock.NewEngine("validation", transactionsPool.Validate, watch, settings.Protocol().ValidationTimer(), 1, 0, metricsRecorder, engineLogger)
+	verificationEngine := clock.NewEngine("verification", blockchain.Update, watch, settings.Protocol().ValidationTimer(), settings.Protocol().VerificationsCountPerValidation(), 1, metricsRecorder, engineLogger)
+	registrySynchronizationEngine := clock.NewEngine("registry-synchronization", addressesRegistry.Synchronize, watch, settings.Registry().SynchronizationTimer(), 1, 0, metricsRecorder, engineLogger)
+	identity, err := p2p.LoadIdentity(filepath.Join(settings.Storage().Directory(), "identity-key"))
 	if err != nil {
 		return nil, err
 	}
-	logger.Info(fmt.Sprintf("host validator node running for address: %s", settings.Validator().Address()))
-	return presentation.NewNode(host, neighborhoodSynchronizationEngine, validationEngine, verificationEngine, registrySynchronizationEngine), nil
+	identityFingerprint, err := p2p.Fingerprint(identity)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("host identity key: %s", identityFingerprint))
+	server, err := p2p.NewServer(settings.Host().Port(), settings.Protocol().ValidationTimeout(), identity, settings.Host().MaxInboundsCount(), settings.Host().MaxRequestsPerSecond(), settings.Host().MaxRequestsPerSecondByTopic(), settings.Host().MaxRequestSize(), metricsRecorder, watch, logger.With(log.ComponentKey, "host"))
+	if err != nil {
+		return nil, err
+	}
+	host := api.NewHost(blockchain, blocksRelay, neighborhood, transactionsPool, transactionsRelay, utxosRegistry, metricsRecorder, server, settings.ProtocolBytes())
+	var shutdowners []presentation.Shutdowner
+	if settings.Metrics().IsEnabled() {
+		metricsServer := metrics.NewServer(settings.Metrics().Port(), metricsRecorder, blockchain, neighborhood, transactionsPool, watch)
+		go func() {
+			if err := metricsServer.Serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error(fmt.Errorf("failed to serve metrics: %w", err).Error())
+			}
+		}()
+		shutdowners = append(shutdowners, metricsServer)
+		logger.Info(fmt.Sprintf("metrics exposed on port %s", settings.Metrics().Port()))
+	}
+	if settings.Status().IsEnabled() {
+		statusServer := status.NewServer(settings.Status().Port(), version, settings.Status().MaxBlocksBehind(), blockchain, neighborhood, transactionsPool, humanityRegistry, watch, logger.With(log.ComponentKey, "status"))
+		go func() {
+			if err := statusServer.Serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error(fmt.Errorf("failed to serve status: %w", err).Error())
+			}
+		}()
+		shutdowners = append(shutdowners, statusServer)
+		logger.Info(fmt.Sprintf("status exposed on port %s", settings.Status().Port()))
+	}
+	logger.Info(fmt.Sprintf("host validator node running for address: %s", validatorAddress))
+	return presentation.NewNode(host, shutdowners, []presentation.Flusher{blockchain, neighborhood}, neighborhoodSynchronizationEngine, transactionsRelayEngine, validationEngine, verificationEngine, registrySynchronizationEngine), nil
+}
+
+func newLogger(settings *configuration.LogSettings) fatalLogger {
+	if settings.Format() == configuration.ConsoleLogFormat {
+		return console.NewLogger(settings.Level())
+	}
+	return structured.NewLogger(settings.Level(), settings.Format(), os.Stderr, clock.NewWatch())
 }
 
-func findHostPublicIp(ip string, logger *console.Logger) (string, error) {
+func findHostPublicIp(ip string, logger log.Logger) (string, error) {
 	if ip != "" {
 		return ip, nil
 	}
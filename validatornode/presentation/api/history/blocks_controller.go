@@ -3,17 +3,117 @@ package history
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
 
 	gp2p "github.com/leprosus/golang-p2p"
+
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/p2p"
 )
 
+const maxConcurrentFetchesCount = 16
+
 type BlocksController struct {
-	blocksManager application.BlocksManager
+	blocksManager    application.BlocksManager
+	blocksRelayer    application.BlocksRelayer
+	fetchesSemaphore chan struct{}
+}
+
+func NewBlocksController(blocksManager application.BlocksManager, blocksRelayer application.BlocksRelayer) *BlocksController {
+	return &BlocksController{blocksManager, blocksRelayer, make(chan struct{}, maxConcurrentFetchesCount)}
+}
+
+func (controller *BlocksController) HandleAddressHistoryRequest(_ context.Context, req gp2p.Data) (gp2p.Data, error) {
+	var request *ledger.AddressHistoryRequest
+	res := gp2p.Data{}
+	data := req.GetBytes()
+	if err := json.Unmarshal(data, &request); err != nil {
+		return res, err
+	}
+	if request == nil {
+		return res, errors.New("address history request is missing")
+	}
+	history, err := controller.blocksManager.AddressHistory(request.Address(), request.Offset(), request.Limit())
+	if err != nil {
+		return res, err
+	}
+	historyBytes, err := json.Marshal(history)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(historyBytes)
+	return res, nil
+}
+
+func (controller *BlocksController) HandleBlockRequest(_ context.Context, req gp2p.Data) (gp2p.Data, error) {
+	var blockHeight uint64
+	res := gp2p.Data{}
+	data := req.GetBytes()
+	if err := json.Unmarshal(data, &blockHeight); err != nil {
+		return res, err
+	}
+	block, err := controller.blocksManager.Block(blockHeight)
+	if err != nil {
+		return res, err
+	}
+	blockBytes, err := json.Marshal(block)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(blockBytes)
+	return res, nil
 }
 
-func NewBlocksController(blocksManager application.BlocksManager) *BlocksController {
-	return &BlocksController{blocksManager}
+func (controller *BlocksController) HandleBlockAnnouncementRequest(ctx context.Context, req gp2p.Data) (gp2p.Data, error) {
+	var announcement *ledger.BlockAnnouncement
+	data := req.GetBytes()
+	res := gp2p.Data{}
+	if err := json.Unmarshal(data, &announcement); err != nil {
+		return res, err
+	}
+	if announcement == nil || announcement.BlockHeader() == nil {
+		return res, errors.New("block header is missing")
+	}
+	header := announcement.BlockHeader()
+	// Only a block competing with or following the host tip can be added, the others are not worth fetching
+	tip, err := controller.blocksManager.Tip()
+	if err != nil || header.Height() < tip.Height() || header.Height() > tip.Height()+1 {
+		return res, nil
+	}
+	select {
+	case controller.fetchesSemaphore <- struct{}{}:
+	default:
+		return res, errors.New("too many announced blocks being fetched")
+	}
+	remoteIp := p2p.RemoteIp(ctx)
+	go func() {
+		defer func() { <-controller.fetchesSemaphore }()
+		broadcasterTarget := announcement.BlockBroadcasterTarget()
+		if block := controller.blocksRelayer.FetchBlock(header, broadcasterTarget, remoteIp); block != nil {
+			controller.blocksManager.AddAnnouncedBlock(block, header.Height(), broadcasterTarget)
+		}
+	}()
+	return res, nil
+}
+
+func (controller *BlocksController) HandleBlockByHashRequest(_ context.Context, req gp2p.Data) (gp2p.Data, error) {
+	var hash [32]byte
+	res := gp2p.Data{}
+	data := req.GetBytes()
+	if err := json.Unmarshal(data, &hash); err != nil {
+		return res, err
+	}
+	block, err := controller.blocksManager.BlockByHash(hash)
+	if err != nil {
+		return res, err
+	}
+	blockBytes, err := json.Marshal(block)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(blockBytes)
+	return res, nil
 }
 
 func (controller *BlocksController) HandleBlocksRequest(_ context.Context, req gp2p.Data) (gp2p.Data, error) {
@@ -32,6 +132,28 @@ func (controller *BlocksController) HandleBlocksRequest(_ context.Context, req g
 	return res, nil
 }
 
+func (controller *BlocksController) HandleBlocksRangeRequest(_ context.Context, req gp2p.Data) (gp2p.Data, error) {
+	var request *ledger.BlocksRangeRequest
+	res := gp2p.Data{}
+	data := req.GetBytes()
+	if err := json.Unmarshal(data, &request); err != nil {
+		return res, err
+	}
+	if request == nil {
+		return res, errors.New("blocks range request is missing")
+	}
+	blocks, err := controller.blocksManager.BlocksRange(request.StartingBlockHeight(), request.EndingBlockHeight())
+	if err != nil {
+		return res, err
+	}
+	blocksBytes, err := json.Marshal(blocks)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(blocksBytes)
+	return res, nil
+}
+
 func (controller *BlocksController) HandleFirstBlockTimestampRequest(_ context.Context, _ gp2p.Data) (gp2p.Data, error) {
 	res := gp2p.Data{}
 	timestamp := controller.blocksManager.FirstBlockTimestamp()
@@ -42,3 +164,74 @@ func (controller *BlocksController) HandleFirstBlockTimestampRequest(_ context.C
 	res.SetBytes(timestampBytes)
 	return res, nil
 }
+
+func (controller *BlocksController) HandleHeadersRequest(_ context.Context, req gp2p.Data) (gp2p.Data, error) {
+	var startingBlockHeight uint64
+	res := gp2p.Data{}
+	data := req.GetBytes()
+	if err := json.Unmarshal(data, &startingBlockHeight); err != nil {
+		return res, err
+	}
+	headers, err := controller.blocksManager.Headers(startingBlockHeight)
+	if err != nil {
+		return res, err
+	}
+	headersBytes, err := json.Marshal(headers)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(headersBytes)
+	return res, nil
+}
+
+func (controller *BlocksController) HandleTipRequest(_ context.Context, _ gp2p.Data) (gp2p.Data, error) {
+	res := gp2p.Data{}
+	tip, err := controller.blocksManager.Tip()
+	if err != nil {
+		return res, err
+	}
+	tipBytes, err := json.Marshal(tip)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(tipBytes)
+	return res, nil
+}
+
+func (controller *BlocksController) HandleTransactionByIdRequest(_ context.Context, req gp2p.Data) (gp2p.Data, error) {
+	var transactionId string
+	res := gp2p.Data{}
+	data := req.GetBytes()
+	if err := json.Unmarshal(data, &transactionId); err != nil {
+		return res, err
+	}
+	transaction, err := controller.blocksManager.TransactionById(transactionId)
+	if err != nil {
+		return res, err
+	}
+	transactionBytes, err := json.Marshal(transaction)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(transactionBytes)
+	return res, nil
+}
+
+func (controller *BlocksController) HandleTransactionProofRequest(_ context.Context, req gp2p.Data) (gp2p.Data, error) {
+	var transactionId string
+	res := gp2p.Data{}
+	data := req.GetBytes()
+	if err := json.Unmarshal(data, &transactionId); err != nil {
+		return res, err
+	}
+	proof, err := controller.blocksManager.TransactionProof(transactionId)
+	if err != nil {
+		return res, err
+	}
+	proofBytes, err := json.Marshal(proof)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(proofBytes)
+	return res, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/my-cloud/ruthenium/validatornode/application"
+	"sync"
 	"testing"
 
 	gp2p "github.com/leprosus/golang-p2p"
@@ -16,7 +17,7 @@ func Test_HandleFirstBlockTimestampRequest_ValidRequest_FirstBlockTimestampCalle
 	// Arrange
 	blocksManagerMock := new(application.BlocksManagerMock)
 	blocksManagerMock.FirstBlockTimestampFunc = func() int64 { return 0 }
-	controller := NewBlocksController(blocksManagerMock)
+	controller := NewBlocksController(blocksManagerMock, new(application.BlocksRelayerMock))
 	req := gp2p.Data{}
 
 	// Act
@@ -27,11 +28,124 @@ func Test_HandleFirstBlockTimestampRequest_ValidRequest_FirstBlockTimestampCalle
 	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
 }
 
+func Test_HandleAddressHistoryRequest_ValidAddressHistoryRequest_AddressHistoryCalled(t *testing.T) {
+	// Arrange
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AddressHistoryFunc = func(string, uint64, uint64) ([]*ledger.AddressHistoryEntry, error) { return nil, nil }
+	controller := NewBlocksController(blocksManagerMock, new(application.BlocksRelayerMock))
+	marshalledRequest, _ := json.Marshal(ledger.NewAddressHistoryRequest(test.Address, 0, 10))
+	req := gp2p.Data{Bytes: marshalledRequest}
+
+	// Act
+	_, _ = controller.HandleAddressHistoryRequest(context.TODO(), req)
+
+	// Assert
+	isMethodCalled := len(blocksManagerMock.AddressHistoryCalls()) == 1
+	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
+}
+
+func Test_HandleAddressHistoryRequest_NullRequest_ReturnsError(t *testing.T) {
+	// Arrange
+	blocksManagerMock := new(application.BlocksManagerMock)
+	controller := NewBlocksController(blocksManagerMock, new(application.BlocksRelayerMock))
+	req := gp2p.Data{}
+	req.SetBytes([]byte("null"))
+
+	// Act
+	_, err := controller.HandleAddressHistoryRequest(context.TODO(), req)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is nil whereas it should not.")
+	isMethodCalled := len(blocksManagerMock.AddressHistoryCalls()) != 0
+	test.Assert(t, !isMethodCalled, "Method is called whereas it should not.")
+}
+
+func Test_HandleBlockRequest_ValidBlockRequest_BlockCalled(t *testing.T) {
+	// Arrange
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.BlockFunc = func(uint64) (*ledger.Block, error) { return nil, nil }
+	controller := NewBlocksController(blocksManagerMock, new(application.BlocksRelayerMock))
+	var height uint64 = 0
+	marshalledHeight, _ := json.Marshal(&height)
+	req := gp2p.Data{Bytes: marshalledHeight}
+
+	// Act
+	_, _ = controller.HandleBlockRequest(context.TODO(), req)
+
+	// Assert
+	isMethodCalled := len(blocksManagerMock.BlockCalls()) == 1
+	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
+}
+
+func Test_HandleBlockByHashRequest_ValidBlockByHashRequest_BlockByHashCalled(t *testing.T) {
+	// Arrange
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.BlockByHashFunc = func([32]byte) (*ledger.Block, error) { return nil, nil }
+	controller := NewBlocksController(blocksManagerMock, new(application.BlocksRelayerMock))
+	var hash [32]byte
+	marshalledHash, _ := json.Marshal(hash)
+	req := gp2p.Data{Bytes: marshalledHash}
+
+	// Act
+	_, _ = controller.HandleBlockByHashRequest(context.TODO(), req)
+
+	// Assert
+	isMethodCalled := len(blocksManagerMock.BlockByHashCalls()) == 1
+	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
+}
+
+func Test_HandleBlocksRangeRequest_ValidBlocksRangeRequest_BlocksRangeCalled(t *testing.T) {
+	// Arrange
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.BlocksRangeFunc = func(uint64, uint64) ([]*ledger.Block, error) { return nil, nil }
+	controller := NewBlocksController(blocksManagerMock, new(application.BlocksRelayerMock))
+	marshalledRequest, _ := json.Marshal(ledger.NewBlocksRangeRequest(0, 1))
+	req := gp2p.Data{Bytes: marshalledRequest}
+
+	// Act
+	_, _ = controller.HandleBlocksRangeRequest(context.TODO(), req)
+
+	// Assert
+	isMethodCalled := len(blocksManagerMock.BlocksRangeCalls()) == 1
+	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
+}
+
+func Test_HandleBlocksRangeRequest_NullRequest_ReturnsError(t *testing.T) {
+	// Arrange
+	blocksManagerMock := new(application.BlocksManagerMock)
+	controller := NewBlocksController(blocksManagerMock, new(application.BlocksRelayerMock))
+	req := gp2p.Data{}
+	req.SetBytes([]byte("null"))
+
+	// Act
+	_, err := controller.HandleBlocksRangeRequest(context.TODO(), req)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is nil whereas it should not.")
+	isMethodCalled := len(blocksManagerMock.BlocksRangeCalls()) != 0
+	test.Assert(t, !isMethodCalled, "Method is called whereas it should not.")
+}
+
+func Test_HandleTipRequest_ValidRequest_TipCalled(t *testing.T) {
+	// Arrange
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.TipFunc = func() (*ledger.BlockHeader, error) { return nil, nil }
+	controller := NewBlocksController(blocksManagerMock, new(application.BlocksRelayerMock))
+	req := gp2p.Data{}
+
+	// Act
+	_, _ = controller.HandleTipRequest(context.TODO(), req)
+
+	// Assert
+	isMethodCalled := len(blocksManagerMock.TipCalls()) == 1
+	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
+}
+
 func Test_HandleBlocksRequest_ValidBlocksRequest_LastBlocksCalled(t *testing.T) {
 	// Arrange
 	blocksManagerMock := new(application.BlocksManagerMock)
 	blocksManagerMock.BlocksFunc = func(uint64) []*ledger.Block { return nil }
-	controller := NewBlocksController(blocksManagerMock)
+	controller := NewBlocksController(blocksManagerMock, new(application.BlocksRelayerMock))
 	var height uint64 = 0
 	marshalledHeight, _ := json.Marshal(&height)
 	req := gp2p.Data{Bytes: marshalledHeight}
@@ -43,3 +157,145 @@ func Test_HandleBlocksRequest_ValidBlocksRequest_LastBlocksCalled(t *testing.T)
 	isMethodCalled := len(blocksManagerMock.BlocksCalls()) == 1
 	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
 }
+
+func Test_HandleHeadersRequest_ValidHeadersRequest_HeadersCalled(t *testing.T) {
+	// Arrange
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.HeadersFunc = func(uint64) ([]*ledger.BlockHeader, error) { return nil, nil }
+	controller := NewBlocksController(blocksManagerMock, new(application.BlocksRelayerMock))
+	var height uint64 = 0
+	marshalledHeight, _ := json.Marshal(&height)
+	req := gp2p.Data{Bytes: marshalledHeight}
+
+	// Act
+	_, _ = controller.HandleHeadersRequest(context.TODO(), req)
+
+	// Assert
+	isMethodCalled := len(blocksManagerMock.HeadersCalls()) == 1
+	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
+}
+
+func Test_HandleTransactionByIdRequest_ValidTransactionByIdRequest_TransactionByIdCalled(t *testing.T) {
+	// Arrange
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.TransactionByIdFunc = func(string) (*ledger.IndexedTransaction, error) { return nil, nil }
+	controller := NewBlocksController(blocksManagerMock, new(application.BlocksRelayerMock))
+	marshalledTransactionId, _ := json.Marshal("transaction id")
+	req := gp2p.Data{Bytes: marshalledTransactionId}
+
+	// Act
+	_, _ = controller.HandleTransactionByIdRequest(context.TODO(), req)
+
+	// Assert
+	isMethodCalled := len(blocksManagerMock.TransactionByIdCalls()) == 1
+	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
+}
+
+func Test_HandleTransactionProofRequest_ValidTransactionProofRequest_TransactionProofCalled(t *testing.T) {
+	// Arrange
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.TransactionProofFunc = func(string) (*ledger.MerkleProof, error) { return nil, nil }
+	controller := NewBlocksController(blocksManagerMock, new(application.BlocksRelayerMock))
+	marshalledTransactionId, _ := json.Marshal("transaction id")
+	req := gp2p.Data{Bytes: marshalledTransactionId}
+
+	// Act
+	_, _ = controller.HandleTransactionProofRequest(context.TODO(), req)
+
+	// Assert
+	isMethodCalled := len(blocksManagerMock.TransactionProofCalls()) == 1
+	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
+}
+
+func Test_HandleBlockAnnouncementRequest_ValidAnnouncement_FetchedBlockAdded(t *testing.T) {
+	// Arrange
+	waitGroup := sync.WaitGroup{}
+	block := ledger.NewBlock([32]byte{}, nil, nil, 0, nil)
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.AddAnnouncedBlockFunc = func(*ledger.Block, uint64, string) { waitGroup.Done() }
+	blocksManagerMock.TipFunc = func() (*ledger.BlockHeader, error) { return ledger.NewBlockHeader(block, 0) }
+	blocksRelayerMock := new(application.BlocksRelayerMock)
+	blocksRelayerMock.FetchBlockFunc = func(*ledger.BlockHeader, string, string) *ledger.Block { return block }
+	controller := NewBlocksController(blocksManagerMock, blocksRelayerMock)
+	req := gp2p.Data{}
+	header, _ := ledger.NewBlockHeader(block, 1)
+	announcement := ledger.NewBlockAnnouncement(header, "0.0.0.0:0")
+	announcementBytes, _ := json.Marshal(announcement)
+	req.SetBytes(announcementBytes)
+	waitGroup.Add(1)
+
+	// Act
+	_, _ = controller.HandleBlockAnnouncementRequest(context.TODO(), req)
+
+	// Assert
+	waitGroup.Wait()
+	isFetchBlockCalled := len(blocksRelayerMock.FetchBlockCalls()) == 1
+	test.Assert(t, isFetchBlockCalled, "Method is not called whereas it should be.")
+	isAddAnnouncedBlockCalled := len(blocksManagerMock.AddAnnouncedBlockCalls()) == 1
+	test.Assert(t, isAddAnnouncedBlockCalled, "Method is not called whereas it should be.")
+}
+
+func Test_HandleBlockAnnouncementRequest_AnnouncedBlockBehindTip_BlockNotFetched(t *testing.T) {
+	// Arrange
+	block := ledger.NewBlock([32]byte{}, nil, nil, 0, nil)
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.TipFunc = func() (*ledger.BlockHeader, error) { return ledger.NewBlockHeader(block, 5) }
+	blocksRelayerMock := new(application.BlocksRelayerMock)
+	controller := NewBlocksController(blocksManagerMock, blocksRelayerMock)
+	req := gp2p.Data{}
+	header, _ := ledger.NewBlockHeader(block, 4)
+	announcement := ledger.NewBlockAnnouncement(header, "0.0.0.0:0")
+	announcementBytes, _ := json.Marshal(announcement)
+	req.SetBytes(announcementBytes)
+
+	// Act
+	_, err := controller.HandleBlockAnnouncementRequest(context.TODO(), req)
+
+	// Assert
+	test.Assert(t, err == nil, "Error is not nil whereas it should be.")
+	isFetchBlockCalled := len(blocksRelayerMock.FetchBlockCalls()) != 0
+	test.Assert(t, !isFetchBlockCalled, "Block is fetched whereas it should not.")
+}
+
+func Test_HandleBlockAnnouncementRequest_TooManyFetches_ReturnsError(t *testing.T) {
+	// Arrange
+	block := ledger.NewBlock([32]byte{}, nil, nil, 0, nil)
+	blocksManagerMock := new(application.BlocksManagerMock)
+	blocksManagerMock.TipFunc = func() (*ledger.BlockHeader, error) { return ledger.NewBlockHeader(block, 0) }
+	isReleased := make(chan struct{})
+	defer close(isReleased)
+	blocksRelayerMock := new(application.BlocksRelayerMock)
+	blocksRelayerMock.FetchBlockFunc = func(*ledger.BlockHeader, string, string) *ledger.Block {
+		<-isReleased
+		return nil
+	}
+	controller := NewBlocksController(blocksManagerMock, blocksRelayerMock)
+	req := gp2p.Data{}
+	header, _ := ledger.NewBlockHeader(block, 1)
+	announcement := ledger.NewBlockAnnouncement(header, "0.0.0.0:0")
+	announcementBytes, _ := json.Marshal(announcement)
+	req.SetBytes(announcementBytes)
+	for i := 0; i < maxConcurrentFetchesCount; i++ {
+		_, _ = controller.HandleBlockAnnouncementRequest(context.TODO(), req)
+	}
+
+	// Act
+	_, err := controller.HandleBlockAnnouncementRequest(context.TODO(), req)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is nil whereas it should not.")
+}
+
+func Test_HandleBlockAnnouncementRequest_MissingHeader_ReturnsError(t *testing.T) {
+	// Arrange
+	blocksRelayerMock := new(application.BlocksRelayerMock)
+	controller := NewBlocksController(new(application.BlocksManagerMock), blocksRelayerMock)
+	req := gp2p.Data{}
+	req.SetBytes([]byte("{}"))
+
+	// Act
+	_, err := controller.HandleBlockAnnouncementRequest(context.TODO(), req)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is nil whereas it should not.")
+}
@@ -1,22 +1,24 @@
 package api
 
 import (
-	"fmt"
+	"context"
+	"time"
+
 	gp2p "github.com/leprosus/golang-p2p"
+
 	"github.com/my-cloud/ruthenium/validatornode/application"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/p2p"
 	"github.com/my-cloud/ruthenium/validatornode/presentation/api/history"
 	"github.com/my-cloud/ruthenium/validatornode/presentation/api/network"
 	"github.com/my-cloud/ruthenium/validatornode/presentation/api/payment"
 	"github.com/my-cloud/ruthenium/validatornode/presentation/api/protocol"
 	"github.com/my-cloud/ruthenium/validatornode/presentation/api/wallet"
-	"time"
-
-	"github.com/my-cloud/ruthenium/validatornode/infrastructure/log/console"
 )
 
 type Host struct {
-	*gp2p.Server
+	*p2p.Server
 	blocksController       *history.BlocksController
+	metricsRecorder        application.MetricsRecorder
 	sendersController      *network.SendersController
 	settingsController     *protocol.SettingsController
 	transactionsController *payment.TransactionsController
@@ -24,54 +26,107 @@ type Host struct {
 }
 
 func NewHost(blocksManager application.BlocksManager,
+	blocksRelayer application.BlocksRelayer,
 	sendersManager application.SendersManager,
 	transactionsManager application.TransactionsManager,
+	transactionsRelayer application.TransactionsRelayer,
 	utxosManager application.UtxosManager,
-	hostPort string,
-	protocolSettingsBytes []byte,
-	validationTimeout time.Duration) (*Host, error) {
-	port := hostPort
-	tcp := gp2p.NewTCP("0.0.0.0", hostPort)
-	server, err := gp2p.NewServer(tcp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to instantiate host on port %s: %w", port, err)
-	}
-	server.SetLogger(console.NewFatalLogger())
-	serverSettings := gp2p.NewServerSettings()
-	serverSettings.SetConnTimeout(validationTimeout)
-	server.SetSettings(serverSettings)
-	blocksController := history.NewBlocksController(blocksManager)
+	metricsRecorder application.MetricsRecorder,
+	server *p2p.Server,
+	protocolSettingsBytes []byte) *Host {
+	blocksController := history.NewBlocksController(blocksManager, blocksRelayer)
 	sendersController := network.NewSendersController(sendersManager)
 	settingsController := protocol.NewSettingsController(protocolSettingsBytes)
-	transactionsController := payment.NewTransactionsController(sendersManager, transactionsManager)
+	transactionsController := payment.NewTransactionsController(transactionsManager, transactionsRelayer)
 	utxosController := wallet.NewUtxosController(utxosManager)
-	return &Host{server, blocksController, sendersController, settingsController, transactionsController, utxosController}, err
+	return &Host{server, blocksController, metricsRecorder, sendersController, settingsController, transactionsController, utxosController}
+}
+
+func (host *Host) SetHandleAddressHistoryRequest(endpoint string) {
+	host.setHandle(endpoint, host.blocksController.HandleAddressHistoryRequest)
+}
+
+func (host *Host) SetHandleBlockRequest(endpoint string) {
+	host.setHandle(endpoint, host.blocksController.HandleBlockRequest)
+}
+
+func (host *Host) SetHandleBlockAnnouncementRequest(endpoint string) {
+	host.setHandle(endpoint, host.blocksController.HandleBlockAnnouncementRequest)
+}
+
+func (host *Host) SetHandleBlockByHashRequest(endpoint string) {
+	host.setHandle(endpoint, host.blocksController.HandleBlockByHashRequest)
 }
 
 func (host *Host) SetHandleBlocksRequest(endpoint string) {
-	host.SetHandle(endpoint, host.blocksController.HandleBlocksRequest)
+	host.setHandle(endpoint, host.blocksController.HandleBlocksRequest)
+}
+
+func (host *Host) SetHandleBlocksRangeRequest(endpoint string) {
+	host.setHandle(endpoint, host.blocksController.HandleBlocksRangeRequest)
 }
 
 func (host *Host) SetHandleFirstBlockTimestampRequest(endpoint string) {
-	host.SetHandle(endpoint, host.blocksController.HandleFirstBlockTimestampRequest)
+	host.setHandle(endpoint, host.blocksController.HandleFirstBlockTimestampRequest)
+}
+
+func (host *Host) SetHandleHandshakeRequest(endpoint string) {
+	host.setHandle(endpoint, host.sendersController.HandleHandshakeRequest)
+}
+
+func (host *Host) SetHandleHeadersRequest(endpoint string) {
+	host.setHandle(endpoint, host.blocksController.HandleHeadersRequest)
+}
+
+func (host *Host) SetHandleNeighborsRequest(endpoint string) {
+	host.setHandle(endpoint, host.sendersController.HandleNeighborsRequest)
+}
+
+func (host *Host) SetHandlePendingTransactionsRequest(endpoint string) {
+	host.setHandle(endpoint, host.transactionsController.HandlePendingTransactionsRequest)
 }
 
 func (host *Host) SetHandleSettingsRequest(endpoint string) {
-	host.SetHandle(endpoint, host.settingsController.HandleSettingsRequest)
+	host.setHandle(endpoint, host.settingsController.HandleSettingsRequest)
 }
 
 func (host *Host) SetHandleTargetsRequest(endpoint string) {
-	host.SetHandle(endpoint, host.sendersController.HandleTargetsRequest)
+	host.setHandle(endpoint, host.sendersController.HandleTargetsRequest)
+}
+
+func (host *Host) SetHandleTipRequest(endpoint string) {
+	host.setHandle(endpoint, host.blocksController.HandleTipRequest)
 }
 
 func (host *Host) SetHandleTransactionRequest(endpoint string) {
-	host.SetHandle(endpoint, host.transactionsController.HandleTransactionRequest)
+	host.setHandle(endpoint, host.transactionsController.HandleTransactionRequest)
+}
+
+func (host *Host) SetHandleTransactionByIdRequest(endpoint string) {
+	host.setHandle(endpoint, host.blocksController.HandleTransactionByIdRequest)
+}
+
+func (host *Host) SetHandleTransactionProofRequest(endpoint string) {
+	host.setHandle(endpoint, host.blocksController.HandleTransactionProofRequest)
 }
 
 func (host *Host) SetHandleTransactionsRequest(endpoint string) {
-	host.SetHandle(endpoint, host.transactionsController.HandleTransactionsRequest)
+	host.setHandle(endpoint, host.transactionsController.HandleTransactionsRequest)
+}
+
+func (host *Host) SetHandleTransactionsAnnouncementRequest(endpoint string) {
+	host.setHandle(endpoint, host.transactionsController.HandleTransactionsAnnouncementRequest)
 }
 
 func (host *Host) SetHandleUtxosRequest(endpoint string) {
-	host.SetHandle(endpoint, host.utxosController.HandleUtxosRequest)
+	host.setHandle(endpoint, host.utxosController.HandleUtxosRequest)
+}
+
+func (host *Host) setHandle(endpoint string, handler gp2p.Handler) {
+	host.SetHandle(endpoint, func(ctx context.Context, req gp2p.Data) (gp2p.Data, error) {
+		startTime := time.Now()
+		res, err := handler(ctx, req)
+		host.metricsRecorder.ObserveRequest(endpoint, time.Since(startTime), err == nil)
+		return res, err
+	})
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/my-cloud/ruthenium/validatornode/application"
 
 	gp2p "github.com/leprosus/golang-p2p"
+
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
 )
 
 type SendersController struct {
@@ -16,6 +18,39 @@ func NewSendersController(sendersManager application.SendersManager) *SendersCon
 	return &SendersController{sendersManager}
 }
 
+func (controller *SendersController) HandleHandshakeRequest(_ context.Context, req gp2p.Data) (gp2p.Data, error) {
+	res := gp2p.Data{}
+	var neighborHandshake *ledger.Handshake
+	data := req.GetBytes()
+	if err := json.Unmarshal(data, &neighborHandshake); err != nil {
+		return res, err
+	}
+	handshake, err := controller.sendersManager.Handshake()
+	if err != nil {
+		return res, err
+	}
+	if err = handshake.VerifyCompatibility(neighborHandshake); err != nil {
+		return res, err
+	}
+	handshakeBytes, err := json.Marshal(handshake)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(handshakeBytes)
+	return res, nil
+}
+
+func (controller *SendersController) HandleNeighborsRequest(_ context.Context, _ gp2p.Data) (gp2p.Data, error) {
+	res := gp2p.Data{}
+	neighborsStatus := controller.sendersManager.NeighborsStatus()
+	neighborsStatusBytes, err := json.Marshal(neighborsStatus)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(neighborsStatusBytes)
+	return res, nil
+}
+
 func (controller *SendersController) HandleTargetsRequest(_ context.Context, req gp2p.Data) (gp2p.Data, error) {
 	res := gp2p.Data{}
 	var targets []string
@@ -3,15 +3,95 @@ package network
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/my-cloud/ruthenium/validatornode/application"
 	"sync"
 	"testing"
 
 	gp2p "github.com/leprosus/golang-p2p"
 
+	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
 )
 
+func Test_HandleHandshakeRequest_ValidHandshake_HostHandshakeReturned(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	expectedNetworkId := "mainnet"
+	sendersManagerMock.HandshakeFunc = func() (*ledger.Handshake, error) {
+		return ledger.NewHandshake([32]byte{}, expectedNetworkId, 1, [32]byte{}, nil), nil
+	}
+	controller := NewSendersController(sendersManagerMock)
+	marshalledHandshake, _ := json.Marshal(ledger.NewHandshake([32]byte{}, expectedNetworkId, 1, [32]byte{}, nil))
+	req := gp2p.Data{Bytes: marshalledHandshake}
+
+	// Act
+	res, err := controller.HandleHandshakeRequest(context.TODO(), req)
+
+	// Assert
+	test.Assert(t, err == nil, "Error is not nil whereas it should be.")
+	var handshake *ledger.Handshake
+	_ = json.Unmarshal(res.GetBytes(), &handshake)
+	actualNetworkId := handshake.NetworkId()
+	test.Assert(t, actualNetworkId == expectedNetworkId, fmt.Sprintf("Wrong network id. Expected: %s - Actual: %s", expectedNetworkId, actualNetworkId))
+}
+
+func Test_HandleHandshakeRequest_IncompatibleHandshake_ErrorReturned(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.HandshakeFunc = func() (*ledger.Handshake, error) {
+		return ledger.NewHandshake([32]byte{}, "mainnet", 1, [32]byte{}, nil), nil
+	}
+	controller := NewSendersController(sendersManagerMock)
+	marshalledHandshake, _ := json.Marshal(ledger.NewHandshake([32]byte{}, "testnet", 1, [32]byte{}, nil))
+	req := gp2p.Data{Bytes: marshalledHandshake}
+
+	// Act
+	_, err := controller.HandleHandshakeRequest(context.TODO(), req)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is nil whereas it should not.")
+}
+
+func Test_HandleHandshakeRequest_NullHandshake_ErrorReturned(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	sendersManagerMock.HandshakeFunc = func() (*ledger.Handshake, error) {
+		return ledger.NewHandshake([32]byte{}, "mainnet", 1, [32]byte{}, nil), nil
+	}
+	controller := NewSendersController(sendersManagerMock)
+	req := gp2p.Data{Bytes: []byte("null")}
+
+	// Act
+	_, err := controller.HandleHandshakeRequest(context.TODO(), req)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is nil whereas it should not.")
+}
+
+func Test_HandleNeighborsRequest_ValidRequest_NeighborsStatusReturned(t *testing.T) {
+	// Arrange
+	sendersManagerMock := new(application.SendersManagerMock)
+	expectedTarget := "0.0.0.0:1"
+	sendersManagerMock.NeighborsStatusFunc = func() []*ledger.NeighborStatus {
+		return []*ledger.NeighborStatus{ledger.NewNeighborStatus(1, false, 0, -50, expectedTarget)}
+	}
+	controller := NewSendersController(sendersManagerMock)
+
+	// Act
+	res, err := controller.HandleNeighborsRequest(context.TODO(), gp2p.Data{})
+
+	// Assert
+	test.Assert(t, err == nil, "Error is not nil whereas it should be.")
+	var neighborsStatus []*ledger.NeighborStatus
+	_ = json.Unmarshal(res.GetBytes(), &neighborsStatus)
+	expectedNeighborsCount := 1
+	test.Assert(t, len(neighborsStatus) == expectedNeighborsCount, fmt.Sprintf("Wrong neighbors count. Expected: %d - Actual: %d", expectedNeighborsCount, len(neighborsStatus)))
+	actualTarget := neighborsStatus[0].Target()
+	test.Assert(t, actualTarget == expectedTarget, fmt.Sprintf("Wrong target. Expected: %s - Actual: %s", expectedTarget, actualTarget))
+	test.Assert(t, neighborsStatus[0].IsBanned(), "Neighbor is not banned whereas it should be.")
+}
+
 func Test_HandleTargetsRequest_AddInvalidTargets_AddTargetsNotCalled(t *testing.T) {
 	// Arrange
 	sendersManagerMock := new(application.SendersManagerMock)
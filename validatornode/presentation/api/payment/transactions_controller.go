@@ -3,20 +3,41 @@ package payment
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"github.com/my-cloud/ruthenium/validatornode/application"
 
 	gp2p "github.com/leprosus/golang-p2p"
 
 	"github.com/my-cloud/ruthenium/validatornode/domain/ledger"
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/p2p"
 )
 
+const maxConcurrentFetchesCount = 16
+
 type TransactionsController struct {
-	sendersManager      application.SendersManager
+	fetchesSemaphore    chan struct{}
 	transactionsManager application.TransactionsManager
+	transactionsRelayer application.TransactionsRelayer
+}
+
+func NewTransactionsController(transactionsManager application.TransactionsManager, transactionsRelayer application.TransactionsRelayer) *TransactionsController {
+	return &TransactionsController{make(chan struct{}, maxConcurrentFetchesCount), transactionsManager, transactionsRelayer}
 }
 
-func NewTransactionsController(sendersManager application.SendersManager, transactionsManager application.TransactionsManager) *TransactionsController {
-	return &TransactionsController{sendersManager, transactionsManager}
+func (controller *TransactionsController) HandlePendingTransactionsRequest(_ context.Context, req gp2p.Data) (gp2p.Data, error) {
+	var transactionIds []string
+	data := req.GetBytes()
+	res := gp2p.Data{}
+	if err := json.Unmarshal(data, &transactionIds); err != nil {
+		return res, err
+	}
+	transactions := controller.transactionsManager.PendingTransactions(transactionIds)
+	transactionsBytes, err := json.Marshal(transactions)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(transactionsBytes)
+	return res, nil
 }
 
 func (controller *TransactionsController) HandleTransactionRequest(_ context.Context, req gp2p.Data) (gp2p.Data, error) {
@@ -26,7 +47,15 @@ func (controller *TransactionsController) HandleTransactionRequest(_ context.Con
 	if err := json.Unmarshal(data, &transactionRequest); err != nil {
 		return res, err
 	}
-	go controller.transactionsManager.AddTransaction(transactionRequest.Transaction(), transactionRequest.TransactionBroadcasterTarget(), controller.sendersManager.HostTarget())
+	if transactionRequest == nil {
+		return res, errors.New("transaction request is missing")
+	}
+	result := controller.transactionsManager.AddTransaction(transactionRequest.Transaction(), transactionRequest.TransactionBroadcasterTarget())
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(resultBytes)
 	return res, nil
 }
 
@@ -40,3 +69,30 @@ func (controller *TransactionsController) HandleTransactionsRequest(_ context.Co
 	res.SetBytes(transactionsBytes)
 	return res, nil
 }
+
+func (controller *TransactionsController) HandleTransactionsAnnouncementRequest(ctx context.Context, req gp2p.Data) (gp2p.Data, error) {
+	var announcement *ledger.TransactionsAnnouncement
+	data := req.GetBytes()
+	res := gp2p.Data{}
+	if err := json.Unmarshal(data, &announcement); err != nil {
+		return res, err
+	}
+	if announcement == nil {
+		return res, errors.New("transactions announcement is missing")
+	}
+	select {
+	case controller.fetchesSemaphore <- struct{}{}:
+	default:
+		return res, errors.New("too many announced transactions being fetched")
+	}
+	remoteIp := p2p.RemoteIp(ctx)
+	go func() {
+		defer func() { <-controller.fetchesSemaphore }()
+		broadcasterTarget := announcement.TransactionBroadcasterTarget()
+		transactions := controller.transactionsRelayer.FetchTransactions(announcement.TransactionIds(), broadcasterTarget, remoteIp)
+		for _, transaction := range transactions {
+			controller.transactionsManager.AddTransaction(transaction, broadcasterTarget)
+		}
+	}()
+	return res, nil
+}
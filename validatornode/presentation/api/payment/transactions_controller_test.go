@@ -3,6 +3,7 @@ package payment
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/my-cloud/ruthenium/validatornode/application"
 	"sync"
 	"testing"
@@ -15,33 +16,57 @@ import (
 
 func Test_HandleTransactionRequest_AddValidTransaction_AddTransactionCalled(t *testing.T) {
 	// Arrange
-	waitGroup := sync.WaitGroup{}
 	transactionsManagerMock := new(application.TransactionsManagerMock)
-	transactionsManagerMock.AddTransactionFunc = func(*ledger.Transaction, string, string) { waitGroup.Done() }
-	sendersManagerMock := new(application.SendersManagerMock)
-	sendersManagerMock.HostTargetFunc = func() string { return "" }
-	controller := NewTransactionsController(sendersManagerMock, transactionsManagerMock)
+	transactionsManagerMock.AddTransactionFunc = func(*ledger.Transaction, string) *ledger.TransactionResult {
+		return ledger.NewAcceptedTransactionResult()
+	}
+	controller := NewTransactionsController(transactionsManagerMock, nil)
 	req := gp2p.Data{}
 	transaction, _ := ledger.NewRewardTransaction("", false, 0, 0)
 	transactionBytes, _ := json.Marshal(transaction)
 	req.SetBytes(transactionBytes)
-	waitGroup.Add(1)
 
 	// Act
-	_, _ = controller.HandleTransactionRequest(context.TODO(), req)
+	res, _ := controller.HandleTransactionRequest(context.TODO(), req)
 
 	// Assert
-	waitGroup.Wait()
 	isMethodCalled := len(transactionsManagerMock.AddTransactionCalls()) == 1
 	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
+	var result *ledger.TransactionResult
+	err := json.Unmarshal(res.GetBytes(), &result)
+	test.Assert(t, err == nil, "Failed to unmarshal transaction result.")
+	test.Assert(t, result.IsAccepted(), "Transaction is not accepted whereas it should be.")
+}
+
+func Test_HandleTransactionRequest_AddRejectedTransaction_RejectionReturned(t *testing.T) {
+	// Arrange
+	transactionsManagerMock := new(application.TransactionsManagerMock)
+	transactionsManagerMock.AddTransactionFunc = func(*ledger.Transaction, string) *ledger.TransactionResult {
+		return ledger.NewRejectedTransactionResult(ledger.InvalidSignatureReasonCode, "failed to verify signature")
+	}
+	controller := NewTransactionsController(transactionsManagerMock, nil)
+	req := gp2p.Data{}
+	transaction, _ := ledger.NewRewardTransaction("", false, 0, 0)
+	transactionBytes, _ := json.Marshal(transaction)
+	req.SetBytes(transactionBytes)
+
+	// Act
+	res, _ := controller.HandleTransactionRequest(context.TODO(), req)
+
+	// Assert
+	var result *ledger.TransactionResult
+	err := json.Unmarshal(res.GetBytes(), &result)
+	test.Assert(t, err == nil, "Failed to unmarshal transaction result.")
+	test.Assert(t, !result.IsAccepted(), "Transaction is accepted whereas it should not.")
+	expectedReasonCode := ledger.InvalidSignatureReasonCode
+	actualReasonCode := result.ReasonCode()
+	test.Assert(t, actualReasonCode == expectedReasonCode, fmt.Sprintf("Wrong reason code. Expected: %s - Actual: %s", expectedReasonCode, actualReasonCode))
 }
 
 func Test_HandleTransactionRequest_AddInvalidValidTransaction_AddTransactionNotCalled(t *testing.T) {
 	// Arrange
 	transactionsManagerMock := new(application.TransactionsManagerMock)
-	sendersManagerMock := new(application.SendersManagerMock)
-	sendersManagerMock.HostTargetFunc = func() string { return "" }
-	controller := NewTransactionsController(sendersManagerMock, transactionsManagerMock)
+	controller := NewTransactionsController(transactionsManagerMock, nil)
 	req := gp2p.Data{}
 
 	// Act
@@ -57,7 +82,7 @@ func Test_HandleTransactionsRequest_ValidTransactionsRequest_TransactionsCalled(
 	// Arrange
 	transactionsManagerMock := new(application.TransactionsManagerMock)
 	transactionsManagerMock.TransactionsFunc = func() []*ledger.Transaction { return nil }
-	controller := NewTransactionsController(nil, transactionsManagerMock)
+	controller := NewTransactionsController(transactionsManagerMock, nil)
 	req := gp2p.Data{}
 
 	// Act
@@ -67,3 +92,66 @@ func Test_HandleTransactionsRequest_ValidTransactionsRequest_TransactionsCalled(
 	isMethodCalled := len(transactionsManagerMock.TransactionsCalls()) == 1
 	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
 }
+
+func Test_HandlePendingTransactionsRequest_ValidRequest_PendingTransactionsCalled(t *testing.T) {
+	// Arrange
+	transactionsManagerMock := new(application.TransactionsManagerMock)
+	transactionsManagerMock.PendingTransactionsFunc = func([]string) []*ledger.Transaction { return nil }
+	controller := NewTransactionsController(transactionsManagerMock, nil)
+	req := gp2p.Data{}
+	transactionIdsBytes, _ := json.Marshal([]string{"id"})
+	req.SetBytes(transactionIdsBytes)
+
+	// Act
+	_, err := controller.HandlePendingTransactionsRequest(context.TODO(), req)
+
+	// Assert
+	isMethodCalled := len(transactionsManagerMock.PendingTransactionsCalls()) == 1
+	test.Assert(t, err == nil, "Error is not nil whereas it should be.")
+	test.Assert(t, isMethodCalled, "Method is not called whereas it should be.")
+}
+
+func Test_HandleTransactionsAnnouncementRequest_ValidAnnouncement_FetchedTransactionsAdded(t *testing.T) {
+	// Arrange
+	waitGroup := sync.WaitGroup{}
+	transaction, _ := ledger.NewRewardTransaction("", false, 0, 0)
+	transactionsManagerMock := new(application.TransactionsManagerMock)
+	transactionsManagerMock.AddTransactionFunc = func(*ledger.Transaction, string) *ledger.TransactionResult {
+		waitGroup.Done()
+		return ledger.NewAcceptedTransactionResult()
+	}
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	transactionsRelayerMock.FetchTransactionsFunc = func([]string, string, string) []*ledger.Transaction { return []*ledger.Transaction{transaction} }
+	controller := NewTransactionsController(transactionsManagerMock, transactionsRelayerMock)
+	req := gp2p.Data{}
+	announcement := ledger.NewTransactionsAnnouncement([]string{transaction.Id()}, "0.0.0.0:0")
+	announcementBytes, _ := json.Marshal(announcement)
+	req.SetBytes(announcementBytes)
+	waitGroup.Add(1)
+
+	// Act
+	_, _ = controller.HandleTransactionsAnnouncementRequest(context.TODO(), req)
+
+	// Assert
+	waitGroup.Wait()
+	isFetchTransactionsCalled := len(transactionsRelayerMock.FetchTransactionsCalls()) == 1
+	test.Assert(t, isFetchTransactionsCalled, "Method is not called whereas it should be.")
+	isAddTransactionCalled := len(transactionsManagerMock.AddTransactionCalls()) == 1
+	test.Assert(t, isAddTransactionCalled, "Method is not called whereas it should be.")
+}
+
+func Test_HandleTransactionsAnnouncementRequest_NullAnnouncement_ErrorReturned(t *testing.T) {
+	// Arrange
+	transactionsRelayerMock := new(application.TransactionsRelayerMock)
+	controller := NewTransactionsController(nil, transactionsRelayerMock)
+	req := gp2p.Data{}
+	req.SetBytes([]byte("null"))
+
+	// Act
+	_, err := controller.HandleTransactionsAnnouncementRequest(context.TODO(), req)
+
+	// Assert
+	test.Assert(t, err != nil, "Error is nil whereas it should not.")
+	isFetchTransactionsCalled := len(transactionsRelayerMock.FetchTransactionsCalls()) != 0
+	test.Assert(t, !isFetchTransactionsCalled, "Method is called whereas it should not.")
+}
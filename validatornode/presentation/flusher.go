@@ -0,0 +1,5 @@
+package presentation
+
+type Flusher interface {
+	Flush() error
+}
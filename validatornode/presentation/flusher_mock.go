@@ -0,0 +1,67 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package presentation
+
+import (
+	"sync"
+)
+
+// Ensure, that FlusherMock does implement Flusher.
+// If this is not the case, regenerate this file with moq.
+var _ Flusher = &FlusherMock{}
+
+// FlusherMock is a mock implementation of Flusher.
+//
+//	func TestSomethingThatUsesFlusher(t *testing.T) {
+//
+//		// make and configure a mocked Flusher
+//		mockedFlusher := &FlusherMock{
+//			FlushFunc: func() error {
+//				panic("mock out the Flush method")
+//			},
+//		}
+//
+//		// use mockedFlusher in code that requires Flusher
+//		// and then make assertions.
+//
+//	}
+type FlusherMock struct {
+	// FlushFunc mocks the Flush method.
+	FlushFunc func() error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Flush holds details about calls to the Flush method.
+		Flush []struct {
+		}
+	}
+	lockFlush sync.RWMutex
+}
+
+// Flush calls FlushFunc.
+func (mock *FlusherMock) Flush() error {
+	if mock.FlushFunc == nil {
+		panic("FlusherMock.FlushFunc: method is nil but Flusher.Flush was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockFlush.Lock()
+	mock.calls.Flush = append(mock.calls.Flush, callInfo)
+	mock.lockFlush.Unlock()
+	return mock.FlushFunc()
+}
+
+// FlushCalls gets all the calls that were made to Flush.
+// Check the length with:
+//
+//	len(mockedFlusher.FlushCalls())
+func (mock *FlusherMock) FlushCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockFlush.RLock()
+	calls = mock.calls.Flush
+	mock.lockFlush.RUnlock()
+	return calls
+}
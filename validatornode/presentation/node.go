@@ -1,26 +1,83 @@
 package presentation
 
-import "github.com/my-cloud/ruthenium/validatornode/infrastructure/p2p"
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/my-cloud/ruthenium/validatornode/infrastructure/p2p"
+)
 
 type Node struct {
-	server  Server
-	engines []Pulser
+	server           Server
+	shutdowners      []Shutdowner
+	flushers         []Flusher
+	engines          []Pulser
+	enginesWaitGroup sync.WaitGroup
 }
 
-func NewNode(server Server, engines ...Pulser) *Node {
+func NewNode(server Server, shutdowners []Shutdowner, flushers []Flusher, engines ...Pulser) *Node {
+	server.SetHandleAddressHistoryRequest(p2p.AddressHistoryEndpoint)
+	server.SetHandleBlockRequest(p2p.BlockEndpoint)
+	server.SetHandleBlockAnnouncementRequest(p2p.BlockAnnouncementEndpoint)
+	server.SetHandleBlockByHashRequest(p2p.BlockByHashEndpoint)
 	server.SetHandleBlocksRequest(p2p.BlocksEndpoint)
+	server.SetHandleBlocksRangeRequest(p2p.BlocksRangeEndpoint)
 	server.SetHandleFirstBlockTimestampRequest(p2p.FirstBlockTimestampEndpoint)
+	server.SetHandleHandshakeRequest(p2p.HandshakeEndpoint)
+	server.SetHandleHeadersRequest(p2p.HeadersEndpoint)
+	server.SetHandleNeighborsRequest(p2p.NeighborsEndpoint)
+	server.SetHandlePendingTransactionsRequest(p2p.PendingTransactionsEndpoint)
 	server.SetHandleSettingsRequest(p2p.SettingsEndpoint)
 	server.SetHandleTargetsRequest(p2p.TargetsEndpoint)
+	server.SetHandleTipRequest(p2p.TipEndpoint)
 	server.SetHandleTransactionRequest(p2p.TransactionEndpoint)
+	server.SetHandleTransactionByIdRequest(p2p.TransactionByIdEndpoint)
+	server.SetHandleTransactionProofRequest(p2p.TransactionProofEndpoint)
 	server.SetHandleTransactionsRequest(p2p.TransactionsEndpoint)
+	server.SetHandleTransactionsAnnouncementRequest(p2p.TransactionsAnnouncementEndpoint)
 	server.SetHandleUtxosRequest(p2p.UtxosEndpoint)
-	return &Node{server, engines}
+	return &Node{server: server, shutdowners: shutdowners, flushers: flushers, engines: engines}
 }
 
 func (node *Node) Run() error {
 	for _, engine := range node.engines {
-		go engine.Start()
+		node.enginesWaitGroup.Add(1)
+		go func(engine Pulser) {
+			defer node.enginesWaitGroup.Done()
+			engine.Start(context.Background())
+		}(engine)
 	}
 	return node.server.Serve()
 }
+
+func (node *Node) Shutdown(ctx context.Context) error {
+	for _, engine := range node.engines {
+		engine.Stop()
+	}
+	err := node.server.Shutdown(ctx)
+	for _, shutdowner := range node.shutdowners {
+		if shutdownErr := shutdowner.Shutdown(ctx); shutdownErr != nil && err == nil {
+			err = fmt.Errorf("failed to shut down: %w", shutdownErr)
+		}
+	}
+	enginesStopped := make(chan struct{})
+	go func() {
+		node.enginesWaitGroup.Wait()
+		close(enginesStopped)
+	}()
+	select {
+	case <-enginesStopped:
+	case <-ctx.Done():
+		if err == nil {
+			err = fmt.Errorf("failed to stop engines: %w", ctx.Err())
+		}
+	}
+	// The state is flushed even if the deadline is exceeded, so that the next start resumes from the latest state
+	for _, flusher := range node.flushers {
+		if flushErr := flusher.Flush(); flushErr != nil && err == nil {
+			err = fmt.Errorf("failed to flush state: %w", flushErr)
+		}
+	}
+	return err
+}
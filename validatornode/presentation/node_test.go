@@ -1,6 +1,8 @@
 package presentation
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/my-cloud/ruthenium/validatornode/infrastructure/test"
@@ -8,24 +10,111 @@ import (
 
 func Test_Run_NoError_ServerStarted(t *testing.T) {
 	// Arrange
+	serverMock := newServerMock()
+	engineMock := new(PulserMock)
+	engineMock.StartFunc = func(context.Context) {}
+	node := NewNode(serverMock, nil, nil, engineMock)
+
+	// Act
+	_ = node.Run()
+
+	// Assert
+	isServerStarted := len(serverMock.ServeCalls()) == 1
+	test.Assert(t, isServerStarted, "Server is not started whereas it should be.")
+}
+
+func Test_Shutdown_NodeRunning_EnginesStoppedAndStateFlushed(t *testing.T) {
+	// Arrange
+	serverMock := newServerMock()
+	serverMock.ShutdownFunc = func(context.Context) error { return nil }
+	isStopped := make(chan struct{})
+	engineMock := new(PulserMock)
+	engineMock.StartFunc = func(context.Context) { <-isStopped }
+	engineMock.StopFunc = func() { close(isStopped) }
+	flusherMock := new(FlusherMock)
+	flusherMock.FlushFunc = func() error { return nil }
+	node := NewNode(serverMock, nil, []Flusher{flusherMock}, engineMock)
+	_ = node.Run()
+
+	// Act
+	err := node.Shutdown(context.Background())
+
+	// Assert
+	test.Assert(t, err == nil, "Error is returned whereas it should not.")
+	test.Assert(t, len(engineMock.StopCalls()) == 1, "Engine is not stopped whereas it should be.")
+	test.Assert(t, len(serverMock.ShutdownCalls()) == 1, "Server is not shut down whereas it should be.")
+	test.Assert(t, len(flusherMock.FlushCalls()) == 1, "State is not flushed whereas it should be.")
+}
+
+func Test_Shutdown_ServerNotDrained_StateFlushedAndErrorReturned(t *testing.T) {
+	// Arrange
+	serverMock := newServerMock()
+	serverMock.ShutdownFunc = func(context.Context) error { return errors.New("") }
+	flusherMock := new(FlusherMock)
+	flusherMock.FlushFunc = func() error { return nil }
+	node := NewNode(serverMock, nil, []Flusher{flusherMock})
+
+	// Act
+	err := node.Shutdown(context.Background())
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should be.")
+	test.Assert(t, len(flusherMock.FlushCalls()) == 1, "State is not flushed whereas it should be.")
+}
+
+func Test_Shutdown_FlushFailed_ReturnsError(t *testing.T) {
+	// Arrange
+	serverMock := newServerMock()
+	serverMock.ShutdownFunc = func(context.Context) error { return nil }
+	flusherMock := new(FlusherMock)
+	flusherMock.FlushFunc = func() error { return errors.New("") }
+	node := NewNode(serverMock, nil, []Flusher{flusherMock})
+
+	// Act
+	err := node.Shutdown(context.Background())
+
+	// Assert
+	test.Assert(t, err != nil, "Error is not returned whereas it should be.")
+}
+
+func Test_Shutdown_NodeRunning_ShutdownersShutDown(t *testing.T) {
+	// Arrange
+	serverMock := newServerMock()
+	serverMock.ShutdownFunc = func(context.Context) error { return nil }
+	shutdownerMock := new(ShutdownerMock)
+	shutdownerMock.ShutdownFunc = func(context.Context) error { return nil }
+	node := NewNode(serverMock, []Shutdowner{shutdownerMock}, nil)
+
+	// Act
+	err := node.Shutdown(context.Background())
+
+	// Assert
+	test.Assert(t, err == nil, "Error is returned whereas it should not.")
+	test.Assert(t, len(shutdownerMock.ShutdownCalls()) == 1, "Shutdowner is not shut down whereas it should be.")
+}
+
+func newServerMock() *ServerMock {
 	serverMock := new(ServerMock)
 	serverMock.ServeFunc = func() error { return nil }
+	serverMock.SetHandleAddressHistoryRequestFunc = func(string) {}
+	serverMock.SetHandleBlockRequestFunc = func(string) {}
+	serverMock.SetHandleBlockAnnouncementRequestFunc = func(string) {}
+	serverMock.SetHandleBlockByHashRequestFunc = func(string) {}
 	serverMock.SetHandleBlocksRequestFunc = func(string) {}
+	serverMock.SetHandleBlocksRangeRequestFunc = func(string) {}
 	serverMock.SetHandleFirstBlockTimestampRequestFunc = func(string) {}
+	serverMock.SetHandleHandshakeRequestFunc = func(string) {}
+	serverMock.SetHandleHeadersRequestFunc = func(string) {}
+	serverMock.SetHandleNeighborsRequestFunc = func(string) {}
+	serverMock.SetHandlePendingTransactionsRequestFunc = func(string) {}
 	serverMock.SetHandleSettingsRequestFunc = func(string) {}
 	serverMock.SetHandleTargetsRequestFunc = func(string) {}
+	serverMock.SetHandleTipRequestFunc = func(string) {}
 	serverMock.SetHandleTransactionRequestFunc = func(string) {}
+	serverMock.SetHandleTransactionByIdRequestFunc = func(string) {}
+	serverMock.SetHandleTransactionProofRequestFunc = func(string) {}
 	serverMock.SetHandleTransactionsRequestFunc = func(string) {}
+	serverMock.SetHandleTransactionsAnnouncementRequestFunc = func(string) {}
 	serverMock.SetHandleUtxosRequestFunc = func(string) {}
-	engineMock := new(PulserMock)
-	engineMock.StartFunc = func() {}
-	engineMock.PulseFunc = func() {}
-	node := NewNode(serverMock, engineMock)
-
-	// Act
-	_ = node.Run()
-
-	// Assert
-	isServerStarted := len(serverMock.ServeCalls()) == 1
-	test.Assert(t, isServerStarted, "Server is not started whereas it should be.")
+	return serverMock
 }
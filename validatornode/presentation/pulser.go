@@ -1,7 +1,9 @@
 package presentation
 
+import "context"
+
 type Pulser interface {
-	Start()
+	Start(ctx context.Context)
 	Stop()
-	Pulse()
+	Pulse(ctx context.Context)
 }
@@ -4,6 +4,7 @@
 package presentation
 
 import (
+	"context"
 	"sync"
 )
 
@@ -17,10 +18,10 @@ var _ Pulser = &PulserMock{}
 //
 //		// make and configure a mocked Pulser
 //		mockedPulser := &PulserMock{
-//			PulseFunc: func()  {
+//			PulseFunc: func(ctx context.Context)  {
 //				panic("mock out the Pulse method")
 //			},
-//			StartFunc: func()  {
+//			StartFunc: func(ctx context.Context)  {
 //				panic("mock out the Start method")
 //			},
 //			StopFunc: func()  {
@@ -34,10 +35,10 @@ var _ Pulser = &PulserMock{}
 //	}
 type PulserMock struct {
 	// PulseFunc mocks the Pulse method.
-	PulseFunc func()
+	PulseFunc func(ctx context.Context)
 
 	// StartFunc mocks the Start method.
-	StartFunc func()
+	StartFunc func(ctx context.Context)
 
 	// StopFunc mocks the Stop method.
 	StopFunc func()
@@ -46,9 +47,13 @@ type PulserMock struct {
 	calls struct {
 		// Pulse holds details about calls to the Pulse method.
 		Pulse []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 		}
 		// Start holds details about calls to the Start method.
 		Start []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 		}
 		// Stop holds details about calls to the Stop method.
 		Stop []struct {
@@ -60,16 +65,19 @@ type PulserMock struct {
 }
 
 // Pulse calls PulseFunc.
-func (mock *PulserMock) Pulse() {
+func (mock *PulserMock) Pulse(ctx context.Context) {
 	if mock.PulseFunc == nil {
 		panic("PulserMock.PulseFunc: method is nil but Pulser.Pulse was just called")
 	}
 	callInfo := struct {
-	}{}
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
 	mock.lockPulse.Lock()
 	mock.calls.Pulse = append(mock.calls.Pulse, callInfo)
 	mock.lockPulse.Unlock()
-	mock.PulseFunc()
+	mock.PulseFunc(ctx)
 }
 
 // PulseCalls gets all the calls that were made to Pulse.
@@ -77,8 +85,10 @@ func (mock *PulserMock) Pulse() {
 //
 //	len(mockedPulser.PulseCalls())
 func (mock *PulserMock) PulseCalls() []struct {
+	Ctx context.Context
 } {
 	var calls []struct {
+		Ctx context.Context
 	}
 	mock.lockPulse.RLock()
 	calls = mock.calls.Pulse
@@ -87,16 +97,19 @@ func (mock *PulserMock) PulseCalls() []struct {
 }
 
 // Start calls StartFunc.
-func (mock *PulserMock) Start() {
+func (mock *PulserMock) Start(ctx context.Context) {
 	if mock.StartFunc == nil {
 		panic("PulserMock.StartFunc: method is nil but Pulser.Start was just called")
 	}
 	callInfo := struct {
-	}{}
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
 	mock.lockStart.Lock()
 	mock.calls.Start = append(mock.calls.Start, callInfo)
 	mock.lockStart.Unlock()
-	mock.StartFunc()
+	mock.StartFunc(ctx)
 }
 
 // StartCalls gets all the calls that were made to Start.
@@ -104,8 +117,10 @@ func (mock *PulserMock) Start() {
 //
 //	len(mockedPulser.StartCalls())
 func (mock *PulserMock) StartCalls() []struct {
+	Ctx context.Context
 } {
 	var calls []struct {
+		Ctx context.Context
 	}
 	mock.lockStart.RLock()
 	calls = mock.calls.Start
@@ -1,12 +1,28 @@
 package presentation
 
+import "context"
+
 type Server interface {
 	Serve() (err error)
+	SetHandleAddressHistoryRequest(endpoint string)
+	SetHandleBlockRequest(endpoint string)
+	SetHandleBlockAnnouncementRequest(endpoint string)
+	SetHandleBlockByHashRequest(endpoint string)
 	SetHandleBlocksRequest(endpoint string)
+	SetHandleBlocksRangeRequest(endpoint string)
 	SetHandleFirstBlockTimestampRequest(endpoint string)
+	SetHandleHandshakeRequest(endpoint string)
+	SetHandleHeadersRequest(endpoint string)
+	SetHandleNeighborsRequest(endpoint string)
+	SetHandlePendingTransactionsRequest(endpoint string)
 	SetHandleSettingsRequest(endpoint string)
 	SetHandleTargetsRequest(endpoint string)
+	SetHandleTipRequest(endpoint string)
 	SetHandleTransactionRequest(endpoint string)
+	SetHandleTransactionByIdRequest(endpoint string)
+	SetHandleTransactionProofRequest(endpoint string)
 	SetHandleTransactionsRequest(endpoint string)
+	SetHandleTransactionsAnnouncementRequest(endpoint string)
 	SetHandleUtxosRequest(endpoint string)
+	Shutdown(ctx context.Context) error
 }
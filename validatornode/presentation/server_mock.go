@@ -4,6 +4,7 @@
 package presentation
 
 import (
+	"context"
 	"sync"
 )
 
@@ -18,7 +19,22 @@ var _ Server = &ServerMock{}
 //		// make and configure a mocked Server
 //		mockedServer := &ServerMock{
 //			ServeFunc: func() error {
-//				panic("mock out the Run method")
+//				panic("mock out the Serve method")
+//			},
+//			SetHandleAddressHistoryRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandleAddressHistoryRequest method")
+//			},
+//			SetHandleBlockAnnouncementRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandleBlockAnnouncementRequest method")
+//			},
+//			SetHandleBlockByHashRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandleBlockByHashRequest method")
+//			},
+//			SetHandleBlockRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandleBlockRequest method")
+//			},
+//			SetHandleBlocksRangeRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandleBlocksRangeRequest method")
 //			},
 //			SetHandleBlocksRequestFunc: func(endpoint string)  {
 //				panic("mock out the SetHandleBlocksRequest method")
@@ -26,21 +42,48 @@ var _ Server = &ServerMock{}
 //			SetHandleFirstBlockTimestampRequestFunc: func(endpoint string)  {
 //				panic("mock out the SetHandleFirstBlockTimestampRequest method")
 //			},
+//			SetHandleHandshakeRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandleHandshakeRequest method")
+//			},
+//			SetHandleHeadersRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandleHeadersRequest method")
+//			},
+//			SetHandleNeighborsRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandleNeighborsRequest method")
+//			},
+//			SetHandlePendingTransactionsRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandlePendingTransactionsRequest method")
+//			},
 //			SetHandleSettingsRequestFunc: func(endpoint string)  {
 //				panic("mock out the SetHandleSettingsRequest method")
 //			},
 //			SetHandleTargetsRequestFunc: func(endpoint string)  {
 //				panic("mock out the SetHandleTargetsRequest method")
 //			},
+//			SetHandleTipRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandleTipRequest method")
+//			},
+//			SetHandleTransactionByIdRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandleTransactionByIdRequest method")
+//			},
+//			SetHandleTransactionProofRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandleTransactionProofRequest method")
+//			},
 //			SetHandleTransactionRequestFunc: func(endpoint string)  {
 //				panic("mock out the SetHandleTransactionRequest method")
 //			},
+//			SetHandleTransactionsAnnouncementRequestFunc: func(endpoint string)  {
+//				panic("mock out the SetHandleTransactionsAnnouncementRequest method")
+//			},
 //			SetHandleTransactionsRequestFunc: func(endpoint string)  {
 //				panic("mock out the SetHandleTransactionsRequest method")
 //			},
 //			SetHandleUtxosRequestFunc: func(endpoint string)  {
 //				panic("mock out the SetHandleUtxosRequest method")
 //			},
+//			ShutdownFunc: func(ctx context.Context) error {
+//				panic("mock out the Shutdown method")
+//			},
 //		}
 //
 //		// use mockedServer in code that requires Server
@@ -51,32 +94,99 @@ type ServerMock struct {
 	// ServeFunc mocks the Serve method.
 	ServeFunc func() error
 
+	// SetHandleAddressHistoryRequestFunc mocks the SetHandleAddressHistoryRequest method.
+	SetHandleAddressHistoryRequestFunc func(endpoint string)
+
+	// SetHandleBlockAnnouncementRequestFunc mocks the SetHandleBlockAnnouncementRequest method.
+	SetHandleBlockAnnouncementRequestFunc func(endpoint string)
+
+	// SetHandleBlockByHashRequestFunc mocks the SetHandleBlockByHashRequest method.
+	SetHandleBlockByHashRequestFunc func(endpoint string)
+
+	// SetHandleBlockRequestFunc mocks the SetHandleBlockRequest method.
+	SetHandleBlockRequestFunc func(endpoint string)
+
+	// SetHandleBlocksRangeRequestFunc mocks the SetHandleBlocksRangeRequest method.
+	SetHandleBlocksRangeRequestFunc func(endpoint string)
+
 	// SetHandleBlocksRequestFunc mocks the SetHandleBlocksRequest method.
 	SetHandleBlocksRequestFunc func(endpoint string)
 
 	// SetHandleFirstBlockTimestampRequestFunc mocks the SetHandleFirstBlockTimestampRequest method.
 	SetHandleFirstBlockTimestampRequestFunc func(endpoint string)
 
+	// SetHandleHandshakeRequestFunc mocks the SetHandleHandshakeRequest method.
+	SetHandleHandshakeRequestFunc func(endpoint string)
+
+	// SetHandleHeadersRequestFunc mocks the SetHandleHeadersRequest method.
+	SetHandleHeadersRequestFunc func(endpoint string)
+
+	// SetHandleNeighborsRequestFunc mocks the SetHandleNeighborsRequest method.
+	SetHandleNeighborsRequestFunc func(endpoint string)
+
+	// SetHandlePendingTransactionsRequestFunc mocks the SetHandlePendingTransactionsRequest method.
+	SetHandlePendingTransactionsRequestFunc func(endpoint string)
+
 	// SetHandleSettingsRequestFunc mocks the SetHandleSettingsRequest method.
 	SetHandleSettingsRequestFunc func(endpoint string)
 
 	// SetHandleTargetsRequestFunc mocks the SetHandleTargetsRequest method.
 	SetHandleTargetsRequestFunc func(endpoint string)
 
+	// SetHandleTipRequestFunc mocks the SetHandleTipRequest method.
+	SetHandleTipRequestFunc func(endpoint string)
+
+	// SetHandleTransactionByIdRequestFunc mocks the SetHandleTransactionByIdRequest method.
+	SetHandleTransactionByIdRequestFunc func(endpoint string)
+
+	// SetHandleTransactionProofRequestFunc mocks the SetHandleTransactionProofRequest method.
+	SetHandleTransactionProofRequestFunc func(endpoint string)
+
 	// SetHandleTransactionRequestFunc mocks the SetHandleTransactionRequest method.
 	SetHandleTransactionRequestFunc func(endpoint string)
 
+	// SetHandleTransactionsAnnouncementRequestFunc mocks the SetHandleTransactionsAnnouncementRequest method.
+	SetHandleTransactionsAnnouncementRequestFunc func(endpoint string)
+
 	// SetHandleTransactionsRequestFunc mocks the SetHandleTransactionsRequest method.
 	SetHandleTransactionsRequestFunc func(endpoint string)
 
 	// SetHandleUtxosRequestFunc mocks the SetHandleUtxosRequest method.
 	SetHandleUtxosRequestFunc func(endpoint string)
 
+	// ShutdownFunc mocks the Shutdown method.
+	ShutdownFunc func(ctx context.Context) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Serve holds details about calls to the Serve method.
 		Serve []struct {
 		}
+		// SetHandleAddressHistoryRequest holds details about calls to the SetHandleAddressHistoryRequest method.
+		SetHandleAddressHistoryRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
+		// SetHandleBlockAnnouncementRequest holds details about calls to the SetHandleBlockAnnouncementRequest method.
+		SetHandleBlockAnnouncementRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
+		// SetHandleBlockByHashRequest holds details about calls to the SetHandleBlockByHashRequest method.
+		SetHandleBlockByHashRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
+		// SetHandleBlockRequest holds details about calls to the SetHandleBlockRequest method.
+		SetHandleBlockRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
+		// SetHandleBlocksRangeRequest holds details about calls to the SetHandleBlocksRangeRequest method.
+		SetHandleBlocksRangeRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
 		// SetHandleBlocksRequest holds details about calls to the SetHandleBlocksRequest method.
 		SetHandleBlocksRequest []struct {
 			// Endpoint is the endpoint argument value.
@@ -87,6 +197,26 @@ type ServerMock struct {
 			// Endpoint is the endpoint argument value.
 			Endpoint string
 		}
+		// SetHandleHandshakeRequest holds details about calls to the SetHandleHandshakeRequest method.
+		SetHandleHandshakeRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
+		// SetHandleHeadersRequest holds details about calls to the SetHandleHeadersRequest method.
+		SetHandleHeadersRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
+		// SetHandleNeighborsRequest holds details about calls to the SetHandleNeighborsRequest method.
+		SetHandleNeighborsRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
+		// SetHandlePendingTransactionsRequest holds details about calls to the SetHandlePendingTransactionsRequest method.
+		SetHandlePendingTransactionsRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
 		// SetHandleSettingsRequest holds details about calls to the SetHandleSettingsRequest method.
 		SetHandleSettingsRequest []struct {
 			// Endpoint is the endpoint argument value.
@@ -97,11 +227,31 @@ type ServerMock struct {
 			// Endpoint is the endpoint argument value.
 			Endpoint string
 		}
+		// SetHandleTipRequest holds details about calls to the SetHandleTipRequest method.
+		SetHandleTipRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
+		// SetHandleTransactionByIdRequest holds details about calls to the SetHandleTransactionByIdRequest method.
+		SetHandleTransactionByIdRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
+		// SetHandleTransactionProofRequest holds details about calls to the SetHandleTransactionProofRequest method.
+		SetHandleTransactionProofRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
 		// SetHandleTransactionRequest holds details about calls to the SetHandleTransactionRequest method.
 		SetHandleTransactionRequest []struct {
 			// Endpoint is the endpoint argument value.
 			Endpoint string
 		}
+		// SetHandleTransactionsAnnouncementRequest holds details about calls to the SetHandleTransactionsAnnouncementRequest method.
+		SetHandleTransactionsAnnouncementRequest []struct {
+			// Endpoint is the endpoint argument value.
+			Endpoint string
+		}
 		// SetHandleTransactionsRequest holds details about calls to the SetHandleTransactionsRequest method.
 		SetHandleTransactionsRequest []struct {
 			// Endpoint is the endpoint argument value.
@@ -112,21 +262,40 @@ type ServerMock struct {
 			// Endpoint is the endpoint argument value.
 			Endpoint string
 		}
+		// Shutdown holds details about calls to the Shutdown method.
+		Shutdown []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 	}
-	lockServe                               sync.RWMutex
-	lockSetHandleBlocksRequest              sync.RWMutex
-	lockSetHandleFirstBlockTimestampRequest sync.RWMutex
-	lockSetHandleSettingsRequest            sync.RWMutex
-	lockSetHandleTargetsRequest             sync.RWMutex
-	lockSetHandleTransactionRequest         sync.RWMutex
-	lockSetHandleTransactionsRequest        sync.RWMutex
-	lockSetHandleUtxosRequest               sync.RWMutex
+	lockServe                                    sync.RWMutex
+	lockSetHandleAddressHistoryRequest           sync.RWMutex
+	lockSetHandleBlockAnnouncementRequest        sync.RWMutex
+	lockSetHandleBlockByHashRequest              sync.RWMutex
+	lockSetHandleBlockRequest                    sync.RWMutex
+	lockSetHandleBlocksRangeRequest              sync.RWMutex
+	lockSetHandleBlocksRequest                   sync.RWMutex
+	lockSetHandleFirstBlockTimestampRequest      sync.RWMutex
+	lockSetHandleHandshakeRequest                sync.RWMutex
+	lockSetHandleHeadersRequest                  sync.RWMutex
+	lockSetHandleNeighborsRequest                sync.RWMutex
+	lockSetHandlePendingTransactionsRequest      sync.RWMutex
+	lockSetHandleSettingsRequest                 sync.RWMutex
+	lockSetHandleTargetsRequest                  sync.RWMutex
+	lockSetHandleTipRequest                      sync.RWMutex
+	lockSetHandleTransactionByIdRequest          sync.RWMutex
+	lockSetHandleTransactionProofRequest         sync.RWMutex
+	lockSetHandleTransactionRequest              sync.RWMutex
+	lockSetHandleTransactionsAnnouncementRequest sync.RWMutex
+	lockSetHandleTransactionsRequest             sync.RWMutex
+	lockSetHandleUtxosRequest                    sync.RWMutex
+	lockShutdown                                 sync.RWMutex
 }
 
 // Serve calls ServeFunc.
 func (mock *ServerMock) Serve() error {
 	if mock.ServeFunc == nil {
-		panic("ServerMock.ServeFunc: method is nil but Server.Run was just called")
+		panic("ServerMock.ServeFunc: method is nil but Server.Serve was just called")
 	}
 	callInfo := struct {
 	}{}
@@ -150,6 +319,166 @@ func (mock *ServerMock) ServeCalls() []struct {
 	return calls
 }
 
+// SetHandleAddressHistoryRequest calls SetHandleAddressHistoryRequestFunc.
+func (mock *ServerMock) SetHandleAddressHistoryRequest(endpoint string) {
+	if mock.SetHandleAddressHistoryRequestFunc == nil {
+		panic("ServerMock.SetHandleAddressHistoryRequestFunc: method is nil but Server.SetHandleAddressHistoryRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandleAddressHistoryRequest.Lock()
+	mock.calls.SetHandleAddressHistoryRequest = append(mock.calls.SetHandleAddressHistoryRequest, callInfo)
+	mock.lockSetHandleAddressHistoryRequest.Unlock()
+	mock.SetHandleAddressHistoryRequestFunc(endpoint)
+}
+
+// SetHandleAddressHistoryRequestCalls gets all the calls that were made to SetHandleAddressHistoryRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandleAddressHistoryRequestCalls())
+func (mock *ServerMock) SetHandleAddressHistoryRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandleAddressHistoryRequest.RLock()
+	calls = mock.calls.SetHandleAddressHistoryRequest
+	mock.lockSetHandleAddressHistoryRequest.RUnlock()
+	return calls
+}
+
+// SetHandleBlockAnnouncementRequest calls SetHandleBlockAnnouncementRequestFunc.
+func (mock *ServerMock) SetHandleBlockAnnouncementRequest(endpoint string) {
+	if mock.SetHandleBlockAnnouncementRequestFunc == nil {
+		panic("ServerMock.SetHandleBlockAnnouncementRequestFunc: method is nil but Server.SetHandleBlockAnnouncementRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandleBlockAnnouncementRequest.Lock()
+	mock.calls.SetHandleBlockAnnouncementRequest = append(mock.calls.SetHandleBlockAnnouncementRequest, callInfo)
+	mock.lockSetHandleBlockAnnouncementRequest.Unlock()
+	mock.SetHandleBlockAnnouncementRequestFunc(endpoint)
+}
+
+// SetHandleBlockAnnouncementRequestCalls gets all the calls that were made to SetHandleBlockAnnouncementRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandleBlockAnnouncementRequestCalls())
+func (mock *ServerMock) SetHandleBlockAnnouncementRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandleBlockAnnouncementRequest.RLock()
+	calls = mock.calls.SetHandleBlockAnnouncementRequest
+	mock.lockSetHandleBlockAnnouncementRequest.RUnlock()
+	return calls
+}
+
+// SetHandleBlockByHashRequest calls SetHandleBlockByHashRequestFunc.
+func (mock *ServerMock) SetHandleBlockByHashRequest(endpoint string) {
+	if mock.SetHandleBlockByHashRequestFunc == nil {
+		panic("ServerMock.SetHandleBlockByHashRequestFunc: method is nil but Server.SetHandleBlockByHashRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandleBlockByHashRequest.Lock()
+	mock.calls.SetHandleBlockByHashRequest = append(mock.calls.SetHandleBlockByHashRequest, callInfo)
+	mock.lockSetHandleBlockByHashRequest.Unlock()
+	mock.SetHandleBlockByHashRequestFunc(endpoint)
+}
+
+// SetHandleBlockByHashRequestCalls gets all the calls that were made to SetHandleBlockByHashRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandleBlockByHashRequestCalls())
+func (mock *ServerMock) SetHandleBlockByHashRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandleBlockByHashRequest.RLock()
+	calls = mock.calls.SetHandleBlockByHashRequest
+	mock.lockSetHandleBlockByHashRequest.RUnlock()
+	return calls
+}
+
+// SetHandleBlockRequest calls SetHandleBlockRequestFunc.
+func (mock *ServerMock) SetHandleBlockRequest(endpoint string) {
+	if mock.SetHandleBlockRequestFunc == nil {
+		panic("ServerMock.SetHandleBlockRequestFunc: method is nil but Server.SetHandleBlockRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandleBlockRequest.Lock()
+	mock.calls.SetHandleBlockRequest = append(mock.calls.SetHandleBlockRequest, callInfo)
+	mock.lockSetHandleBlockRequest.Unlock()
+	mock.SetHandleBlockRequestFunc(endpoint)
+}
+
+// SetHandleBlockRequestCalls gets all the calls that were made to SetHandleBlockRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandleBlockRequestCalls())
+func (mock *ServerMock) SetHandleBlockRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandleBlockRequest.RLock()
+	calls = mock.calls.SetHandleBlockRequest
+	mock.lockSetHandleBlockRequest.RUnlock()
+	return calls
+}
+
+// SetHandleBlocksRangeRequest calls SetHandleBlocksRangeRequestFunc.
+func (mock *ServerMock) SetHandleBlocksRangeRequest(endpoint string) {
+	if mock.SetHandleBlocksRangeRequestFunc == nil {
+		panic("ServerMock.SetHandleBlocksRangeRequestFunc: method is nil but Server.SetHandleBlocksRangeRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandleBlocksRangeRequest.Lock()
+	mock.calls.SetHandleBlocksRangeRequest = append(mock.calls.SetHandleBlocksRangeRequest, callInfo)
+	mock.lockSetHandleBlocksRangeRequest.Unlock()
+	mock.SetHandleBlocksRangeRequestFunc(endpoint)
+}
+
+// SetHandleBlocksRangeRequestCalls gets all the calls that were made to SetHandleBlocksRangeRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandleBlocksRangeRequestCalls())
+func (mock *ServerMock) SetHandleBlocksRangeRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandleBlocksRangeRequest.RLock()
+	calls = mock.calls.SetHandleBlocksRangeRequest
+	mock.lockSetHandleBlocksRangeRequest.RUnlock()
+	return calls
+}
+
 // SetHandleBlocksRequest calls SetHandleBlocksRequestFunc.
 func (mock *ServerMock) SetHandleBlocksRequest(endpoint string) {
 	if mock.SetHandleBlocksRequestFunc == nil {
@@ -214,6 +543,134 @@ func (mock *ServerMock) SetHandleFirstBlockTimestampRequestCalls() []struct {
 	return calls
 }
 
+// SetHandleHandshakeRequest calls SetHandleHandshakeRequestFunc.
+func (mock *ServerMock) SetHandleHandshakeRequest(endpoint string) {
+	if mock.SetHandleHandshakeRequestFunc == nil {
+		panic("ServerMock.SetHandleHandshakeRequestFunc: method is nil but Server.SetHandleHandshakeRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandleHandshakeRequest.Lock()
+	mock.calls.SetHandleHandshakeRequest = append(mock.calls.SetHandleHandshakeRequest, callInfo)
+	mock.lockSetHandleHandshakeRequest.Unlock()
+	mock.SetHandleHandshakeRequestFunc(endpoint)
+}
+
+// SetHandleHandshakeRequestCalls gets all the calls that were made to SetHandleHandshakeRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandleHandshakeRequestCalls())
+func (mock *ServerMock) SetHandleHandshakeRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandleHandshakeRequest.RLock()
+	calls = mock.calls.SetHandleHandshakeRequest
+	mock.lockSetHandleHandshakeRequest.RUnlock()
+	return calls
+}
+
+// SetHandleHeadersRequest calls SetHandleHeadersRequestFunc.
+func (mock *ServerMock) SetHandleHeadersRequest(endpoint string) {
+	if mock.SetHandleHeadersRequestFunc == nil {
+		panic("ServerMock.SetHandleHeadersRequestFunc: method is nil but Server.SetHandleHeadersRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandleHeadersRequest.Lock()
+	mock.calls.SetHandleHeadersRequest = append(mock.calls.SetHandleHeadersRequest, callInfo)
+	mock.lockSetHandleHeadersRequest.Unlock()
+	mock.SetHandleHeadersRequestFunc(endpoint)
+}
+
+// SetHandleHeadersRequestCalls gets all the calls that were made to SetHandleHeadersRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandleHeadersRequestCalls())
+func (mock *ServerMock) SetHandleHeadersRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandleHeadersRequest.RLock()
+	calls = mock.calls.SetHandleHeadersRequest
+	mock.lockSetHandleHeadersRequest.RUnlock()
+	return calls
+}
+
+// SetHandleNeighborsRequest calls SetHandleNeighborsRequestFunc.
+func (mock *ServerMock) SetHandleNeighborsRequest(endpoint string) {
+	if mock.SetHandleNeighborsRequestFunc == nil {
+		panic("ServerMock.SetHandleNeighborsRequestFunc: method is nil but Server.SetHandleNeighborsRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandleNeighborsRequest.Lock()
+	mock.calls.SetHandleNeighborsRequest = append(mock.calls.SetHandleNeighborsRequest, callInfo)
+	mock.lockSetHandleNeighborsRequest.Unlock()
+	mock.SetHandleNeighborsRequestFunc(endpoint)
+}
+
+// SetHandleNeighborsRequestCalls gets all the calls that were made to SetHandleNeighborsRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandleNeighborsRequestCalls())
+func (mock *ServerMock) SetHandleNeighborsRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandleNeighborsRequest.RLock()
+	calls = mock.calls.SetHandleNeighborsRequest
+	mock.lockSetHandleNeighborsRequest.RUnlock()
+	return calls
+}
+
+// SetHandlePendingTransactionsRequest calls SetHandlePendingTransactionsRequestFunc.
+func (mock *ServerMock) SetHandlePendingTransactionsRequest(endpoint string) {
+	if mock.SetHandlePendingTransactionsRequestFunc == nil {
+		panic("ServerMock.SetHandlePendingTransactionsRequestFunc: method is nil but Server.SetHandlePendingTransactionsRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandlePendingTransactionsRequest.Lock()
+	mock.calls.SetHandlePendingTransactionsRequest = append(mock.calls.SetHandlePendingTransactionsRequest, callInfo)
+	mock.lockSetHandlePendingTransactionsRequest.Unlock()
+	mock.SetHandlePendingTransactionsRequestFunc(endpoint)
+}
+
+// SetHandlePendingTransactionsRequestCalls gets all the calls that were made to SetHandlePendingTransactionsRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandlePendingTransactionsRequestCalls())
+func (mock *ServerMock) SetHandlePendingTransactionsRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandlePendingTransactionsRequest.RLock()
+	calls = mock.calls.SetHandlePendingTransactionsRequest
+	mock.lockSetHandlePendingTransactionsRequest.RUnlock()
+	return calls
+}
+
 // SetHandleSettingsRequest calls SetHandleSettingsRequestFunc.
 func (mock *ServerMock) SetHandleSettingsRequest(endpoint string) {
 	if mock.SetHandleSettingsRequestFunc == nil {
@@ -278,6 +735,102 @@ func (mock *ServerMock) SetHandleTargetsRequestCalls() []struct {
 	return calls
 }
 
+// SetHandleTipRequest calls SetHandleTipRequestFunc.
+func (mock *ServerMock) SetHandleTipRequest(endpoint string) {
+	if mock.SetHandleTipRequestFunc == nil {
+		panic("ServerMock.SetHandleTipRequestFunc: method is nil but Server.SetHandleTipRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandleTipRequest.Lock()
+	mock.calls.SetHandleTipRequest = append(mock.calls.SetHandleTipRequest, callInfo)
+	mock.lockSetHandleTipRequest.Unlock()
+	mock.SetHandleTipRequestFunc(endpoint)
+}
+
+// SetHandleTipRequestCalls gets all the calls that were made to SetHandleTipRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandleTipRequestCalls())
+func (mock *ServerMock) SetHandleTipRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandleTipRequest.RLock()
+	calls = mock.calls.SetHandleTipRequest
+	mock.lockSetHandleTipRequest.RUnlock()
+	return calls
+}
+
+// SetHandleTransactionByIdRequest calls SetHandleTransactionByIdRequestFunc.
+func (mock *ServerMock) SetHandleTransactionByIdRequest(endpoint string) {
+	if mock.SetHandleTransactionByIdRequestFunc == nil {
+		panic("ServerMock.SetHandleTransactionByIdRequestFunc: method is nil but Server.SetHandleTransactionByIdRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandleTransactionByIdRequest.Lock()
+	mock.calls.SetHandleTransactionByIdRequest = append(mock.calls.SetHandleTransactionByIdRequest, callInfo)
+	mock.lockSetHandleTransactionByIdRequest.Unlock()
+	mock.SetHandleTransactionByIdRequestFunc(endpoint)
+}
+
+// SetHandleTransactionByIdRequestCalls gets all the calls that were made to SetHandleTransactionByIdRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandleTransactionByIdRequestCalls())
+func (mock *ServerMock) SetHandleTransactionByIdRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandleTransactionByIdRequest.RLock()
+	calls = mock.calls.SetHandleTransactionByIdRequest
+	mock.lockSetHandleTransactionByIdRequest.RUnlock()
+	return calls
+}
+
+// SetHandleTransactionProofRequest calls SetHandleTransactionProofRequestFunc.
+func (mock *ServerMock) SetHandleTransactionProofRequest(endpoint string) {
+	if mock.SetHandleTransactionProofRequestFunc == nil {
+		panic("ServerMock.SetHandleTransactionProofRequestFunc: method is nil but Server.SetHandleTransactionProofRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandleTransactionProofRequest.Lock()
+	mock.calls.SetHandleTransactionProofRequest = append(mock.calls.SetHandleTransactionProofRequest, callInfo)
+	mock.lockSetHandleTransactionProofRequest.Unlock()
+	mock.SetHandleTransactionProofRequestFunc(endpoint)
+}
+
+// SetHandleTransactionProofRequestCalls gets all the calls that were made to SetHandleTransactionProofRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandleTransactionProofRequestCalls())
+func (mock *ServerMock) SetHandleTransactionProofRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandleTransactionProofRequest.RLock()
+	calls = mock.calls.SetHandleTransactionProofRequest
+	mock.lockSetHandleTransactionProofRequest.RUnlock()
+	return calls
+}
+
 // SetHandleTransactionRequest calls SetHandleTransactionRequestFunc.
 func (mock *ServerMock) SetHandleTransactionRequest(endpoint string) {
 	if mock.SetHandleTransactionRequestFunc == nil {
@@ -310,6 +863,38 @@ func (mock *ServerMock) SetHandleTransactionRequestCalls() []struct {
 	return calls
 }
 
+// SetHandleTransactionsAnnouncementRequest calls SetHandleTransactionsAnnouncementRequestFunc.
+func (mock *ServerMock) SetHandleTransactionsAnnouncementRequest(endpoint string) {
+	if mock.SetHandleTransactionsAnnouncementRequestFunc == nil {
+		panic("ServerMock.SetHandleTransactionsAnnouncementRequestFunc: method is nil but Server.SetHandleTransactionsAnnouncementRequest was just called")
+	}
+	callInfo := struct {
+		Endpoint string
+	}{
+		Endpoint: endpoint,
+	}
+	mock.lockSetHandleTransactionsAnnouncementRequest.Lock()
+	mock.calls.SetHandleTransactionsAnnouncementRequest = append(mock.calls.SetHandleTransactionsAnnouncementRequest, callInfo)
+	mock.lockSetHandleTransactionsAnnouncementRequest.Unlock()
+	mock.SetHandleTransactionsAnnouncementRequestFunc(endpoint)
+}
+
+// SetHandleTransactionsAnnouncementRequestCalls gets all the calls that were made to SetHandleTransactionsAnnouncementRequest.
+// Check the length with:
+//
+//	len(mockedServer.SetHandleTransactionsAnnouncementRequestCalls())
+func (mock *ServerMock) SetHandleTransactionsAnnouncementRequestCalls() []struct {
+	Endpoint string
+} {
+	var calls []struct {
+		Endpoint string
+	}
+	mock.lockSetHandleTransactionsAnnouncementRequest.RLock()
+	calls = mock.calls.SetHandleTransactionsAnnouncementRequest
+	mock.lockSetHandleTransactionsAnnouncementRequest.RUnlock()
+	return calls
+}
+
 // SetHandleTransactionsRequest calls SetHandleTransactionsRequestFunc.
 func (mock *ServerMock) SetHandleTransactionsRequest(endpoint string) {
 	if mock.SetHandleTransactionsRequestFunc == nil {
@@ -373,3 +958,35 @@ func (mock *ServerMock) SetHandleUtxosRequestCalls() []struct {
 	mock.lockSetHandleUtxosRequest.RUnlock()
 	return calls
 }
+
+// Shutdown calls ShutdownFunc.
+func (mock *ServerMock) Shutdown(ctx context.Context) error {
+	if mock.ShutdownFunc == nil {
+		panic("ServerMock.ShutdownFunc: method is nil but Server.Shutdown was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockShutdown.Lock()
+	mock.calls.Shutdown = append(mock.calls.Shutdown, callInfo)
+	mock.lockShutdown.Unlock()
+	return mock.ShutdownFunc(ctx)
+}
+
+// ShutdownCalls gets all the calls that were made to Shutdown.
+// Check the length with:
+//
+//	len(mockedServer.ShutdownCalls())
+func (mock *ServerMock) ShutdownCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockShutdown.RLock()
+	calls = mock.calls.Shutdown
+	mock.lockShutdown.RUnlock()
+	return calls
+}
@@ -0,0 +1,7 @@
+package presentation
+
+import "context"
+
+type Shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
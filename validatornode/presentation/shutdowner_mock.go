@@ -0,0 +1,75 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package presentation
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that ShutdownerMock does implement Shutdowner.
+// If this is not the case, regenerate this file with moq.
+var _ Shutdowner = &ShutdownerMock{}
+
+// ShutdownerMock is a mock implementation of Shutdowner.
+//
+//	func TestSomethingThatUsesShutdowner(t *testing.T) {
+//
+//		// make and configure a mocked Shutdowner
+//		mockedShutdowner := &ShutdownerMock{
+//			ShutdownFunc: func(ctx context.Context) error {
+//				panic("mock out the Shutdown method")
+//			},
+//		}
+//
+//		// use mockedShutdowner in code that requires Shutdowner
+//		// and then make assertions.
+//
+//	}
+type ShutdownerMock struct {
+	// ShutdownFunc mocks the Shutdown method.
+	ShutdownFunc func(ctx context.Context) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Shutdown holds details about calls to the Shutdown method.
+		Shutdown []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockShutdown sync.RWMutex
+}
+
+// Shutdown calls ShutdownFunc.
+func (mock *ShutdownerMock) Shutdown(ctx context.Context) error {
+	if mock.ShutdownFunc == nil {
+		panic("ShutdownerMock.ShutdownFunc: method is nil but Shutdowner.Shutdown was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockShutdown.Lock()
+	mock.calls.Shutdown = append(mock.calls.Shutdown, callInfo)
+	mock.lockShutdown.Unlock()
+	return mock.ShutdownFunc(ctx)
+}
+
+// ShutdownCalls gets all the calls that were made to Shutdown.
+// Check the length with:
+//
+//	len(mockedShutdowner.ShutdownCalls())
+func (mock *ShutdownerMock) ShutdownCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockShutdown.RLock()
+	calls = mock.calls.Shutdown
+	mock.lockShutdown.RUnlock()
+	return calls
+}